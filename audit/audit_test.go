@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testEvent = Event{
+	Time:          time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC),
+	Action:        "reject",
+	UserID:        "publisher@ons.gov.uk",
+	DatasetID:     "cpih01",
+	EditionID:     "time-series",
+	VersionID:     "3",
+	PreviousState: "associated",
+	NewState:      "edition-confirmed",
+	Result:        ResultSuccess,
+	Reason:        "Totals do not match the release",
+	RequestID:     "request-1",
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	Convey("Given a JSON lines sink", t, func() {
+		var buf bytes.Buffer
+		sink := NewJSONLinesSink(&buf)
+
+		Convey("When an event is emitted", func() {
+			So(sink.Emit(t.Context(), testEvent), ShouldBeNil)
+
+			Convey("Then it is written as a single line with the audit schema", func() {
+				So(bytes.Count(buf.Bytes(), []byte("\n")), ShouldEqual, 1)
+
+				var fields map[string]interface{}
+				So(json.Unmarshal(buf.Bytes(), &fields), ShouldBeNil)
+				So(fields, ShouldResemble, map[string]interface{}{
+					"event":          "version state audit",
+					"time":           "2026-03-04T09:30:00Z",
+					"action":         "reject",
+					"user_id":        "publisher@ons.gov.uk",
+					"dataset_id":     "cpih01",
+					"edition_id":     "time-series",
+					"version_id":     "3",
+					"previous_state": "associated",
+					"new_state":      "edition-confirmed",
+					"result":         "success",
+					"reason":         "Totals do not match the release",
+					"request_id":     "request-1",
+				})
+			})
+		})
+
+		Convey("When an event without a time or reason is emitted", func() {
+			e := testEvent
+			e.Time = time.Time{}
+			e.Reason = ""
+			So(sink.Emit(t.Context(), e), ShouldBeNil)
+
+			Convey("Then it is timestamped and the reason and cause are omitted", func() {
+				var fields map[string]interface{}
+				So(json.Unmarshal(buf.Bytes(), &fields), ShouldBeNil)
+				So(fields["time"], ShouldNotEqual, "0001-01-01T00:00:00Z")
+				So(fields, ShouldNotContainKey, "reason")
+				So(fields, ShouldNotContainKey, "cause")
+			})
+		})
+	})
+}
+
+func TestFileSink(t *testing.T) {
+	Convey("Given a file sink for a file which already has events", t, func() {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		So(os.WriteFile(path, []byte("{}\n"), 0o600), ShouldBeNil)
+
+		sink, err := NewFileSink(path)
+		So(err, ShouldBeNil)
+
+		Convey("When events are emitted", func() {
+			So(sink.Emit(t.Context(), testEvent), ShouldBeNil)
+			So(sink.Emit(t.Context(), testEvent), ShouldBeNil)
+			So(sink.Close(), ShouldBeNil)
+
+			Convey("Then they are appended to the file as JSON lines", func() {
+				f, err := os.Open(path)
+				So(err, ShouldBeNil)
+				defer f.Close()
+
+				var lines []Event
+				scanner := bufio.NewScanner(f)
+				for scanner.Scan() {
+					var e Event
+					So(json.Unmarshal(scanner.Bytes(), &e), ShouldBeNil)
+					lines = append(lines, e)
+				}
+				So(lines, ShouldHaveLength, 3)
+				written := testEvent
+				written.Name = EventName
+				So(lines[1], ShouldResemble, written)
+				So(lines[2], ShouldResemble, written)
+			})
+		})
+	})
+
+	Convey("Given a path in a directory which does not exist", t, func() {
+		_, err := NewFileSink(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"time"
+)
+
+// AreaLabelCache is an in-memory cache of area labels, held separately for each population type. Entries expire after
+// the configured time to live, and are replaced when the area is next looked up. Once the cache holds its maximum
+// number of entries, the least recently used entry is evicted to make room for a new one. A nil *AreaLabelCache is
+// valid and caches nothing.
+type AreaLabelCache struct {
+	*expiringLRU[areaKey, string]
+}
+
+type areaKey struct {
+	populationType string
+	areaType       string
+	area           string
+}
+
+// NewAreaLabelCache creates an area label cache holding at most maxEntries labels, which expire after ttl
+func NewAreaLabelCache(ttl time.Duration, maxEntries int) *AreaLabelCache {
+	return &AreaLabelCache{newExpiringLRU[areaKey, string](ttl, maxEntries)}
+}
+
+// Get returns the cached label of an area of the given area type in a population type
+func (c *AreaLabelCache) Get(populationType, areaType, area string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	return c.get(areaKey{populationType, areaType, area})
+}
+
+// Set caches the label of an area of the given area type in a population type, evicting the least recently used
+// label if the cache is full
+func (c *AreaLabelCache) Set(populationType, areaType, area, label string) {
+	if c == nil {
+		return
+	}
+	c.set(areaKey{populationType, areaType, area}, label)
+}
+
+// Len returns the number of labels in the cache, including any which have expired but not yet been removed
+func (c *AreaLabelCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.len()
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAreaLabelCache(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an area label cache", t, func() {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := NewAreaLabelCache(time.Hour, 2)
+		c.now = func() time.Time { return now }
+
+		Convey("When a label is cached", func() {
+			c.Set("UR", "ltla", "E06000001", "Hartlepool")
+
+			Convey("Then it is returned for the same population type, area type and area", func() {
+				label, ok := c.Get("UR", "ltla", "E06000001")
+				So(ok, ShouldBeTrue)
+				So(label, ShouldEqual, "Hartlepool")
+			})
+
+			Convey("Then it is not returned for another population type or area type", func() {
+				_, ok := c.Get("HH", "ltla", "E06000001")
+				So(ok, ShouldBeFalse)
+				_, ok = c.Get("UR", "utla", "E06000001")
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then it is not returned once it has expired", func() {
+				now = now.Add(time.Hour)
+				_, ok := c.Get("UR", "ltla", "E06000001")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When labels are cached and read concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					c.Set("UR", "ltla", "E06000001", "Hartlepool")
+				}()
+				go func() {
+					defer wg.Done()
+					c.Get("UR", "ltla", "E06000001")
+				}()
+			}
+			wg.Wait()
+
+			Convey("Then the label is cached", func() {
+				label, _ := c.Get("UR", "ltla", "E06000001")
+				So(label, ShouldEqual, "Hartlepool")
+			})
+		})
+	})
+
+	Convey("Given a full area label cache", t, func() {
+		c := NewAreaLabelCache(time.Hour, 2)
+		c.Set("UR", "ltla", "E06000001", "Hartlepool")
+		c.Set("UR", "ltla", "E06000002", "Middlesbrough")
+
+		Convey("When the oldest label is read and another is cached", func() {
+			c.Get("UR", "ltla", "E06000001")
+			c.Set("UR", "ltla", "E06000003", "Redcar and Cleveland")
+
+			Convey("Then the least recently used label is evicted", func() {
+				So(c.Len(), ShouldEqual, 2)
+				_, ok := c.Get("UR", "ltla", "E06000002")
+				So(ok, ShouldBeFalse)
+				label, ok := c.Get("UR", "ltla", "E06000001")
+				So(ok, ShouldBeTrue)
+				So(label, ShouldEqual, "Hartlepool")
+			})
+		})
+
+		Convey("When a cached label is replaced", func() {
+			c.Set("UR", "ltla", "E06000002", "Middlesbrough (updated)")
+
+			Convey("Then nothing is evicted", func() {
+				So(c.Len(), ShouldEqual, 2)
+				label, _ := c.Get("UR", "ltla", "E06000002")
+				So(label, ShouldEqual, "Middlesbrough (updated)")
+			})
+		})
+	})
+
+	Convey("Given a nil area label cache", t, func() {
+		var c *AreaLabelCache
+
+		Convey("Then nothing is cached", func() {
+			c.Set("UR", "ltla", "E06000001", "Hartlepool")
+			_, ok := c.Get("UR", "ltla", "E06000001")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
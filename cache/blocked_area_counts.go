@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"golang.org/x/sync/singleflight"
+)
+
+// BlockedAreaCountCache is an in-memory cache of the statistical disclosure control results of filter outputs, keyed
+// by filter output ID, or by a key derived from it for the results of changes to a filter output. The areas and
+// dimensions of a filter output do not change, so each result is only requested once however many pages and event
+// streams show it. A nil *BlockedAreaCountCache is valid and caches nothing.
+type BlockedAreaCountCache struct {
+	*expiringLRU[string, *cantabular.GetBlockedAreaCountResult]
+	loads   singleflight.Group
+	timeout time.Duration
+}
+
+// NewBlockedAreaCountCache creates a blocked area count cache holding at most maxEntries results, which expire after
+// ttl. A load shared by concurrent callers is given up after timeout, or never if timeout is zero.
+func NewBlockedAreaCountCache(ttl time.Duration, maxEntries int, timeout time.Duration) *BlockedAreaCountCache {
+	return &BlockedAreaCountCache{
+		expiringLRU: newExpiringLRU[string, *cantabular.GetBlockedAreaCountResult](ttl, maxEntries),
+		timeout:     timeout,
+	}
+}
+
+// Load returns the result cached under key, calling load to get it if it is not cached. Concurrent loads of the same
+// key share a single call, and only results which are loaded without an error are cached. The shared call is not
+// cancelled with the context of the caller which started it, so that the other callers waiting for it are not
+// failed, while each caller stops waiting once its own context is done.
+func (c *BlockedAreaCountCache) Load(ctx context.Context, key string, load func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error)) (*cantabular.GetBlockedAreaCountResult, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	if result, ok := c.get(key); ok {
+		return result, nil
+	}
+
+	loaded := c.loads.DoChan(key, func() (interface{}, error) {
+		if result, ok := c.get(key); ok {
+			return result, nil
+		}
+
+		loadCtx := context.WithoutCancel(ctx)
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			loadCtx, cancel = context.WithTimeout(loadCtx, c.timeout)
+			defer cancel()
+		}
+
+		result, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.set(key, result)
+		return result, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-loaded:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*cantabular.GetBlockedAreaCountResult), nil
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBlockedAreaCountCache(t *testing.T) {
+	t.Parallel()
+
+	result := &cantabular.GetBlockedAreaCountResult{Passed: 9, Blocked: 1, Total: 10}
+
+	Convey("Given a blocked area count cache", t, func() {
+		c := NewBlockedAreaCountCache(time.Hour, 10, time.Second)
+		var loads int32
+		load := func(context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+			atomic.AddInt32(&loads, 1)
+			time.Sleep(time.Millisecond)
+			return result, nil
+		}
+
+		Convey("When the result of a filter output is loaded by concurrent callers", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _ = c.Load(context.Background(), "filter-output-1", load)
+				}()
+			}
+			wg.Wait()
+
+			Convey("Then it is only loaded once", func() {
+				So(atomic.LoadInt32(&loads), ShouldEqual, 1)
+			})
+
+			Convey("Then it is returned from the cache when loaded again", func() {
+				got, err := c.Load(context.Background(), "filter-output-1", load)
+				So(err, ShouldBeNil)
+				So(got, ShouldEqual, result)
+				So(atomic.LoadInt32(&loads), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the result fails to load", func() {
+			loadErr := errors.New("population api error")
+			_, err := c.Load(context.Background(), "filter-output-1", func(context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+				return nil, loadErr
+			})
+
+			Convey("Then the error is returned and nothing is cached", func() {
+				So(err, ShouldEqual, loadErr)
+				So(c.len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the caller which started a load stops waiting for it before it is loaded", func() {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var loadErr error
+			slowLoad := func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+				close(started)
+				<-release
+				loadErr = ctx.Err()
+				return result, nil
+			}
+
+			firstCtx, cancelFirst := context.WithCancel(context.Background())
+			firstErr := make(chan error, 1)
+			go func() {
+				_, err := c.Load(firstCtx, "filter-output-1", slowLoad)
+				firstErr <- err
+			}()
+			<-started
+
+			second := make(chan *cantabular.GetBlockedAreaCountResult, 1)
+			go func() {
+				got, _ := c.Load(context.Background(), "filter-output-1", slowLoad)
+				second <- got
+			}()
+
+			cancelFirst()
+			err := <-firstErr
+			close(release)
+
+			Convey("Then the first caller's context error is returned to it", func() {
+				So(err, ShouldEqual, context.Canceled)
+			})
+
+			Convey("Then the shared load is not cancelled and the other caller gets its result", func() {
+				So(<-second, ShouldEqual, result)
+				So(loadErr, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a nil blocked area count cache", t, func() {
+		var c *BlockedAreaCountCache
+
+		Convey("Then every load calls through", func() {
+			var loads int
+			for i := 0; i < 2; i++ {
+				_, _ = c.Load(context.Background(), "filter-output-1", func(context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+					loads++
+					return result, nil
+				})
+			}
+			So(loads, ShouldEqual, 2)
+		})
+	})
+}
@@ -2,5 +2,7 @@ package cache
 
 // List is a list of caches for the dp-frontend-dataset-controller
 type List struct {
-	Navigation *NavigationCache
+	Navigation        *NavigationCache
+	AreaLabels        *AreaLabelCache
+	BlockedAreaCounts *BlockedAreaCountCache
 }
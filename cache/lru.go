@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// expiringLRU is an in-memory cache of values which expire after the configured time to live. Once it holds its
+// maximum number of entries, the least recently used entry is evicted to make room for a new one.
+type expiringLRU[K comparable, V any] struct {
+	mutex      sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[K]*list.Element
+	// recent orders the entries from the most to the least recently used
+	recent *list.List
+	now    func() time.Time
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+func newExpiringLRU[K comparable, V any](ttl time.Duration, maxEntries int) *expiringLRU[K, V] {
+	return &expiringLRU[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[K]*list.Element),
+		recent:     list.New(),
+		now:        time.Now,
+	}
+}
+
+// get returns the cached value of a key, removing it if it has expired
+func (c *expiringLRU[K, V]) get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	cached := element.Value.(*lruEntry[K, V])
+	if !c.now().Before(cached.expires) {
+		c.remove(element)
+		return zero, false
+	}
+
+	c.recent.MoveToFront(element)
+	return cached.value, true
+}
+
+// set caches the value of a key, evicting the least recently used value if the cache is full
+func (c *expiringLRU[K, V]) set(key K, value V) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expires := c.now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		cached := element.Value.(*lruEntry[K, V])
+		cached.value = value
+		cached.expires = expires
+		c.recent.MoveToFront(element)
+		return
+	}
+
+	for c.recent.Len() >= c.maxEntries {
+		c.remove(c.recent.Back())
+	}
+	c.entries[key] = c.recent.PushFront(&lruEntry[K, V]{key: key, value: value, expires: expires})
+}
+
+// len returns the number of cached values, including any which have expired but not yet been removed
+func (c *expiringLRU[K, V]) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.recent.Len()
+}
+
+func (c *expiringLRU[K, V]) remove(element *list.Element) {
+	c.recent.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[K, V]).key)
+}
@@ -17,13 +17,13 @@ func UpdateNavigationData(ctx context.Context, cfg *config.Config, lang string,
 	}
 	return func() *topicModel.Navigation {
 		headers := topicCli.Headers{}
-		options := topicCli.Options{}
+		options := topicCli.Options{
+			Lang: topicCli.English,
+		}
 
-		switch lang {
-		case "cy":
-			options.Lang = topicCli.Welsh
-		default:
-			options.Lang = topicCli.English
+		// the navigation is requested in each supported language, which are named as the topic API expects
+		if lang != "" {
+			options.Lang = topicCli.Language(lang)
 		}
 
 		navigationData, err := topicClient.GetNavigationPublic(ctx, headers, options)
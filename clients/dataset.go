@@ -25,6 +25,7 @@ type APIClientsGoDatasetClient interface {
 // Interface with methods required for a dp-dataset-api/sdk dataset client
 type DatasetAPISdkClient interface {
 	GetDataset(ctx context.Context, headers datasetAPISDK.Headers, datasetID string) (m datasetAPIModels.Dataset, err error)
+	GetDatasetCurrentAndNext(ctx context.Context, headers datasetAPISDK.Headers, datasetID string) (m datasetAPIModels.DatasetUpdate, err error)
 	GetDatasetByPath(ctx context.Context, headers datasetAPISDK.Headers, path string) (m datasetAPIModels.Dataset, err error)
 	GetEditions(ctx context.Context, headers datasetAPISDK.Headers, datasetID string, q *datasetAPISDK.QueryParams) (m datasetAPISDK.EditionsList, err error)
 	GetEdition(ctx context.Context, headers datasetAPISDK.Headers, datasetID, edition string) (datasetAPIModels.Edition, error)
@@ -337,6 +337,21 @@ func (mr *MockDatasetAPISdkClientMockRecorder) GetDataset(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataset", reflect.TypeOf((*MockDatasetAPISdkClient)(nil).GetDataset), arg0, arg1, arg2)
 }
 
+// GetDatasetCurrentAndNext mocks base method.
+func (m *MockDatasetAPISdkClient) GetDatasetCurrentAndNext(arg0 context.Context, arg1 sdk.Headers, arg2 string) (models.DatasetUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatasetCurrentAndNext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.DatasetUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatasetCurrentAndNext indicates an expected call of GetDatasetCurrentAndNext.
+func (mr *MockDatasetAPISdkClientMockRecorder) GetDatasetCurrentAndNext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatasetCurrentAndNext", reflect.TypeOf((*MockDatasetAPISdkClient)(nil).GetDatasetCurrentAndNext), arg0, arg1, arg2)
+}
+
 // GetDatasetByPath mocks base method.
 func (m *MockDatasetAPISdkClient) GetDatasetByPath(arg0 context.Context, arg1 sdk.Headers, arg2 string) (models.Dataset, error) {
 	m.ctrl.T.Helper()
@@ -577,6 +592,21 @@ func (mr *MockPopulationClientMockRecorder) GetArea(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArea", reflect.TypeOf((*MockPopulationClient)(nil).GetArea), arg0, arg1)
 }
 
+// GetAreaTypes mocks base method.
+func (m *MockPopulationClient) GetAreaTypes(arg0 context.Context, arg1 population.GetAreaTypesInput) (population.GetAreaTypesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAreaTypes", arg0, arg1)
+	ret0, _ := ret[0].(population.GetAreaTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAreaTypes indicates an expected call of GetAreaTypes.
+func (mr *MockPopulationClientMockRecorder) GetAreaTypes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreaTypes", reflect.TypeOf((*MockPopulationClient)(nil).GetAreaTypes), arg0, arg1)
+}
+
 // GetAreas mocks base method.
 func (m *MockPopulationClient) GetAreas(arg0 context.Context, arg1 population.GetAreasInput) (population.GetAreasResponse, error) {
 	m.ctrl.T.Helper()
@@ -818,6 +848,18 @@ func (m *MockRenderClient) EXPECT() *MockRenderClientMockRecorder {
 	return m.recorder
 }
 
+// BuildErrorPage mocks base method.
+func (m *MockRenderClient) BuildErrorPage(arg0 io.Writer, arg1 model.Page, arg2 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BuildErrorPage", arg0, arg1, arg2)
+}
+
+// BuildErrorPage indicates an expected call of BuildErrorPage.
+func (mr *MockRenderClientMockRecorder) BuildErrorPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildErrorPage", reflect.TypeOf((*MockRenderClient)(nil).BuildErrorPage), arg0, arg1, arg2)
+}
+
 // BuildPage mocks base method.
 func (m *MockRenderClient) BuildPage(arg0 io.Writer, arg1 interface{}, arg2 string) {
 	m.ctrl.T.Helper()
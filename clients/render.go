@@ -9,5 +9,6 @@ import (
 // RenderClient is an interface with methods for require for rendering a template
 type RenderClient interface {
 	BuildPage(w io.Writer, pageModel interface{}, templateName string)
+	BuildErrorPage(w io.Writer, pageModel model.Page, statusCode int)
 	NewBasePageModel() model.Page
 }
@@ -16,7 +16,8 @@ type TopicAPIClient interface {
 }
 
 // FetchTopic retrieves a single topic from the topic API.
-// The userAccessToken is only required when isPublishing is true.
+// The userAccessToken is only required when isPublishing is true. The current topic is returned in publishing, as the
+// topic API does not record which collection pending changes to a topic belong to, so they cannot be previewed in one.
 func FetchTopic(ctx context.Context, topicAPIClient TopicAPIClient, topicID string, isPublishing bool, userAccessToken string) (*topicAPIModels.Topic, error) {
 	headers := topicAPISDK.Headers{
 		UserAuthToken: userAccessToken,
@@ -39,6 +39,19 @@ func TestFetchTopic(t *testing.T) {
 			})
 		})
 
+		Convey("When FetchTopic is called in publishing mode for a topic with pending changes", func() {
+			nextTopic := topicAPIModels.Topic{ID: "topic1", Slug: "topic1-renamed"}
+			mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: userAccessToken}, "topic1").
+				Return(&topicAPIModels.TopicResponse{Current: &testTopic1, Next: &nextTopic}, nil)
+
+			topic, err := FetchTopic(ctx, mockTopicAPIClient, "topic1", true, userAccessToken)
+
+			Convey("Then the current topic is returned, as the changes may belong to another collection", func() {
+				So(err, ShouldBeNil)
+				So(topic, ShouldResemble, &testTopic1)
+			})
+		})
+
 		Convey("When FetchTopic is called successfully in web mode", func() {
 			mockTopicAPIClient.EXPECT().GetTopicPublic(ctx, topicAPISDK.Headers{UserAuthToken: userAccessToken}, "topic1").
 				Return(&testTopic1, nil)
@@ -14,27 +14,44 @@ var RendererVersion = "v0.2.0"
 
 // Config represents service configuration for dp-frontend-dataset-controller
 type Config struct {
-	APIRouterURL                  string        `envconfig:"API_ROUTER_URL"`
-	BindAddr                      string        `envconfig:"BIND_ADDR"`
-	CacheNavigationUpdateInterval time.Duration `envconfig:"CACHE_NAVIGATION_UPDATE_INTERVAL"`
-	Debug                         bool          `envconfig:"DEBUG"`
-	DownloadServiceURL            string        `envconfig:"DOWNLOAD_SERVICE_URL"`
-	EnableMultivariate            bool          `envconfig:"ENABLE_MULTIVARIATE"`
-	EnableNewNavBar               bool          `envconfig:"ENABLE_NEW_NAV_BAR"`
-	EnableProfiler                bool          `envconfig:"ENABLE_PROFILER"`
-	FeedbackAPIURL                string        `envconfig:"FEEDBACK_API_URL"`
-	GracefulShutdownTimeout       time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckCriticalTimeout    time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	HealthCheckInterval           time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	IsPublishing                  bool          `envconfig:"IS_PUBLISHING"`
-	OTBatchTimeout                time.Duration `envconfig:"OTEL_BATCH_TIMEOUT"`
-	OTServiceName                 string        `envconfig:"OTEL_SERVICE_NAME"`
-	OTExporterOTLPEndpoint        string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
-	OtelEnabled                   bool          `envconfig:"OTEL_ENABLED"`
-	PatternLibraryAssetsPath      string        `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
-	PprofToken                    string        `envconfig:"PPROF_TOKEN" json:"-"`
-	SiteDomain                    string        `envconfig:"SITE_DOMAIN"`
-	SupportedLanguages            []string      `envconfig:"SUPPORTED_LANGUAGES"`
+	APIRouterURL                  string            `envconfig:"API_ROUTER_URL"`
+	AreaLabelCacheSize            int               `envconfig:"AREA_LABEL_CACHE_SIZE"`
+	AreaLabelCacheTTL             time.Duration     `envconfig:"AREA_LABEL_CACHE_TTL"`
+	AuditLogPath                  string            `envconfig:"AUDIT_LOG_PATH"`
+	BindAddr                      string            `envconfig:"BIND_ADDR"`
+	BlockedAreaCountCacheSize     int               `envconfig:"BLOCKED_AREA_COUNT_CACHE_SIZE"`
+	BlockedAreaCountCacheTTL      time.Duration     `envconfig:"BLOCKED_AREA_COUNT_CACHE_TTL"`
+	CacheNavigationUpdateInterval time.Duration     `envconfig:"CACHE_NAVIGATION_UPDATE_INTERVAL"`
+	CriticalDependencyTimeout     time.Duration     `envconfig:"CRITICAL_DEPENDENCY_TIMEOUT"`
+	CSPReportOnly                 bool              `envconfig:"CSP_REPORT_ONLY"`
+	CSRFAllowedOrigins            []string          `envconfig:"CSRF_ALLOWED_ORIGINS"`
+	Debug                         bool              `envconfig:"DEBUG"`
+	DownloadServiceURL            string            `envconfig:"DOWNLOAD_SERVICE_URL"`
+	EnableMultivariate            bool              `envconfig:"ENABLE_MULTIVARIATE"`
+	EnableNewNavBar               bool              `envconfig:"ENABLE_NEW_NAV_BAR"`
+	EnableProfiler                bool              `envconfig:"ENABLE_PROFILER"`
+	FeedbackAPIURL                string            `envconfig:"FEEDBACK_API_URL"`
+	FilterOutputEventsInterval    time.Duration     `envconfig:"FILTER_OUTPUT_EVENTS_INTERVAL"`
+	FilterOutputEventsTimeout     time.Duration     `envconfig:"FILTER_OUTPUT_EVENTS_TIMEOUT"`
+	GracefulShutdownTimeout       time.Duration     `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckCriticalTimeout    time.Duration     `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	HealthCheckInterval           time.Duration     `envconfig:"HEALTHCHECK_INTERVAL"`
+	HSTSMaxAge                    time.Duration     `envconfig:"HSTS_MAX_AGE"`
+	IsPublishing                  bool              `envconfig:"IS_PUBLISHING"`
+	LintRuleSeverities            map[string]string `envconfig:"LINT_RULE_SEVERITIES"`
+	OptionalDependencyTimeout     time.Duration     `envconfig:"OPTIONAL_DEPENDENCY_TIMEOUT"`
+	OTBatchTimeout                time.Duration     `envconfig:"OTEL_BATCH_TIMEOUT"`
+	OTServiceName                 string            `envconfig:"OTEL_SERVICE_NAME"`
+	OTExporterOTLPEndpoint        string            `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OtelEnabled                   bool              `envconfig:"OTEL_ENABLED"`
+	PatternLibraryAssetsPath      string            `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
+	PprofToken                    string            `envconfig:"PPROF_TOKEN" json:"-"`
+	RateLimits                    map[string]string `envconfig:"RATE_LIMITS"`
+	RateLimitTrustedProxies       int               `envconfig:"RATE_LIMIT_TRUSTED_PROXIES"`
+	RequestBudget                 time.Duration     `envconfig:"REQUEST_BUDGET"`
+	SiteDomain                    string            `envconfig:"SITE_DOMAIN"`
+	SocialImageURLs               map[string]string `envconfig:"SOCIAL_IMAGE_URLS"`
+	SupportedLanguages            []string          `envconfig:"SUPPORTED_LANGUAGES"`
 	AuthConfig                    *authorisation.Config
 }
 
@@ -62,22 +79,35 @@ func get() (*Config, error) {
 
 	cfg = &Config{
 		APIRouterURL:                  "http://localhost:23200/v1",
+		AreaLabelCacheSize:            50000,
+		AreaLabelCacheTTL:             time.Hour,
+		AuditLogPath:                  "",
 		BindAddr:                      "localhost:20200",
+		BlockedAreaCountCacheSize:     1000,
+		BlockedAreaCountCacheTTL:      time.Hour,
 		CacheNavigationUpdateInterval: 10 * time.Second,
+		CriticalDependencyTimeout:     5 * time.Second,
+		CSPReportOnly:                 true,
 		Debug:                         false,
 		DownloadServiceURL:            "http://localhost:23600",
 		EnableMultivariate:            false,
 		EnableNewNavBar:               false,
 		EnableProfiler:                false,
 		FeedbackAPIURL:                "http://localhost:23200/v1/feedback",
+		FilterOutputEventsInterval:    2 * time.Second,
+		FilterOutputEventsTimeout:     5 * time.Minute,
 		GracefulShutdownTimeout:       5 * time.Second,
 		HealthCheckCriticalTimeout:    90 * time.Second,
 		HealthCheckInterval:           30 * time.Second,
+		HSTSMaxAge:                    365 * 24 * time.Hour,
 		IsPublishing:                  false,
+		OptionalDependencyTimeout:     2 * time.Second,
 		OTBatchTimeout:                5 * time.Second,
 		OTExporterOTLPEndpoint:        "localhost:4317",
 		OTServiceName:                 "dp-frontend-dataset-controller",
 		OtelEnabled:                   false,
+		RateLimitTrustedProxies:       1,
+		RequestBudget:                 10 * time.Second,
 		SiteDomain:                    "localhost",
 		SupportedLanguages:            []string{"en", "cy"},
 		AuthConfig:                    authorisation.NewDefaultConfig(),
@@ -23,12 +23,28 @@ func TestConfig(t *testing.T) {
 				So(cfg.Debug, ShouldBeFalse)
 				So(cfg.EnableMultivariate, ShouldBeFalse)
 				So(cfg.APIRouterURL, ShouldEqual, "http://localhost:23200/v1")
+				So(cfg.AreaLabelCacheSize, ShouldEqual, 50000)
+				So(cfg.AreaLabelCacheTTL, ShouldEqual, time.Hour)
+				So(cfg.AuditLogPath, ShouldBeEmpty)
+				So(cfg.BlockedAreaCountCacheSize, ShouldEqual, 1000)
+				So(cfg.BlockedAreaCountCacheTTL, ShouldEqual, time.Hour)
+				So(cfg.CacheNavigationUpdateInterval, ShouldEqual, 10*time.Second)
+				So(cfg.CriticalDependencyTimeout, ShouldEqual, 5*time.Second)
+				So(cfg.CSPReportOnly, ShouldBeTrue)
+				So(cfg.CSRFAllowedOrigins, ShouldBeEmpty)
+				So(cfg.OptionalDependencyTimeout, ShouldEqual, 2*time.Second)
+				So(cfg.RateLimits, ShouldBeEmpty)
+				So(cfg.RateLimitTrustedProxies, ShouldEqual, 1)
+				So(cfg.RequestBudget, ShouldEqual, 10*time.Second)
 				So(cfg.DownloadServiceURL, ShouldEqual, "http://localhost:23600")
 				So(cfg.SiteDomain, ShouldEqual, "localhost")
+				So(cfg.SocialImageURLs, ShouldBeEmpty)
+				So(cfg.LintRuleSeverities, ShouldBeEmpty)
 				So(cfg.SupportedLanguages, ShouldResemble, []string{"en", "cy"})
 				So(cfg.GracefulShutdownTimeout, ShouldEqual, 5*time.Second)
 				So(cfg.HealthCheckInterval, ShouldEqual, 30*time.Second)
 				So(cfg.HealthCheckCriticalTimeout, ShouldEqual, 90*time.Second)
+				So(cfg.HSTSMaxAge, ShouldEqual, 365*24*time.Hour)
 				So(cfg.IsPublishing, ShouldEqual, false)
 				So(cfg.EnableProfiler, ShouldBeFalse)
 				So(cfg.PatternLibraryAssetsPath, ShouldEqual, "//cdn.ons.gov.uk/dis-design-system-go/v0.2.0")
@@ -0,0 +1,170 @@
+// Package csrf protects state-changing requests from cross-site request forgery. Requests which the browser marks as
+// coming from the same origin, or whose origin or referrer is the host they are sent to, are accepted, so forms on
+// public pages can be cached without a token. Other requests must
+// submit a double-submit token: a random token is stored in a cookie and must be sent back in a form field of the same
+// name, or in a header by scripts.
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// FieldName is the name of both the cookie holding the token and the form field it must be submitted in
+const FieldName = "csrf_token"
+
+// HeaderName is the header scripts can submit the token in instead of a form field
+const HeaderName = "X-CSRF-Token"
+
+// fetchSiteHeader is set by browsers to the relationship between the page making a request and its target. Pages on
+// other sites cannot set or change it.
+const fetchSiteHeader = "Sec-Fetch-Site"
+
+const tokenBytes = 32
+
+// maxFormBytes limits the forms read to find a submitted token, which are only ever small
+const maxFormBytes = 64 * 1024
+
+// ErrInvalidToken is returned when a request does not include the token from its cookie
+var ErrInvalidToken = errors.New("missing or invalid csrf token")
+
+// Token returns the token of the request's cookie, setting a new cookie if there is none, to be rendered in a form.
+// The response is marked private as a page holding a user's token must not be served to anyone else from a cache.
+func Token(w http.ResponseWriter, req *http.Request) (string, error) {
+	w.Header().Set("Cache-Control", "private")
+
+	if cookie, err := req.Cookie(FieldName); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     FieldName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// Validate checks that the token submitted in the request's header or form matches its cookie. Only URL encoded
+// forms are read, so other requests, such as file uploads, must submit the token in the header.
+func Validate(req *http.Request) error {
+	cookie, err := req.Cookie(FieldName)
+	if err != nil || cookie.Value == "" {
+		return ErrInvalidToken
+	}
+
+	submitted := req.Header.Get(HeaderName)
+	if submitted == "" && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		submitted = req.PostFormValue(FieldName)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(submitted), []byte(cookie.Value)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// Middleware rejects state-changing requests which do not submit the token from their cookie. Requests the browser
+// marks as same-origin, requests whose origin or referrer is the host they are sent to, and requests from scripts on
+// the allowed origins are accepted without a token, as browsers set the Sec-Fetch-Site, Origin and Referer headers of
+// these requests and a page on another site cannot change them. The Origin and Referer headers are checked for the
+// browsers and proxies which do not send Sec-Fetch-Site. A submitted form is only read, up to a limit, when the token
+// is not in the header. onRejected, when not nil, is called with each request rejected so that the attempt can be
+// recorded, and rejected, when not nil, responds to it in place of an empty forbidden response.
+func Middleware(allowedOrigins []string, onRejected func(req *http.Request), rejected http.Handler) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			allowed[origin] = true
+		}
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if isSafeMethod(req.Method) || req.Header.Get(fetchSiteHeader) == "same-origin" || allowed[req.Header.Get("Origin")] || isSameHost(req) {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			if req.Header.Get(HeaderName) == "" {
+				req.Body = http.MaxBytesReader(w, req.Body, maxFormBytes)
+			}
+			if err := Validate(req); err != nil {
+				log.Warn(req.Context(), "request rejected without a valid csrf token", log.Data{
+					"method":     req.Method,
+					"path":       req.URL.Path,
+					"origin":     req.Header.Get("Origin"),
+					"fetch_site": req.Header.Get(fetchSiteHeader),
+				})
+				if onRejected != nil {
+					onRejected(req)
+				}
+				if rejected != nil {
+					rejected.ServeHTTP(w, req)
+					return
+				}
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			h.ServeHTTP(w, req)
+		})
+	}
+}
+
+// isSameHost reports whether the origin of a request, or its referrer when it has no origin, is the host it is sent
+// to. A request whose origin browsers hide, which they send as "null", is not from the same host.
+func isSameHost(req *http.Request) bool {
+	source := req.Header.Get("Origin")
+	if source == "" {
+		source = req.Header.Get("Referer")
+	}
+	if source == "" {
+		return false
+	}
+
+	u, err := url.Parse(source)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return strings.EqualFold(u.Host, req.Host)
+}
+
+// isSafeMethod reports whether requests with the method do not change state, so need no protection
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// Field returns the hidden form field submitting the token, for templates to embed in their forms as csrfField
+func Field(token string) template.HTML {
+	//nolint:gosec // the token is escaped and the field name is a constant
+	return template.HTML(`<input type="hidden" name="` + FieldName + `" value="` + template.HTMLEscapeString(token) + `">`)
+}
+
+// RegisterTemplateFuncs makes the csrfField helper available to templates. It must be called before the renderer is
+// created, as the templates are parsed with the functions registered at that point.
+func RegisterTemplateFuncs() {
+	helper.RegisteredFuncs["csrfField"] = Field
+}
@@ -0,0 +1,290 @@
+package csrf
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestToken(t *testing.T) {
+	Convey("Given a request without a csrf cookie", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+		Convey("When a token is requested", func() {
+			token, err := Token(w, req)
+
+			Convey("Then a new token is set as a cookie", func() {
+				So(err, ShouldBeNil)
+				So(token, ShouldNotBeEmpty)
+
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, FieldName)
+				So(cookies[0].Value, ShouldEqual, token)
+				So(cookies[0].HttpOnly, ShouldBeTrue)
+				So(cookies[0].SameSite, ShouldEqual, http.SameSiteStrictMode)
+			})
+
+			Convey("Then the response is not cached for other users", func() {
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private")
+			})
+		})
+	})
+
+	Convey("Given a request with a csrf cookie", t, func() {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.AddCookie(&http.Cookie{Name: FieldName, Value: "existing-token"})
+
+		Convey("When a token is requested", func() {
+			token, err := Token(w, req)
+
+			Convey("Then the existing token is reused", func() {
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "existing-token")
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestValidate(t *testing.T) {
+	newRequest := func(cookie, field string) *http.Request {
+		form := url.Values{}
+		if field != "" {
+			form.Set(FieldName, field)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: FieldName, Value: cookie})
+		}
+		return req
+	}
+
+	Convey("Given the submitted token matches the cookie", t, func() {
+		So(Validate(newRequest("token", "token")), ShouldBeNil)
+	})
+
+	Convey("Given the submitted token does not match the cookie", t, func() {
+		So(Validate(newRequest("token", "other")), ShouldEqual, ErrInvalidToken)
+	})
+
+	Convey("Given no token is submitted", t, func() {
+		So(Validate(newRequest("token", "")), ShouldEqual, ErrInvalidToken)
+	})
+
+	Convey("Given there is no cookie", t, func() {
+		So(Validate(newRequest("", "token")), ShouldEqual, ErrInvalidToken)
+	})
+
+	Convey("Given the token is submitted in the header", t, func() {
+		req := newRequest("token", "")
+		req.Header.Set(HeaderName, "token")
+		So(Validate(req), ShouldBeNil)
+	})
+
+	Convey("Given the token is submitted in a form which is not URL encoded", t, func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("--boundary\r\nContent-Disposition: form-data; name=\"csrf_token\"\r\n\r\ntoken\r\n--boundary--\r\n"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+		req.AddCookie(&http.Cookie{Name: FieldName, Value: "token"})
+
+		Convey("Then the form is not read", func() {
+			So(Validate(req), ShouldEqual, ErrInvalidToken)
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	var rejected []*http.Request
+	handler := Middleware([]string{"https://www.ons.gov.uk/"}, func(req *http.Request) {
+		rejected = append(rejected, req)
+	}, nil)(next)
+
+	serve := func(req *http.Request) int {
+		rejected = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	newPost := func(origin, cookie, field string) *http.Request {
+		form := url.Values{}
+		if field != "" {
+			form.Set(FieldName, field)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/datasets/create", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: FieldName, Value: cookie})
+		}
+		return req
+	}
+
+	Convey("Given a GET request without a token", t, func() {
+		Convey("Then it is passed on", func() {
+			So(serve(httptest.NewRequest(http.MethodGet, "/datasets/create", http.NoBody)), ShouldEqual, http.StatusNoContent)
+		})
+	})
+
+	Convey("Given a POST request submitting the token from its cookie", t, func() {
+		Convey("Then it is passed on", func() {
+			So(serve(newPost("", "token", "token")), ShouldEqual, http.StatusNoContent)
+			So(rejected, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a POST request from a page on another site", t, func() {
+		Convey("Then it is rejected without a token", func() {
+			So(serve(newPost("https://attacker.example", "", "")), ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("Then it is rejected with a token which does not match the cookie", func() {
+			req := newPost("https://attacker.example", "token", "forged")
+			So(serve(req), ShouldEqual, http.StatusForbidden)
+
+			Convey("And the rejection is reported", func() {
+				So(rejected, ShouldHaveLength, 1)
+				So(rejected[0], ShouldEqual, req)
+			})
+		})
+	})
+
+	Convey("Given a POST request without an origin or token", t, func() {
+		Convey("Then it is rejected", func() {
+			So(serve(newPost("", "token", "")), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Given a DELETE request without a token", t, func() {
+		Convey("Then it is rejected", func() {
+			So(serve(httptest.NewRequest(http.MethodDelete, "/datasets/create", http.NoBody)), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Given a POST request from a script on an allowed origin", t, func() {
+		Convey("Then it is passed on without a token", func() {
+			So(serve(newPost("https://www.ons.gov.uk", "", "")), ShouldEqual, http.StatusNoContent)
+		})
+	})
+
+	Convey("Given a POST request the browser marks as same-origin", t, func() {
+		req := newPost("", "", "")
+		req.Header.Set("Sec-Fetch-Site", "same-origin")
+
+		Convey("Then it is passed on without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusNoContent)
+		})
+	})
+
+	Convey("Given a POST request from a page on the same host without Sec-Fetch-Site", t, func() {
+		req := newPost("http://example.com", "", "")
+
+		Convey("Then it is passed on without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusNoContent)
+		})
+	})
+
+	Convey("Given a POST request without an origin referred by a page on the same host", t, func() {
+		req := newPost("", "", "")
+		req.Header.Set("Referer", "http://example.com/datasets/create")
+
+		Convey("Then it is passed on without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusNoContent)
+		})
+	})
+
+	Convey("Given a POST request referred by a page on another host", t, func() {
+		req := newPost("", "", "")
+		req.Header.Set("Referer", "https://attacker.example/example.com")
+
+		Convey("Then it is rejected without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Given a POST request whose origin is hidden", t, func() {
+		req := newPost("null", "", "")
+		req.Header.Set("Referer", "http://example.com/datasets/create")
+
+		Convey("Then it is rejected without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Given a POST request the browser marks as cross-site", t, func() {
+		req := newPost("", "", "")
+		req.Header.Set("Sec-Fetch-Site", "cross-site")
+
+		Convey("Then it is rejected without a token", func() {
+			So(serve(req), ShouldEqual, http.StatusForbidden)
+		})
+	})
+
+	Convey("Given a large POST request submitting the token in the header", t, func() {
+		body := strings.Repeat("a", 2*maxFormBytes)
+		req := httptest.NewRequest(http.MethodPost, "/datasets/create/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderName, "token")
+		req.AddCookie(&http.Cookie{Name: FieldName, Value: "token"})
+
+		var read int
+		handler := Middleware(nil, nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			b, err := io.ReadAll(req.Body)
+			if err == nil {
+				read = len(b)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		Convey("Then the whole body is passed on", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(read, ShouldEqual, len(body))
+		})
+	})
+
+	Convey("Given a handler responding to rejected requests", t, func() {
+		handler := Middleware(nil, nil, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden page"))
+		}))(next)
+
+		Convey("Then it responds to a request rejected without a token", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newPost("https://attacker.example", "", ""))
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+			So(w.Body.String(), ShouldEqual, "forbidden page")
+		})
+	})
+
+	Convey("Given a POST request with a form too large to read", t, func() {
+		req := newPost("", "token", "token")
+		req.Body = io.NopCloser(strings.NewReader("padding=" + strings.Repeat("a", maxFormBytes) + "&csrf_token=token"))
+
+		Convey("Then it is rejected", func() {
+			So(serve(req), ShouldEqual, http.StatusForbidden)
+		})
+	})
+}
+
+func TestField(t *testing.T) {
+	Convey("Given a token", t, func() {
+		Convey("Then it is embedded in an escaped hidden form field", func() {
+			So(string(Field(`"><script>`)), ShouldEqual, `<input type="hidden" name="csrf_token" value="&#34;&gt;&lt;script&gt;">`)
+		})
+	})
+}
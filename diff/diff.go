@@ -0,0 +1,157 @@
+// Package diff compares a dataset version under review with the latest published version of its edition, so that
+// publishers can see what will change when it is published.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// Fields of a dataset version which are compared
+const (
+	FieldTitle         = "title"
+	FieldDescription   = "description"
+	FieldDistributions = "distributions"
+	FieldAlerts        = "alerts"
+	FieldUsageNotes    = "usage-notes"
+	FieldReleaseDate   = "release-date"
+)
+
+// field is a part of a dataset version which is compared
+type field struct {
+	id        string
+	localeKey string
+	// isList is whether the field holds a list of items, rather than a single value
+	isList bool
+	// values returns the field's values as the lines which are compared and shown to publishers
+	values func(d models.Dataset, v models.Version) []string
+}
+
+// Change is a field whose values differ between the published version and the version under review
+type Change struct {
+	Field     string
+	LocaleKey string
+	IsList    bool
+	// Removed are the values only in the published version
+	Removed []string
+	// Added are the values only in the version under review
+	Added []string
+}
+
+// fields are the fields compared, in the order changes are reported
+var fields = []field{
+	{id: FieldTitle, localeKey: "VersionDiffTitle", values: titleValues},
+	{id: FieldDescription, localeKey: "VersionDiffDescription", values: descriptionValues},
+	{id: FieldDistributions, localeKey: "VersionDiffDistributions", isList: true, values: distributionValues},
+	{id: FieldAlerts, localeKey: "VersionDiffAlerts", isList: true, values: alertValues},
+	{id: FieldUsageNotes, localeKey: "VersionDiffUsageNotes", isList: true, values: usageNoteValues},
+	{id: FieldReleaseDate, localeKey: "VersionDiffReleaseDate", values: releaseDateValues},
+}
+
+// Compare returns the fields which differ between the published dataset version and the version under review
+func Compare(publishedDataset models.Dataset, publishedVersion models.Version, dataset models.Dataset, version models.Version) []Change {
+	var changes []Change
+	for _, f := range fields {
+		removed, added := difference(f.values(publishedDataset, publishedVersion), f.values(dataset, version))
+		if len(removed) == 0 && len(added) == 0 {
+			continue
+		}
+		changes = append(changes, Change{
+			Field:     f.id,
+			LocaleKey: f.localeKey,
+			IsList:    f.isList,
+			Removed:   removed,
+			Added:     added,
+		})
+	}
+	return changes
+}
+
+// difference returns the lines only in previous and the lines only in current, in the order they appear. Lines which
+// are repeated are matched up one for one, so a line repeated more often in one list than the other is reported.
+func difference(previous, current []string) (removed, added []string) {
+	counts := make(map[string]int, len(current))
+	for _, line := range current {
+		counts[line]++
+	}
+	for _, line := range previous {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		removed = append(removed, line)
+	}
+
+	counts = make(map[string]int, len(previous))
+	for _, line := range previous {
+		counts[line]++
+	}
+	for _, line := range current {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		added = append(added, line)
+	}
+	return removed, added
+}
+
+func singleValue(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func titleValues(d models.Dataset, _ models.Version) []string {
+	return singleValue(d.Title)
+}
+
+func descriptionValues(d models.Dataset, _ models.Version) []string {
+	return singleValue(d.Description)
+}
+
+func releaseDateValues(_ models.Dataset, v models.Version) []string {
+	return singleValue(v.ReleaseDate)
+}
+
+// distributionValues describes each distribution without its download URL, which always differs between versions
+func distributionValues(_ models.Dataset, v models.Version) []string {
+	if v.Distributions == nil {
+		return nil
+	}
+	values := make([]string, 0, len(*v.Distributions))
+	for _, dist := range *v.Distributions {
+		format := strings.ToUpper(dist.Format.String())
+		name := dist.Title
+		if name == "" {
+			name = format
+		}
+		values = append(values, fmt.Sprintf("%s (%s, %d bytes)", name, format, dist.ByteSize))
+	}
+	return values
+}
+
+func alertValues(_ models.Dataset, v models.Version) []string {
+	if v.Alerts == nil {
+		return nil
+	}
+	values := make([]string, 0, len(*v.Alerts))
+	for _, a := range *v.Alerts {
+		values = append(values, fmt.Sprintf("%s %s: %s", a.Type, a.Date, a.Description))
+	}
+	return values
+}
+
+func usageNoteValues(_ models.Dataset, v models.Version) []string {
+	if v.UsageNotes == nil {
+		return nil
+	}
+	values := make([]string, 0, len(*v.UsageNotes))
+	for _, n := range *v.UsageNotes {
+		values = append(values, fmt.Sprintf("%s: %s", n.Title, n.Note))
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	publishedDataset = models.Dataset{Title: "Consumer price inflation", Description: "Monthly consumer price indices"}
+	publishedVersion = models.Version{
+		ReleaseDate: "2026-03-18T07:00:00.000Z",
+		Distributions: &[]models.Distribution{
+			{Format: models.DistributionFormatCSV, ByteSize: 1024, DownloadURL: "/cpih01/2026/1.csv"},
+		},
+		Alerts:     &[]models.Alert{{Type: models.AlertTypeCorrection, Date: "2026-03-20", Description: "Corrected March figures"}},
+		UsageNotes: &[]models.UsageNote{{Title: "Coverage", Note: "Covers the UK"}},
+	}
+)
+
+func TestCompare(t *testing.T) {
+	Convey("Given a version with the same metadata as the published version", t, func() {
+		v := publishedVersion
+		v.Distributions = &[]models.Distribution{
+			{Format: models.DistributionFormatCSV, ByteSize: 1024, DownloadURL: "/cpih01/2026/2.csv"},
+		}
+
+		Convey("Then there are no changes, even though its download URLs differ", func() {
+			So(Compare(publishedDataset, publishedVersion, publishedDataset, v), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a version which changes the title and release date", t, func() {
+		d := publishedDataset
+		d.Title = "Consumer price inflation, UK"
+		v := publishedVersion
+		v.ReleaseDate = "2026-04-15T07:00:00.000Z"
+
+		Convey("Then the previous and new values are reported in field order", func() {
+			So(Compare(publishedDataset, publishedVersion, d, v), ShouldResemble, []Change{
+				{
+					Field:     FieldTitle,
+					LocaleKey: "VersionDiffTitle",
+					Removed:   []string{"Consumer price inflation"},
+					Added:     []string{"Consumer price inflation, UK"},
+				},
+				{
+					Field:     FieldReleaseDate,
+					LocaleKey: "VersionDiffReleaseDate",
+					Removed:   []string{"2026-03-18T07:00:00.000Z"},
+					Added:     []string{"2026-04-15T07:00:00.000Z"},
+				},
+			})
+		})
+	})
+
+	Convey("Given a version which adds a distribution, changes a usage note and removes the alerts", t, func() {
+		v := publishedVersion
+		v.Distributions = &[]models.Distribution{
+			{Format: models.DistributionFormatCSV, ByteSize: 1024},
+			{Title: "Supporting tables", Format: models.DistributionFormatXLSX, ByteSize: 2048},
+		}
+		v.UsageNotes = &[]models.UsageNote{{Title: "Coverage", Note: "Covers England and Wales"}}
+		v.Alerts = nil
+
+		Convey("Then only the items which differ are reported", func() {
+			So(Compare(publishedDataset, publishedVersion, publishedDataset, v), ShouldResemble, []Change{
+				{
+					Field:     FieldDistributions,
+					LocaleKey: "VersionDiffDistributions",
+					IsList:    true,
+					Added:     []string{"Supporting tables (XLSX, 2048 bytes)"},
+				},
+				{
+					Field:     FieldAlerts,
+					LocaleKey: "VersionDiffAlerts",
+					IsList:    true,
+					Removed:   []string{"correction 2026-03-20: Corrected March figures"},
+				},
+				{
+					Field:     FieldUsageNotes,
+					LocaleKey: "VersionDiffUsageNotes",
+					IsList:    true,
+					Removed:   []string{"Coverage: Covers the UK"},
+					Added:     []string{"Coverage: Covers England and Wales"},
+				},
+			})
+		})
+	})
+
+	Convey("Given a version which repeats a usage note", t, func() {
+		v := publishedVersion
+		v.UsageNotes = &[]models.UsageNote{{Title: "Coverage", Note: "Covers the UK"}, {Title: "Coverage", Note: "Covers the UK"}}
+
+		Convey("Then the repeated note is reported as added", func() {
+			changes := Compare(publishedDataset, publishedVersion, publishedDataset, v)
+			So(changes, ShouldHaveLength, 1)
+			So(changes[0].Removed, ShouldBeEmpty)
+			So(changes[0].Added, ShouldResemble, []string{"Coverage: Covers the UK"})
+		})
+	})
+}
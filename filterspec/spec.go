@@ -0,0 +1,133 @@
+// Package filterspec describes the selections of a filter output in a form which can be downloaded, shared and used
+// to create the same filter again.
+package filterspec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+)
+
+// Version is the version of the specification format written by this service
+const Version = 1
+
+// ErrInvalidSpec is returned, wrapped with the reason, when a specification fails validation
+var ErrInvalidSpec = errors.New("invalid filter specification")
+
+// Spec is the specification of a filter output
+type Spec struct {
+	SpecVersion    int         `json:"spec_version"`
+	Dataset        Dataset     `json:"dataset"`
+	PopulationType string      `json:"population_type"`
+	Custom         bool        `json:"custom"`
+	Dimensions     []Dimension `json:"dimensions"`
+}
+
+// Dataset identifies the dataset version that a specification was created from
+type Dataset struct {
+	ID      string `json:"id"`
+	Edition string `json:"edition"`
+	Version int    `json:"version"`
+}
+
+// Dimension is a dimension of a specification along with its selected options
+type Dimension struct {
+	Name           string   `json:"name"`
+	IsAreaType     bool     `json:"is_area_type"`
+	FilterByParent string   `json:"filter_by_parent,omitempty"`
+	Options        []string `json:"options"`
+}
+
+// New returns the specification of a filter output
+func New(filterOutput filter.Model) Spec {
+	spec := Spec{
+		SpecVersion: Version,
+		Dataset: Dataset{
+			ID:      filterOutput.Dataset.DatasetID,
+			Edition: filterOutput.Dataset.Edition,
+			Version: filterOutput.Dataset.Version,
+		},
+		PopulationType: filterOutput.PopulationType,
+		Custom:         helpers.IsBoolPtr(filterOutput.Custom),
+		Dimensions:     make([]Dimension, 0, len(filterOutput.Dimensions)),
+	}
+
+	for i := range filterOutput.Dimensions {
+		dimension := &filterOutput.Dimensions[i]
+		dimensionOptions := dimension.Options
+		if dimensionOptions == nil {
+			dimensionOptions = []string{}
+		}
+		spec.Dimensions = append(spec.Dimensions, Dimension{
+			Name:           dimension.Name,
+			IsAreaType:     helpers.IsBoolPtr(dimension.IsAreaType),
+			FilterByParent: dimension.FilterByParent,
+			Options:        dimensionOptions,
+		})
+	}
+
+	return spec
+}
+
+// Validate checks that the specification can be used to create a filter
+func (s *Spec) Validate() error {
+	if s.SpecVersion != Version {
+		return fmt.Errorf("%w: unsupported spec_version %d", ErrInvalidSpec, s.SpecVersion)
+	}
+	if s.Dataset.ID == "" || s.Dataset.Edition == "" || s.Dataset.Version < 1 {
+		return fmt.Errorf("%w: dataset id, edition and version are required", ErrInvalidSpec)
+	}
+	if s.PopulationType == "" {
+		return fmt.Errorf("%w: population_type is required", ErrInvalidSpec)
+	}
+	if len(s.Dimensions) == 0 {
+		return fmt.Errorf("%w: at least one dimension is required", ErrInvalidSpec)
+	}
+
+	names := make(map[string]bool, len(s.Dimensions))
+	areaTypes := 0
+	for i := range s.Dimensions {
+		dimension := &s.Dimensions[i]
+		if dimension.Name == "" {
+			return fmt.Errorf("%w: dimension %d has no name", ErrInvalidSpec, i)
+		}
+		if names[dimension.Name] {
+			return fmt.Errorf("%w: dimension %q is repeated", ErrInvalidSpec, dimension.Name)
+		}
+		names[dimension.Name] = true
+
+		if dimension.IsAreaType {
+			areaTypes++
+		} else if dimension.FilterByParent != "" {
+			return fmt.Errorf("%w: dimension %q is not an area type so cannot be filtered by parent", ErrInvalidSpec, dimension.Name)
+		}
+
+		for _, option := range dimension.Options {
+			if option == "" {
+				return fmt.Errorf("%w: dimension %q has an empty option", ErrInvalidSpec, dimension.Name)
+			}
+		}
+	}
+	if areaTypes != 1 {
+		return fmt.Errorf("%w: exactly one area type dimension is required, found %d", ErrInvalidSpec, areaTypes)
+	}
+
+	return nil
+}
+
+// FilterDimensions returns the dimensions of the specification as filter API dimensions
+func (s *Spec) FilterDimensions() []filter.ModelDimension {
+	dims := make([]filter.ModelDimension, 0, len(s.Dimensions))
+	for i := range s.Dimensions {
+		dimension := &s.Dimensions[i]
+		dims = append(dims, filter.ModelDimension{
+			Name:           dimension.Name,
+			IsAreaType:     helpers.ToBoolPtr(dimension.IsAreaType),
+			Options:        dimension.Options,
+			FilterByParent: dimension.FilterByParent,
+		})
+	}
+	return dims
+}
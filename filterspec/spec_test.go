@@ -0,0 +1,92 @@
+package filterspec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNew(t *testing.T) {
+	Convey("Given a custom filter output", t, func() {
+		filterOutput := filter.Model{
+			Dataset:        filter.Dataset{DatasetID: "TS008", Edition: "2021", Version: 1},
+			PopulationType: "UR",
+			Custom:         helpers.ToBoolPtr(true),
+			Dimensions: []filter.ModelDimension{
+				{Name: "ltla", IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "rgn", Options: []string{"E12000001"}},
+				{Name: "sex", IsAreaType: helpers.ToBoolPtr(false)},
+			},
+		}
+
+		Convey("When the specification is created", func() {
+			spec := New(filterOutput)
+
+			Convey("Then it describes the dataset, population type, dimensions and options", func() {
+				So(spec, ShouldResemble, Spec{
+					SpecVersion:    Version,
+					Dataset:        Dataset{ID: "TS008", Edition: "2021", Version: 1},
+					PopulationType: "UR",
+					Custom:         true,
+					Dimensions: []Dimension{
+						{Name: "ltla", IsAreaType: true, FilterByParent: "rgn", Options: []string{"E12000001"}},
+						{Name: "sex", IsAreaType: false, Options: []string{}},
+					},
+				})
+			})
+
+			Convey("And it is valid", func() {
+				So(spec.Validate(), ShouldBeNil)
+			})
+
+			Convey("And it can be converted back to filter dimensions", func() {
+				So(spec.FilterDimensions(), ShouldResemble, []filter.ModelDimension{
+					{Name: "ltla", IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "rgn", Options: []string{"E12000001"}},
+					{Name: "sex", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+				})
+			})
+		})
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() Spec {
+		return Spec{
+			SpecVersion:    Version,
+			Dataset:        Dataset{ID: "TS008", Edition: "2021", Version: 1},
+			PopulationType: "UR",
+			Dimensions: []Dimension{
+				{Name: "ltla", IsAreaType: true, Options: []string{"E06000001"}},
+				{Name: "sex", Options: []string{}},
+			},
+		}
+	}
+
+	Convey("Given specifications which cannot be used to create a filter", t, func() {
+		tests := map[string]func(s *Spec){
+			"unsupported version":          func(s *Spec) { s.SpecVersion = 2 },
+			"missing dataset":              func(s *Spec) { s.Dataset.ID = "" },
+			"missing population type":      func(s *Spec) { s.PopulationType = "" },
+			"no dimensions":                func(s *Spec) { s.Dimensions = nil },
+			"unnamed dimension":            func(s *Spec) { s.Dimensions[1].Name = "" },
+			"repeated dimension":           func(s *Spec) { s.Dimensions[1].Name = "ltla" },
+			"parent on non area dimension": func(s *Spec) { s.Dimensions[1].FilterByParent = "rgn" },
+			"empty option":                 func(s *Spec) { s.Dimensions[0].Options = []string{""} },
+			"no area type":                 func(s *Spec) { s.Dimensions[0].IsAreaType = false },
+			"two area types":               func(s *Spec) { s.Dimensions[1].IsAreaType = true },
+		}
+
+		for name, modify := range tests {
+			Convey("When the specification has "+name, func() {
+				spec := valid()
+				modify(&spec)
+
+				Convey("Then validation fails", func() {
+					So(errors.Is(spec.Validate(), ErrInvalidSpec), ShouldBeTrue)
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Package flash carries a one-off message across a redirect, so that the page redirected to can show the result of a
+// form submission. The message is kept in a short-lived cookie which is cleared once read.
+package flash
+
+import (
+	"net/http"
+	"time"
+)
+
+// Message identifies a flash message. Only the identifier is stored, so pages decide how each message is shown.
+type Message string
+
+// Messages set by the handlers of this service
+const (
+	ApprovalSucceeded      Message = "approval-succeeded"
+	ApprovalFailed         Message = "approval-failed"
+	RejectionSucceeded     Message = "rejection-succeeded"
+	RejectionFailed        Message = "rejection-failed"
+	ReturnToDraftSucceeded Message = "return-to-draft-succeeded"
+	ReturnToDraftFailed    Message = "return-to-draft-failed"
+)
+
+const (
+	cookieName = "flash"
+	maxAge     = time.Minute
+)
+
+// Set stores the message to be read by the next request
+func Set(w http.ResponseWriter, req *http.Request, m Message) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Value:    string(m),
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Pop returns the message stored by the previous request, if any, and clears it so it is only shown once
+func Pop(w http.ResponseWriter, req *http.Request) (Message, bool) {
+	cookie, err := req.Cookie(cookieName)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return Message(cookie.Value), true
+}
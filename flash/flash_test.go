@@ -0,0 +1,52 @@
+package flash
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFlash(t *testing.T) {
+	Convey("Given a message is set", t, func() {
+		w := httptest.NewRecorder()
+		Set(w, httptest.NewRequest(http.MethodPost, "/", http.NoBody), ApprovalSucceeded)
+
+		cookies := w.Result().Cookies()
+		So(cookies, ShouldHaveLength, 1)
+		So(cookies[0].Value, ShouldEqual, string(ApprovalSucceeded))
+		So(cookies[0].MaxAge, ShouldBeGreaterThan, 0)
+
+		Convey("When the next request pops the message", func() {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.AddCookie(cookies[0])
+			next := httptest.NewRecorder()
+
+			m, ok := Pop(next, req)
+
+			Convey("Then the message is returned", func() {
+				So(ok, ShouldBeTrue)
+				So(m, ShouldEqual, ApprovalSucceeded)
+			})
+
+			Convey("And the cookie is cleared", func() {
+				cleared := next.Result().Cookies()
+				So(cleared, ShouldHaveLength, 1)
+				So(cleared[0].Name, ShouldEqual, cookieName)
+				So(cleared[0].MaxAge, ShouldBeLessThan, 0)
+			})
+		})
+	})
+
+	Convey("Given no message was set", t, func() {
+		w := httptest.NewRecorder()
+		m, ok := Pop(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+		Convey("Then no message is returned and no cookie is written", func() {
+			So(ok, ShouldBeFalse)
+			So(m, ShouldBeEmpty)
+			So(w.Result().Cookies(), ShouldBeEmpty)
+		})
+	})
+}
@@ -17,6 +17,7 @@ require (
 	github.com/ONSdigital/log.go/v2 v2.5.2
 	github.com/PuerkitoBio/goquery v1.11.0
 	github.com/go-jose/go-jose/v4 v4.1.3
+	github.com/golang-jwt/jwt/v4 v4.5.2
 	github.com/golang/glog v1.2.5
 	github.com/golang/mock v1.6.0
 	github.com/gorilla/mux v1.8.1
@@ -43,7 +44,6 @@ require (
 	github.com/fsnotify/fsnotify v1.9.0 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
-	github.com/golang-jwt/jwt/v4 v4.5.2 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/gopherjs/gopherjs v1.17.2 // indirect
 	github.com/gosimple/slug v1.15.0 // indirect
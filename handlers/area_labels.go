@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/log.go/v2/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxConcurrentAreaRequests is the number of area labels requested from the population API at once
+	maxConcurrentAreaRequests = 10
+	// areaBatchSize is the number of areas of an area type listed from the population API in each page
+	areaBatchSize = 1000
+)
+
+// areaLabelResolver looks up the labels of areas, using the cache where possible
+type areaLabelResolver struct {
+	pc              clients.PopulationClient
+	cache           *cache.AreaLabelCache
+	userAccessToken string
+}
+
+// resolve returns the labels of the given areas in the same order as the area codes. When more than one label is not
+// cached, the areas of the area type are listed from the population API a page at a time to find them. Any labels
+// which are still not found are requested individually with at most maxConcurrentAreaRequests in flight, and each area
+// is only requested once. The first error cancels the outstanding requests and is returned.
+func (r areaLabelResolver) resolve(ctx context.Context, populationType, areaType string, areas []string) ([]string, error) {
+	labels := make([]string, len(areas))
+
+	// group the positions of each area that is not cached, so that repeated areas are only requested once
+	uncached := make(map[string][]int)
+	var uncachedAreas []string
+	for i, area := range areas {
+		if label, ok := r.cache.Get(populationType, areaType, area); ok {
+			labels[i] = label
+			continue
+		}
+		if _, ok := uncached[area]; !ok {
+			uncachedAreas = append(uncachedAreas, area)
+		}
+		uncached[area] = append(uncached[area], i)
+	}
+
+	requested := len(uncachedAreas)
+	if requested > 1 {
+		uncachedAreas = r.list(ctx, populationType, areaType, uncachedAreas, func(area, label string) {
+			for _, i := range uncached[area] {
+				labels[i] = label
+			}
+		})
+	}
+
+	if len(uncachedAreas) == 0 {
+		return labels, nil
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentAreaRequests)
+
+	for _, area := range uncachedAreas {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+
+			resp, err := r.pc.GetArea(gctx, population.GetAreaInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: r.userAccessToken,
+				},
+				PopulationType: populationType,
+				AreaType:       areaType,
+				Area:           area,
+			})
+			if err != nil {
+				log.Error(ctx, "failed to get area", err, log.Data{
+					"population_type": populationType,
+					"area_type":       areaType,
+					"area":            area,
+				})
+				return err
+			}
+
+			// each area has its own positions, so no two goroutines write to the same label
+			for _, i := range uncached[area] {
+				labels[i] = resp.Area.Label
+			}
+			r.cache.Set(populationType, areaType, area, resp.Area.Label)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to get dimension areas: %w", err)
+	}
+
+	log.Info(ctx, "resolved area labels", log.Data{
+		"population_type": populationType,
+		"area_type":       areaType,
+		"areas":           len(areas),
+		"requested":       requested,
+		"looked_up":       len(uncachedAreas),
+	})
+
+	return labels, nil
+}
+
+// list pages through the areas of an area type in the population API, calling found with the label of each of the
+// given areas and caching it. Paging stops once every area is found, or once there are at least as many pages left as
+// areas still to be found, as looking those up individually is then no more expensive. The areas which were not found are returned, and an
+// error listing the areas is logged rather than returned so that the areas can still be looked up individually.
+func (r areaLabelResolver) list(ctx context.Context, populationType, areaType string, areas []string, found func(area, label string)) []string {
+	remaining := make(map[string]bool, len(areas))
+	for _, area := range areas {
+		remaining[area] = true
+	}
+
+	for offset := 0; len(remaining) > 0; offset += areaBatchSize {
+		resp, err := r.pc.GetAreas(ctx, population.GetAreasInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: r.userAccessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit:  areaBatchSize,
+				Offset: offset,
+			},
+			PopulationType: populationType,
+			AreaTypeID:     areaType,
+		})
+		if err != nil {
+			log.Warn(ctx, "failed to list areas, looking up area labels individually", log.FormatErrors([]error{err}), log.Data{
+				"population_type": populationType,
+				"area_type":       areaType,
+				"offset":          offset,
+			})
+			break
+		}
+
+		for _, area := range resp.Areas {
+			if remaining[area.ID] {
+				delete(remaining, area.ID)
+				found(area.ID, area.Label)
+				r.cache.Set(populationType, areaType, area.ID, area.Label)
+			}
+		}
+
+		next := offset + areaBatchSize
+		pagesLeft := (resp.TotalCount - next + areaBatchSize - 1) / areaBatchSize
+		if len(resp.Areas) == 0 || pagesLeft <= 0 || pagesLeft >= len(remaining) {
+			break
+		}
+	}
+
+	notFound := make([]string, 0, len(remaining))
+	for _, area := range areas {
+		if remaining[area] {
+			notFound = append(notFound, area)
+		}
+	}
+	return notFound
+}
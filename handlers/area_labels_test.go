@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAreaLabelResolver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := context.Background()
+
+	areas := []string{}
+	for i := 0; i < 40; i++ {
+		areas = append(areas, string(rune('A'+i%26))+string(rune('a'+i/26)))
+	}
+
+	Convey("Given a resolver with an empty cache", t, func() {
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		resolver := areaLabelResolver{pc: mockPc, cache: cache.NewAreaLabelCache(time.Hour, 100), userAccessToken: userAuthToken}
+		mockPc.EXPECT().GetAreas(gomock.Any(), gomock.Any()).
+			Return(population.GetAreasResponse{}, errors.New("areas cannot be listed")).Times(1)
+
+		var inFlight, maxInFlight int32
+		mockPc.EXPECT().GetArea(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input population.GetAreaInput) (population.GetAreaResponse, error) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					highest := atomic.LoadInt32(&maxInFlight)
+					if current <= highest || atomic.CompareAndSwapInt32(&maxInFlight, highest, current) {
+						break
+					}
+				}
+				if input.PopulationType != "UR" || input.AreaType != "ltla" {
+					return population.GetAreaResponse{}, errors.New("unexpected area type")
+				}
+
+				// vary the response time by area so the responses arrive out of order
+				time.Sleep(time.Duration(input.Area[0]%5) * time.Millisecond)
+				return population.GetAreaResponse{Area: population.Area{ID: input.Area, Label: "Label " + input.Area}}, nil
+			}).Times(len(areas))
+
+		Convey("When the labels of the areas are resolved", func() {
+			labels, err := resolver.resolve(ctx, "UR", "ltla", areas)
+
+			Convey("Then the labels are returned in the order of the areas", func() {
+				So(err, ShouldBeNil)
+				So(labels, ShouldHaveLength, len(areas))
+				for i := range areas {
+					So(labels[i], ShouldEqual, "Label "+areas[i])
+				}
+			})
+
+			Convey("Then no more than the maximum number of requests are made at once", func() {
+				So(atomic.LoadInt32(&maxInFlight), ShouldBeLessThanOrEqualTo, maxConcurrentAreaRequests)
+			})
+
+			Convey("And the labels are resolved again, including a repeated area", func() {
+				labels, err = resolver.resolve(ctx, "UR", "ltla", []string{areas[1], areas[0], areas[1]})
+
+				Convey("Then the cached labels are returned without requesting them again", func() {
+					So(err, ShouldBeNil)
+					So(labels, ShouldResemble, []string{"Label " + areas[1], "Label " + areas[0], "Label " + areas[1]})
+				})
+			})
+		})
+	})
+
+	Convey("Given the same area is selected more than once", t, func() {
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		resolver := areaLabelResolver{pc: mockPc, userAccessToken: userAuthToken}
+		mockPc.EXPECT().GetArea(gomock.Any(), gomock.Any()).
+			Return(population.GetAreaResponse{Area: population.Area{Label: "Hartlepool"}}, nil).Times(1)
+
+		Convey("When the labels are resolved without a cache", func() {
+			labels, err := resolver.resolve(ctx, "UR", "ltla", []string{"E06000001", "E06000001"})
+
+			Convey("Then the area is only requested once", func() {
+				So(err, ShouldBeNil)
+				So(labels, ShouldResemble, []string{"Hartlepool", "Hartlepool"})
+			})
+		})
+	})
+
+	Convey("Given the population API fails for an area", t, func() {
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		areaCache := cache.NewAreaLabelCache(time.Hour, 100)
+		resolver := areaLabelResolver{pc: mockPc, cache: areaCache, userAccessToken: userAuthToken}
+		apiErr := errors.New("population api error")
+		mockPc.EXPECT().GetAreas(gomock.Any(), gomock.Any()).Return(population.GetAreasResponse{}, apiErr).Times(1)
+		mockPc.EXPECT().GetArea(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(reqCtx context.Context, input population.GetAreaInput) (population.GetAreaResponse, error) {
+				if input.Area == areas[0] {
+					return population.GetAreaResponse{}, apiErr
+				}
+				<-reqCtx.Done()
+				return population.GetAreaResponse{}, reqCtx.Err()
+			}).MinTimes(1).MaxTimes(maxConcurrentAreaRequests)
+
+		Convey("When the labels of the areas are resolved", func() {
+			labels, err := resolver.resolve(ctx, "UR", "ltla", areas)
+
+			Convey("Then the error is returned and nothing is cached", func() {
+				So(errors.Is(err, apiErr), ShouldBeTrue)
+				So(labels, ShouldBeNil)
+				_, ok := areaCache.Get("UR", "ltla", areas[1])
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given the areas can be listed a page at a time", t, func() {
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		areaCache := cache.NewAreaLabelCache(time.Hour, 100)
+		resolver := areaLabelResolver{pc: mockPc, cache: areaCache, userAccessToken: userAuthToken}
+
+		// two pages of areas
+		page := func(ids ...string) population.GetAreasResponse {
+			resp := population.GetAreasResponse{PaginationResponse: population.PaginationResponse{TotalCount: 2 * areaBatchSize}}
+			for _, id := range ids {
+				resp.Areas = append(resp.Areas, population.Area{ID: id, Label: "Label " + id})
+			}
+			return resp
+		}
+		var offsets []int
+		mockPc.EXPECT().GetAreas(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input population.GetAreasInput) (population.GetAreasResponse, error) {
+				offsets = append(offsets, input.Offset)
+				if input.PopulationType != "UR" || input.AreaTypeID != "ltla" || input.Limit != areaBatchSize {
+					return population.GetAreasResponse{}, errors.New("unexpected areas input")
+				}
+				if input.Offset == 0 {
+					return page(areas[0], areas[1], "other"), nil
+				}
+				return page(areas[2], areas[3]), nil
+			}).AnyTimes()
+
+		Convey("When the labels of areas on both pages are resolved", func() {
+			labels, err := resolver.resolve(ctx, "UR", "ltla", areas[:4])
+
+			Convey("Then the labels are found by listing the areas", func() {
+				So(err, ShouldBeNil)
+				So(labels, ShouldResemble, []string{"Label " + areas[0], "Label " + areas[1], "Label " + areas[2], "Label " + areas[3]})
+				So(offsets, ShouldResemble, []int{0, areaBatchSize})
+			})
+
+			Convey("Then only the selected areas are cached", func() {
+				So(areaCache.Len(), ShouldEqual, 4)
+			})
+		})
+
+		Convey("When no more areas are left to find than pages to list", func() {
+			mockPc.EXPECT().GetArea(gomock.Any(), gomock.Any()).
+				Return(population.GetAreaResponse{Area: population.Area{ID: areas[3], Label: "Label " + areas[3]}}, nil).Times(1)
+
+			labels, err := resolver.resolve(ctx, "UR", "ltla", []string{areas[0], areas[3]})
+
+			Convey("Then listing stops and the remaining area is requested individually", func() {
+				So(err, ShouldBeNil)
+				So(labels, ShouldResemble, []string{"Label " + areas[0], "Label " + areas[3]})
+				So(offsets, ShouldResemble, []int{0})
+			})
+		})
+	})
+}
@@ -1,39 +1,55 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // CreateCustomDataset will load the create custom dataset page
 func CreateCustomDataset(pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		createCustomDataset(w, req, pc, zc, rend, collectionID, lang, userAccessToken)
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		createCustomDataset(w, req, pc, zc, rend, cfg, collectionID, lang, userAccessToken)
 	})
 }
 
-func createCustomDataset(w http.ResponseWriter, req *http.Request, pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, collectionID, lang, userAccessToken string) {
-	ctx := req.Context()
+func createCustomDataset(w http.ResponseWriter, req *http.Request, pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, collectionID, lang, userAccessToken string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
 
-	homepageContent, err := zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
-	populationTypes, err := pc.GetPopulationTypes(ctx, population.GetPopulationTypesInput{
-		DefaultDatasets: true,
-		AuthTokens: population.AuthTokens{
-			UserAuthToken: userAccessToken,
-		},
-		PaginationParams: population.PaginationParams{
-			Limit: 1000,
-		},
+	var populationTypes population.GetPopulationTypesResponse
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		populationTypes, err = pc.GetPopulationTypes(ctx, population.GetPopulationTypesInput{
+			DefaultDatasets: true,
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: userAccessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+		})
+		return err
 	})
 	if err != nil {
 		log.Error(ctx, "unable to get population types", err)
@@ -41,7 +57,266 @@ func createCustomDataset(w http.ResponseWriter, req *http.Request, pc clients.Po
 		return
 	}
 
+	csrfToken, err := csrf.Token(w, req)
+	if logError(ctx, w, err, "failed to create csrf token", nil) {
+		return
+	}
+
 	basePage := rend.NewBasePageModel()
 	page := mapper.CreateCustomDatasetPage(req, basePage, populationTypes.Items, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	page.CSRFToken = csrfToken
+	page.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
 	rend.BuildPage(w, page, "create-custom-dataset")
 }
+
+// CreateCustomDatasetFromLink shows the population type, area type and dimensions given in the query string of a link
+// for confirmation, so that links can open a prepared table. Nothing is created until the selections are submitted to
+// PostCreateCustomDataset.
+func CreateCustomDatasetFromLink(pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		createCustomDatasetFromLink(w, req, pc, zc, rend, cfg, lang, collectionID, userAccessToken)
+	})
+}
+
+// customDatasetSelection is the population type, area type and dimensions requested by a custom dataset link
+type customDatasetSelection struct {
+	PopulationType string
+	AreaType       string
+	Dimensions     []string
+}
+
+// customDatasetDescription is the population type, area type and dimensions of a valid custom dataset selection as
+// described by the population types API
+type customDatasetDescription struct {
+	PopulationType population.PopulationType
+	AreaType       population.Dimension
+	Dimensions     []population.Dimension
+}
+
+func createCustomDatasetFromLink(w http.ResponseWriter, req *http.Request, pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, lang, collectionID, userAccessToken string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+	selection := parseCustomDatasetSelection(req.URL.Query())
+	logData := log.Data{
+		"population_type": selection.PopulationType,
+		"area_type":       selection.AreaType,
+		"dimensions":      selection.Dimensions,
+	}
+
+	if selection.PopulationType == "" {
+		http.Redirect(w, req, "/datasets/create?error=true", http.StatusFound)
+		return
+	}
+
+	description, err := validateCustomDatasetSelection(ctx, budget, pc, userAccessToken, selection)
+	if errors.Is(err, errInvalidCustomSelection) {
+		log.Warn(ctx, "custom dataset link has an invalid selection", log.FormatErrors([]error{err}), logData)
+		http.Redirect(w, req, "/datasets/create?error=true", http.StatusFound)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "failed to validate custom dataset selection", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	csrfToken, err := csrf.Token(w, req)
+	if logError(ctx, w, err, "failed to create csrf token", nil) {
+		return
+	}
+
+	basePage := rend.NewBasePageModel()
+	page := mapper.CreateConfirmCustomDatasetPage(req, basePage, description.PopulationType, description.AreaType, description.Dimensions, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	page.CSRFToken = csrfToken
+	page.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
+	rend.BuildPage(w, page, "confirm-custom-dataset")
+}
+
+// createCustomDatasetFromSelection creates a custom dataset with the area type and dimensions of a confirmed custom
+// dataset link and redirects to it
+func createCustomDatasetFromSelection(w http.ResponseWriter, req *http.Request, pc clients.PopulationClient, fc clients.FilterClient, cfg config.Config, collectionID, userAccessToken string, selection customDatasetSelection) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+	logData := log.Data{
+		"population_type": selection.PopulationType,
+		"area_type":       selection.AreaType,
+		"dimensions":      selection.Dimensions,
+	}
+
+	_, err := validateCustomDatasetSelection(ctx, budget, pc, userAccessToken, selection)
+	if errors.Is(err, errInvalidCustomSelection) {
+		log.Warn(ctx, "custom dataset has an invalid selection", log.FormatErrors([]error{err}), logData)
+		http.Redirect(w, req, "/datasets/create?error=true", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "failed to validate custom dataset selection", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	var metadata population.GetPopulationTypeMetadataResponse
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		metadata, err = pc.GetPopulationTypeMetadata(ctx, population.GetPopulationTypeMetadataInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: userAccessToken,
+			},
+			PopulationType: selection.PopulationType,
+		})
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get population type metadata", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	dims := []filter.ModelDimension{{Name: selection.AreaType, IsAreaType: helpers.ToBoolPtr(true)}}
+	for _, dimension := range selection.Dimensions {
+		dims = append(dims, filter.ModelDimension{Name: dimension, IsAreaType: helpers.ToBoolPtr(false)})
+	}
+
+	var filterID string
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		filterID, _, err = fc.CreateFlexibleBlueprintCustom(ctx, userAccessToken, "", "", filter.CreateFlexBlueprintCustomRequest{
+			Dataset: filter.Dataset{
+				DatasetID: metadata.DefaultDatasetID,
+				Edition:   metadata.Edition,
+				Version:   metadata.Version,
+			},
+			Dimensions:     dims,
+			PopulationType: selection.PopulationType,
+			CollectionID:   collectionID,
+		})
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "failed to create new custom filter", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	log.Info(ctx, "created custom filter from selection", log.Data{"filter_id": filterID})
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusSeeOther)
+}
+
+// parseCustomDatasetSelection reads the selection from the query string of a link or the fields of the confirmation
+// form, ignoring empty and repeated dimensions
+func parseCustomDatasetSelection(query url.Values) customDatasetSelection {
+	selection := customDatasetSelection{
+		PopulationType: strings.TrimSpace(query.Get("populationType")),
+		AreaType:       strings.TrimSpace(query.Get("area_type")),
+	}
+
+	seen := map[string]bool{}
+	for _, dimension := range strings.Split(query.Get("dimensions"), ",") {
+		dimension = strings.TrimSpace(dimension)
+		if dimension == "" || seen[dimension] {
+			continue
+		}
+		seen[dimension] = true
+		selection.Dimensions = append(selection.Dimensions, dimension)
+	}
+
+	return selection
+}
+
+// validateCustomDatasetSelection checks that the population type exists, that the area type and dimensions are
+// dimensions of the population type and that each dimension is one of the categorisations of its variable, returning
+// their descriptions. An area type is only required when dimensions are given. errInvalidCustomSelection is wrapped
+// in the returned error when the selection is not valid.
+func validateCustomDatasetSelection(ctx context.Context, budget *dependencyBudget, pc clients.PopulationClient, userAccessToken string, selection customDatasetSelection) (customDatasetDescription, error) {
+	var description customDatasetDescription
+	authTokens := population.AuthTokens{
+		UserAuthToken: userAccessToken,
+	}
+
+	if selection.AreaType == "" && len(selection.Dimensions) > 0 {
+		return description, fmt.Errorf("%w: area type is required", errInvalidCustomSelection)
+	}
+	if slices.Contains(selection.Dimensions, selection.AreaType) {
+		return description, fmt.Errorf("%w: area type %q is also given as a dimension", errInvalidCustomSelection, selection.AreaType)
+	}
+
+	var populationTypes population.GetPopulationTypesResponse
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		populationTypes, err = pc.GetPopulationTypes(ctx, population.GetPopulationTypesInput{
+			DefaultDatasets: true,
+			AuthTokens:      authTokens,
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return description, err
+	}
+	i := slices.IndexFunc(populationTypes.Items, func(p population.PopulationType) bool { return p.Name == selection.PopulationType })
+	if i < 0 {
+		return description, fmt.Errorf("%w: unknown population type %q", errInvalidCustomSelection, selection.PopulationType)
+	}
+	description.PopulationType = populationTypes.Items[i]
+
+	// links which only choose the population type behave as though the population type form had been submitted
+	if selection.AreaType == "" {
+		return description, nil
+	}
+
+	dimensionIDs := append([]string{selection.AreaType}, selection.Dimensions...)
+	var descriptions population.GetDimensionsResponse
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		descriptions, err = pc.GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
+			AuthTokens:     authTokens,
+			PopulationType: selection.PopulationType,
+			DimensionIDs:   dimensionIDs,
+		})
+		return err
+	})
+	if err != nil {
+		return description, err
+	}
+	for _, id := range dimensionIDs {
+		j := slices.IndexFunc(descriptions.Dimensions, func(d population.Dimension) bool { return d.ID == id })
+		if j < 0 {
+			return description, fmt.Errorf("%w: unknown dimension %q", errInvalidCustomSelection, id)
+		}
+		if id == selection.AreaType {
+			description.AreaType = descriptions.Dimensions[j]
+			continue
+		}
+		description.Dimensions = append(description.Dimensions, descriptions.Dimensions[j])
+	}
+
+	err = forEachDimension(ctx, len(selection.Dimensions), func(ctx context.Context, i int) error {
+		dimension := selection.Dimensions[i]
+		var cats population.GetCategorisationsResponse
+		err := budget.critical(ctx, func(ctx context.Context) (err error) {
+			cats, err = pc.GetCategorisations(ctx, population.GetCategorisationsInput{
+				AuthTokens: authTokens,
+				PaginationParams: population.PaginationParams{
+					Limit: 1000,
+				},
+				PopulationType: selection.PopulationType,
+				Dimension:      dimension,
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(cats.Items, func(d population.Dimension) bool { return d.ID == dimension }) {
+			return fmt.Errorf("%w: %q is not a categorisation", errInvalidCustomSelection, dimension)
+		}
+		return nil
+	})
+	return description, err
+}
@@ -1,15 +1,21 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dis-design-system-go/helper"
 	core "github.com/ONSdigital/dis-design-system-go/model"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/custom"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
@@ -37,8 +43,11 @@ func TestCreateCustomDatasetHandlers(t *testing.T) {
 	}
 
 	Convey("Given the expected calls to render a Create Custom Dataset page", t, func() {
+		var page custom.Page
 		rend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
-		rend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "create-custom-dataset")
+		rend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "create-custom-dataset").Do(func(_ io.Writer, m interface{}, _ string) {
+			page = m.(custom.Page)
+		})
 
 		zc.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
 		pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(mockPopulationTypes, nil)
@@ -54,6 +63,190 @@ func TestCreateCustomDatasetHandlers(t *testing.T) {
 			Convey("Then it returns StatusOK", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
 			})
+
+			Convey("Then the form is given the token of the csrf cookie set", func() {
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, csrf.FieldName)
+				So(page.CSRFToken, ShouldEqual, cookies[0].Value)
+			})
+		})
+
+		Convey("When the page is rendered behind the security middleware", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/create", http.NoBody)
+
+			router := mux.NewRouter()
+			router.Use(security.Middleware(false, time.Hour))
+			router.HandleFunc("/datasets/create", CreateCustomDataset(pc, zc, rend, cfg, ""))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the page's scripts are given the nonce of the policy", func() {
+				So(page.CSPNonce, ShouldNotBeEmpty)
+				So(w.Header().Get("Content-Security-Policy"), ShouldEqual, security.Policy(page.CSPNonce))
+			})
+		})
+	})
+}
+
+func TestCreateCustomDatasetFromLink(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	populationTypes := population.GetPopulationTypesResponse{
+		Items: []population.PopulationType{{Name: "UR", Label: "All usual residents"}, {Name: "HH", Label: "All households"}},
+	}
+	descriptions := population.GetDimensionsResponse{
+		Dimensions: []population.Dimension{{ID: "ltla", Label: "Lower tier local authorities"}, {ID: "sex", Label: "Sex"}, {ID: "age_6a", Label: "Age"}},
+	}
+	categorisations := map[string]population.GetCategorisationsResponse{
+		"sex":    {Items: []population.Dimension{{ID: "sex"}}},
+		"age_6a": {Items: []population.Dimension{{ID: "age_86a"}, {ID: "age_6a"}}},
+	}
+
+	serveLink := func(pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", target, http.NoBody)
+
+		router := mux.NewRouter()
+		router.Path("/datasets/create").Queries("populationType", "{populationType}").HandlerFunc(CreateCustomDatasetFromLink(pc, zc, rend, cfg))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	expectValidation := func(pc *clients.MockPopulationClient) {
+		pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(populationTypes, nil)
+		pc.EXPECT().GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
+			AuthTokens:     population.AuthTokens{UserAuthToken: userAuthToken},
+			PopulationType: "UR",
+			DimensionIDs:   []string{"ltla", "sex", "age_6a"},
+		}).Return(descriptions, nil)
+		pc.EXPECT().GetCategorisations(ctx, gomock.Any()).DoAndReturn(func(_ interface{}, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+			return categorisations[input.Dimension], nil
+		}).Times(2)
+	}
+
+	expectConfirmation := func(zc *clients.MockZebedeeClient, rend *clients.MockRenderClient, page *custom.ConfirmPage) {
+		zc.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+		rend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		rend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "confirm-custom-dataset").Do(func(_ io.Writer, m interface{}, _ string) {
+			*page = m.(custom.ConfirmPage)
+		})
+	}
+
+	Convey("Given a link with a valid population type, area type and dimensions", t, func() {
+		pc := clients.NewMockPopulationClient(mockCtrl)
+		zc := clients.NewMockZebedeeClient(mockCtrl)
+		rend := clients.NewMockRenderClient(mockCtrl)
+		var page custom.ConfirmPage
+		expectValidation(pc)
+		expectConfirmation(zc, rend, &page)
+
+		Convey("When the link is followed", func() {
+			w := serveLink(pc, zc, rend, "/datasets/create?populationType=UR&dimensions=sex,age_6a,sex,&area_type=ltla")
+
+			Convey("Then the selection is shown for confirmation without creating a custom filter", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Location"), ShouldBeEmpty)
+				So(page.ConfirmCustomDatasetPage.PopulationType.Name, ShouldEqual, "UR")
+				So(page.ConfirmCustomDatasetPage.PopulationType.Label, ShouldEqual, "All usual residents")
+				So(page.ConfirmCustomDatasetPage.AreaType, ShouldResemble, custom.Selection{ID: "ltla", Label: "Lower tier local authorities"})
+				So(page.ConfirmCustomDatasetPage.Dimensions, ShouldResemble, []custom.Selection{{ID: "sex", Label: "Sex"}, {ID: "age_6a", Label: "Age"}})
+				So(page.ConfirmCustomDatasetPage.DimensionIDs, ShouldEqual, "sex,age_6a")
+			})
+
+			Convey("Then the confirmation form is given the token of the csrf cookie set", func() {
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, csrf.FieldName)
+				So(page.CSRFToken, ShouldEqual, cookies[0].Value)
+			})
+		})
+	})
+
+	Convey("Given a link with only a population type", t, func() {
+		pc := clients.NewMockPopulationClient(mockCtrl)
+		pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(populationTypes, nil)
+		zc := clients.NewMockZebedeeClient(mockCtrl)
+		rend := clients.NewMockRenderClient(mockCtrl)
+		var page custom.ConfirmPage
+		expectConfirmation(zc, rend, &page)
+
+		Convey("When the link is followed", func() {
+			w := serveLink(pc, zc, rend, "/datasets/create?populationType=UR")
+
+			Convey("Then the population type is shown for confirmation", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(page.ConfirmCustomDatasetPage.PopulationType.Name, ShouldEqual, "UR")
+				So(page.ConfirmCustomDatasetPage.AreaType.ID, ShouldBeEmpty)
+				So(page.ConfirmCustomDatasetPage.Dimensions, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given links with invalid selections", t, func() {
+		zc := clients.NewMockZebedeeClient(mockCtrl)
+		rend := clients.NewMockRenderClient(mockCtrl)
+
+		Convey("When the link has no area type", func() {
+			w := serveLink(clients.NewMockPopulationClient(mockCtrl), zc, rend, "/datasets/create?populationType=UR&dimensions=sex")
+
+			Convey("Then the user is redirected to the create page with an error", func() {
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldEqual, "/datasets/create?error=true")
+			})
+		})
+
+		Convey("When the link has an unknown population type", func() {
+			pc := clients.NewMockPopulationClient(mockCtrl)
+			pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(populationTypes, nil)
+			w := serveLink(pc, zc, rend, "/datasets/create?populationType=XX&dimensions=sex&area_type=ltla")
+
+			Convey("Then the user is redirected to the create page with an error", func() {
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldEqual, "/datasets/create?error=true")
+			})
+		})
+
+		Convey("When the link has a dimension which is not described", func() {
+			pc := clients.NewMockPopulationClient(mockCtrl)
+			pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(populationTypes, nil)
+			pc.EXPECT().GetDimensionsDescription(ctx, gomock.Any()).Return(descriptions, nil)
+			w := serveLink(pc, zc, rend, "/datasets/create?populationType=UR&dimensions=sex,unknown&area_type=ltla")
+
+			Convey("Then the user is redirected to the create page with an error", func() {
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldEqual, "/datasets/create?error=true")
+			})
+		})
+
+		Convey("When the link has a dimension which is not a categorisation", func() {
+			pc := clients.NewMockPopulationClient(mockCtrl)
+			pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(populationTypes, nil)
+			pc.EXPECT().GetDimensionsDescription(ctx, gomock.Any()).Return(descriptions, nil)
+			pc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{Items: []population.Dimension{{ID: "sex_3a"}}}, nil)
+			w := serveLink(pc, zc, rend, "/datasets/create?populationType=UR&dimensions=sex&area_type=ltla")
+
+			Convey("Then the user is redirected to the create page with an error", func() {
+				So(w.Code, ShouldEqual, http.StatusFound)
+				So(w.Header().Get("Location"), ShouldEqual, "/datasets/create?error=true")
+			})
+		})
+	})
+
+	Convey("Given the population API is unavailable", t, func() {
+		pc := clients.NewMockPopulationClient(mockCtrl)
+		pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(population.GetPopulationTypesResponse{}, errors.New("population api error"))
+
+		Convey("When the link is followed", func() {
+			w := serveLink(pc, clients.NewMockZebedeeClient(mockCtrl), clients.NewMockRenderClient(mockCtrl), "/datasets/create?populationType=UR&dimensions=sex&area_type=ltla")
+
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
 		})
 	})
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
-	"github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
@@ -19,7 +18,7 @@ const maxFormBytes = 8 * 1024 // 8KB as only one form value is expected
 
 // CreateFilterID controls the creating of a filter idea when a new user journey is requested
 func CreateFilterID(c clients.FilterClient, dc clients.APIClientsGoDatasetClient) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
 		vars := mux.Vars(req)
 		datasetID := vars["datasetID"]
 		edition := vars["editionID"]
@@ -62,7 +61,7 @@ func CreateFilterID(c clients.FilterClient, dc clients.APIClientsGoDatasetClient
 
 // CreateFilterFlexID creates a new filter ID for filter flex journeys
 func CreateFilterFlexID(fc clients.FilterClient, dc clients.APIClientsGoDatasetClient) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
 		vars := mux.Vars(req)
 		datasetID := vars["datasetID"]
 		edition := vars["editionID"]
@@ -116,7 +115,7 @@ func CreateFilterFlexID(fc clients.FilterClient, dc clients.APIClientsGoDatasetC
 
 // CreateFilterFlexIDFromOutput creates a new filter ID for filter flex journeys from the user's filter output
 func CreateFilterFlexIDFromOutput(fc clients.FilterClient) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
 		vars := mux.Vars(req)
 		filterOutputID := vars["filterOutputID"]
 		ctx := req.Context()
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,22 +10,24 @@ import (
 	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
 	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	dpHandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // DatasetData handles requests for JSON dataset data
-func DatasetData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool) http.HandlerFunc {
-	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
-		datasetData(r, w, datasetAPIClient, topicAPIClient, isPublishing, accessToken)
+func DatasetData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		datasetData(r, w, datasetAPIClient, topicAPIClient, cfg, accessToken, collectionID)
 	})
 }
 
-func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool, accessToken string) {
-	ctx := r.Context()
+func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, accessToken, collectionID string) {
+	budget, ctx, cancel := newDependencyBudget(r.Context(), cfg)
+	defer cancel()
 
 	vars := mux.Vars(r)
 	topicSlug := vars["topic"]
@@ -35,9 +38,13 @@ func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		"datasetID": datasetID,
 	}
 
-	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken}
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken, CollectionID: collectionID}
 
-	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	var dataset datasetAPIModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch dataset", err, logData)
 		setStatusCode(ctx, w, err)
@@ -50,7 +57,11 @@ func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
-	topicList, err := clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, isPublishing, accessToken)
+	var topicList []*topicModel.Topic
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		topicList, err = clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, accessToken)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch topics", err, logData)
 		setStatusCode(ctx, w, err)
@@ -64,16 +75,14 @@ func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
+	// The dataset can be viewed under any of its topics, otherwise permanently redirect to the primary (canonical) topic
 	expectedTopicSlug := topicSlugs[0]
-	if expectedTopicSlug != topicSlug {
+	if !helpers.HasStringInSlice(topicSlug, topicSlugs) {
 		logData["providedTopicSlug"] = topicSlug
 		logData["expectedTopicSlug"] = expectedTopicSlug
-		log.Info(ctx, "incorrect topic slug provided, redirecting to correct topic", logData)
-
-		redirectPath := helpers.ReplaceFirstPathSegment(r.URL.Path, expectedTopicSlug)
+		log.Info(ctx, "incorrect topic slug provided, redirecting to canonical topic", logData)
 
-		//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
-		http.Redirect(w, r, redirectPath, http.StatusFound)
+		redirectToCanonicalTopic(w, r, expectedTopicSlug)
 		return
 	}
 
@@ -92,14 +101,15 @@ func datasetData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 }
 
 // EditionData handles requests for JSON edition data
-func EditionData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool) http.HandlerFunc {
-	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
-		editionData(r, w, datasetAPIClient, topicAPIClient, isPublishing, accessToken)
+func EditionData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		editionData(r, w, datasetAPIClient, topicAPIClient, cfg, accessToken, collectionID)
 	})
 }
 
-func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool, accessToken string) {
-	ctx := r.Context()
+func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, accessToken, collectionID string) {
+	budget, ctx, cancel := newDependencyBudget(r.Context(), cfg)
+	defer cancel()
 
 	vars := mux.Vars(r)
 	topicSlug := vars["topic"]
@@ -112,9 +122,13 @@ func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		"editionID": editionID,
 	}
 
-	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken}
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken, CollectionID: collectionID}
 
-	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	var dataset datasetAPIModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch dataset", err, logData)
 		setStatusCode(ctx, w, err)
@@ -127,7 +141,11 @@ func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
-	topicList, err := clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, isPublishing, accessToken)
+	var topicList []*topicModel.Topic
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		topicList, err = clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, accessToken)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch topics", err, logData)
 		setStatusCode(ctx, w, err)
@@ -141,21 +159,23 @@ func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
+	// The dataset can be viewed under any of its topics, otherwise permanently redirect to the primary (canonical) topic
 	expectedTopicSlug := topicSlugs[0]
-	if expectedTopicSlug != topicSlug {
+	if !helpers.HasStringInSlice(topicSlug, topicSlugs) {
 		logData["providedTopicSlug"] = topicSlug
 		logData["expectedTopicSlug"] = expectedTopicSlug
-		log.Info(ctx, "incorrect topic slug provided, redirecting to correct topic", logData)
-
-		redirectPath := helpers.ReplaceFirstPathSegment(r.URL.Path, expectedTopicSlug)
+		log.Info(ctx, "incorrect topic slug provided, redirecting to canonical topic", logData)
 
-		//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
-		http.Redirect(w, r, redirectPath, http.StatusFound)
+		redirectToCanonicalTopic(w, r, expectedTopicSlug)
 		return
 	}
 
 	// TODO: Fetch versions using GetVersionsInBatches to improve performance and avoid edge case of having more than 1000 versions.
-	versions, err := datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000})
+	var versions datasetAPISDK.VersionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		versions, err = datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000})
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch versions", err, logData)
 		setStatusCode(ctx, w, err)
@@ -187,14 +207,15 @@ func editionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 }
 
 // VersionData handles requests for JSON version data
-func VersionData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool) http.HandlerFunc {
-	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
-		versionData(r, w, datasetAPIClient, topicAPIClient, isPublishing, accessToken)
+func VersionData(datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		versionData(r, w, datasetAPIClient, topicAPIClient, cfg, accessToken, collectionID)
 	})
 }
 
-func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, isPublishing bool, accessToken string) {
-	ctx := r.Context()
+func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, accessToken, collectionID string) {
+	budget, ctx, cancel := newDependencyBudget(r.Context(), cfg)
+	defer cancel()
 
 	vars := mux.Vars(r)
 	topicSlug := vars["topic"]
@@ -209,9 +230,13 @@ func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		"versionID": versionID,
 	}
 
-	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken}
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken, CollectionID: collectionID}
 
-	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	var dataset datasetAPIModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch dataset", err, logData)
 		setStatusCode(ctx, w, err)
@@ -224,7 +249,11 @@ func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
-	topicList, err := clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, isPublishing, accessToken)
+	var topicList []*topicModel.Topic
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		topicList, err = clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, accessToken)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch topics", err, logData)
 		setStatusCode(ctx, w, err)
@@ -238,20 +267,22 @@ func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 		return
 	}
 
+	// The dataset can be viewed under any of its topics, otherwise permanently redirect to the primary (canonical) topic
 	expectedTopicSlug := topicSlugs[0]
-	if expectedTopicSlug != topicSlug {
+	if !helpers.HasStringInSlice(topicSlug, topicSlugs) {
 		logData["providedTopicSlug"] = topicSlug
 		logData["expectedTopicSlug"] = expectedTopicSlug
-		log.Info(ctx, "incorrect topic slug provided, redirecting to correct topic", logData)
+		log.Info(ctx, "incorrect topic slug provided, redirecting to canonical topic", logData)
 
-		redirectPath := helpers.ReplaceFirstPathSegment(r.URL.Path, expectedTopicSlug)
-
-		//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
-		http.Redirect(w, r, redirectPath, http.StatusFound)
+		redirectToCanonicalTopic(w, r, expectedTopicSlug)
 		return
 	}
 
-	version, err := datasetAPIClient.GetVersionV2(ctx, datasetAPIClientHeaders, datasetID, editionID, versionID)
+	var version datasetAPIModels.Version
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		version, err = datasetAPIClient.GetVersionV2(ctx, datasetAPIClientHeaders, datasetID, editionID, versionID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch version", err, logData)
 		setStatusCode(ctx, w, err)
@@ -290,7 +321,11 @@ func versionData(r *http.Request, w http.ResponseWriter, datasetAPIClient client
 			datasetAPIQueryParams.Limit = 1000
 		}
 
-		previousVersionsList, err := datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, datasetAPIQueryParams)
+		var previousVersionsList datasetAPISDK.VersionsList
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			previousVersionsList, err = datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, datasetAPIQueryParams)
+			return err
+		})
 		if err != nil {
 			log.Error(ctx, "failed to fetch previous versions", err, logData)
 			setStatusCode(ctx, w, err)
@@ -104,6 +104,7 @@ var (
 
 func TestDatasetData(t *testing.T) {
 	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
 
 	dataset := testStaticDataset
 	datasetID := dataset.ID
@@ -131,7 +132,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 200 with the expected JSON body", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -171,6 +172,32 @@ func TestDatasetData(t *testing.T) {
 			})
 		})
 
+		Convey("When dataset is static and a secondary topic matches", func() {
+			mockDatasetClient.EXPECT().GetDataset(ctx, testDatasetHeaders, datasetID).
+				Return(dataset, nil)
+
+			mockTopicClient.EXPECT().GetTopicPublic(ctx, testTopicHeaders, dataset.Topics[0]).
+				Return(testTopicEconomy, nil)
+
+			mockTopicClient.EXPECT().GetTopicPublic(ctx, testTopicHeaders, dataset.Topics[1]).
+				Return(testTopicInflation, nil)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%s/datasets/%s/data", testTopicSlugs[1], datasetID), http.NoBody)
+			r = mux.SetURLVars(r, map[string]string{"topic": testTopicSlugs[1], "datasetID": datasetID})
+
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
+
+			Convey("Then the response status code should be 200 and the URI should use the canonical topic", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var resp zebedee.DatasetLandingPage
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				So(err, ShouldBeNil)
+				So(resp.URI, ShouldEqual, "/economy/datasets/dataset-123")
+			})
+		})
+
 		Convey("When GetDataset fails", func() {
 			mockDatasetClient.EXPECT().GetDataset(ctx, testDatasetHeaders, datasetID).
 				Return(datasetAPIModels.Dataset{}, errors.New("failed to fetch dataset"))
@@ -179,7 +206,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -194,7 +221,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 404 Not Found", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -212,7 +239,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -230,7 +257,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -251,10 +278,10 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
-			Convey("Then the response status code should be 302 Found and redirect to correct topic", func() {
-				So(w.Code, ShouldEqual, http.StatusFound)
+			Convey("Then the response status code should be 301 Moved Permanently and redirect to the canonical topic", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/%s/datasets/%s/data", "different-topic", datasetID))
 			})
 		})
@@ -280,7 +307,7 @@ func TestDatasetData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			datasetData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			datasetData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -291,6 +318,7 @@ func TestDatasetData(t *testing.T) {
 
 func TestEditionData(t *testing.T) {
 	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
 
 	dataset := testStaticDataset
 	datasetID := dataset.ID
@@ -322,7 +350,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 200 with the expected JSON body", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -384,7 +412,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -399,7 +427,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 404 Not Found", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -417,7 +445,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -435,7 +463,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -456,10 +484,10 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
-			Convey("Then the response status code should be 302 Found and redirect to correct topic", func() {
-				So(w.Code, ShouldEqual, http.StatusFound)
+			Convey("Then the response status code should be 301 Moved Permanently and redirect to the canonical topic", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/%s/datasets/%s/editions/%s/data", "different-topic", datasetID, editionID))
 			})
 		})
@@ -481,7 +509,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -508,7 +536,7 @@ func TestEditionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			editionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			editionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -519,6 +547,7 @@ func TestEditionData(t *testing.T) {
 
 func TestVersionData(t *testing.T) {
 	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
 
 	dataset := testStaticDataset
 	datasetID := dataset.ID
@@ -559,7 +588,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 200 with the expected JSON body", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -621,7 +650,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -636,7 +665,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 404 Not Found", func() {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -654,7 +683,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -672,7 +701,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -693,10 +722,10 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
-			Convey("Then the response status code should be 302 Found and redirect to correct topic", func() {
-				So(w.Code, ShouldEqual, http.StatusFound)
+			Convey("Then the response status code should be 301 Moved Permanently and redirect to the canonical topic", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s/data", "different-topic", datasetID, editionID, versionID))
 			})
 		})
@@ -718,7 +747,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -745,7 +774,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -775,7 +804,7 @@ func TestVersionData(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, requestPath, http.NoBody)
 			r = mux.SetURLVars(r, urlVars)
 
-			versionData(r, w, mockDatasetClient, mockTopicClient, false, testUserAccessToken)
+			versionData(r, w, mockDatasetClient, mockTopicClient, cfg, testUserAccessToken, collectionID)
 
 			Convey("Then the response status code should be 500 Internal Server Error", func() {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -1,34 +1,46 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	"github.com/ONSdigital/dp-net/v3/handlers"
-	"github.com/ONSdigital/dp-net/v3/request"
-	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/pkg/errors"
 )
 
-func datasetPage(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, rend clients.RenderClient, fac clients.FilesAPIClient, collectionID, lang, userAccessToken string, cacheList *cache.List) {
+func datasetPage(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, rend clients.RenderClient, fac clients.FilesAPIClient, collectionID, lang, userAccessToken string, cacheList *cache.List, cfg config.Config) {
 	path := req.URL.Path
-	ctx := req.Context()
 
-	if handleRequestForZebedeeJSONData(ctx, w, zc, path, userAccessToken) {
+	if handleRequestForZebedeeJSONData(req.Context(), w, zc, path, userAccessToken) {
 		return
 	}
 
-	ds, err := zc.GetDataset(ctx, userAccessToken, collectionID, lang, path)
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+
+	var ds zebedee.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		ds, err = zc.GetDataset(ctx, userAccessToken, collectionID, lang, path)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, w, errors.Wrap(err, "zebedee client get dataset returned an error"))
 		return
 	}
 
-	bc, err := zc.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, ds.URI)
+	// the breadcrumb is critical as it locates the dataset landing page
+	var bc []zebedee.Breadcrumb
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		bc, err = zc.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, ds.URI)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, w, err)
 		return
@@ -41,12 +53,17 @@ func datasetPage(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCli
 
 	parentPath := bc[len(bc)-1].URI
 
-	homepageContent, err := zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
-	dlp, err := zc.GetDatasetLandingPage(ctx, userAccessToken, collectionID, lang, parentPath)
+	var dlp zebedee.DatasetLandingPage
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		dlp, err = zc.GetDatasetLandingPage(ctx, userAccessToken, collectionID, lang, parentPath)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, w, err)
 		return
@@ -56,38 +73,44 @@ func datasetPage(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCli
 	versions := make([]zebedee.Dataset, 0, len(ds.Versions))
 
 	for _, ver := range ds.Versions {
-		version, err := zc.GetDataset(ctx, userAccessToken, collectionID, lang, ver.URI)
+		var version zebedee.Dataset
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			version, err = zc.GetDataset(ctx, userAccessToken, collectionID, lang, ver.URI)
+			return err
+		})
 		if err != nil {
 			setStatusCode(ctx, w, errors.Wrap(err, "zebedee client get previous dataset versions returned an error"))
 			return
 		}
 
-		version, err = addFileSizesToDataset(ctx, fac, version, userAccessToken)
-		if err != nil {
-			log.Error(ctx, "failed to get file size from files API", err, log.Data{"version": version})
-		}
+		budget.optional(ctx, partFileSizes, func(ctx context.Context) (err error) {
+			version, err = addFileSizesToDataset(ctx, fac, version, userAccessToken)
+			return err
+		})
 
 		versions = append(versions, version)
 	}
 
 	// get cached navigation data
-	locale := request.GetLocaleCode(req)
-	navigationCache, err := cacheList.Navigation.GetNavigationData(ctx, locale)
-	if err != nil {
-		log.Error(ctx, "failed to get navigation cache", err)
-		setStatusCode(ctx, w, err)
-		return
-	}
+	var navigationCache *topicModel.Navigation
+	budget.optional(ctx, partNavigation, func(ctx context.Context) (err error) {
+		navigationCache, err = cacheList.Navigation.GetNavigationData(ctx, lang)
+		return err
+	})
 
 	basePage := rend.NewBasePageModel()
 	m := mapper.CreateDatasetPage(basePage, req, ds, dlp, bc, versions, lang, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, navigationCache)
 
+	m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, "")
+
+	budget.setHeader(w)
+	m.CSPNonce = security.Nonce(ctx)
 	rend.BuildPage(w, m, "dataset")
 }
 
 // DatasetPage will load a legacy dataset page
-func DatasetPage(zc clients.ZebedeeClient, rend clients.RenderClient, fac clients.FilesAPIClient, cacheList *cache.List) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		datasetPage(w, req, zc, rend, fac, collectionID, lang, userAccessToken, cacheList)
+func DatasetPage(zc clients.ZebedeeClient, rend clients.RenderClient, fac clients.FilesAPIClient, cacheList *cache.List, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		datasetPage(w, req, zc, rend, fac, collectionID, lang, userAccessToken, cacheList, cfg)
 	})
 }
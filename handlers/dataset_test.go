@@ -18,6 +18,7 @@ import (
 	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
 	dsp "github.com/ONSdigital/dp-frontend-dataset-controller/model/dataset"
 	"github.com/PuerkitoBio/goquery"
@@ -97,7 +98,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then the request to generate is OK", func() {
 						So(w.Code, ShouldEqual, http.StatusOK)
@@ -119,7 +120,7 @@ func TestDatasetHandlers(t *testing.T) {
 				mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 				So(err, ShouldBeNil)
 
-				DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+				DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 				Convey("When the dataset page is rendered", func() {
 					actualDownloadSize := actualPageModel.DatasetPage.Versions[0].Downloads[0].Size
@@ -128,8 +129,12 @@ func TestDatasetHandlers(t *testing.T) {
 						So(w.Code, ShouldEqual, http.StatusOK)
 					})
 
-					Convey("And the file size is 0 (unknown)", func() {
-						So(actualDownloadSize, ShouldEqual, "0")
+					Convey("And the file size is left empty to be rendered as unavailable", func() {
+						So(actualDownloadSize, ShouldBeEmpty)
+					})
+
+					Convey("And the file sizes are reported as degraded", func() {
+						So(w.Header().Get(degradedPartsHeader), ShouldEqual, partFileSizes)
 					})
 				})
 			})
@@ -172,7 +177,7 @@ func TestDatasetHandlers(t *testing.T) {
 					ctxOther := context.Background()
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then the request to generate is OK", func() {
 						So(w.Code, ShouldEqual, http.StatusOK)
@@ -199,7 +204,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then an internal server error is the response code", func() {
 						So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -218,7 +223,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then an internal server error is the response code", func() {
 						So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -237,7 +242,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then an internal server error is the response code", func() {
 						So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -256,7 +261,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then an internal server error is the response code", func() {
 						So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -277,7 +282,7 @@ func TestDatasetHandlers(t *testing.T) {
 					mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 					So(err, ShouldBeNil)
 
-					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+					DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, cfg)(w, req)
 
 					Convey("Then an internal server error is the response code", func() {
 						So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -305,7 +310,7 @@ func TestDatasetHandlers(t *testing.T) {
 			mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 			So(err, ShouldBeNil)
 
-			DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList)(w, req)
+			DatasetPage(mockZebedeeClient, mockRend, mockFilesAPIClient, mockCacheList, *cfg)(w, req)
 
 			Convey("Then the status should be OK", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -321,6 +326,7 @@ func TestDatasetHandlers(t *testing.T) {
 
 func TestDatasetTemplateRendering(t *testing.T) {
 	cfg, _ := config.Get()
+	csrf.RegisterTemplateFuncs()
 	renderClient := render.NewWithDefaultClient(assets.Asset, assets.AssetNames, cfg.PatternLibraryAssetsPath, "https://ons.gov.uk")
 
 	extension := "csv"
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// degradedPartsHeader lists the optional parts of a page which could not be fetched and were rendered as placeholders
+const degradedPartsHeader = "X-Degraded-Parts"
+
+// Optional parts of a page, as reported in the degradedPartsHeader
+const (
+	partBreadcrumb      = "breadcrumb"
+	partFileSizes       = "file-sizes"
+	partHomepageContent = "homepage-content"
+	partNavigation      = "navigation"
+	partRelatedDatasets = "related-datasets"
+	partVersionDiff     = "version-diff"
+)
+
+// dependencyBudget bounds the downstream calls made while handling a request to an overall request budget. Each call
+// is declared as critical, failing the request when it fails, or optional, in which case the failure is recorded so
+// that the page can be rendered with a placeholder instead.
+type dependencyBudget struct {
+	criticalTimeout time.Duration
+	optionalTimeout time.Duration
+
+	mutex    sync.Mutex
+	degraded []string
+}
+
+// newDependencyBudget starts the budget of a request, returning a context bounded by the overall request budget which
+// should be passed to each call. A zero duration in the config leaves the corresponding calls bounded only by the
+// request context.
+func newDependencyBudget(ctx context.Context, cfg config.Config) (*dependencyBudget, context.Context, context.CancelFunc) {
+	ctx, cancel := withTimeout(ctx, cfg.RequestBudget)
+
+	return &dependencyBudget{
+		criticalTimeout: cfg.CriticalDependencyTimeout,
+		optionalTimeout: cfg.OptionalDependencyTimeout,
+	}, ctx, cancel
+}
+
+// critical calls fn within the critical dependency timeout, returning its error so the request can be failed
+func (b *dependencyBudget) critical(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := withTimeout(ctx, b.criticalTimeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// optional calls fn within the optional dependency timeout. If it fails the part is recorded as degraded and false is
+// returned, leaving the caller to render a placeholder.
+func (b *dependencyBudget) optional(ctx context.Context, part string, fn func(ctx context.Context) error) bool {
+	ctx, cancel := withTimeout(ctx, b.optionalTimeout)
+	defer cancel()
+
+	if err := fn(ctx); err != nil {
+		log.Warn(ctx, "optional dependency failed, rendering a placeholder", log.FormatErrors([]error{err}), log.Data{"part": part})
+		b.degrade(part)
+		return false
+	}
+	return true
+}
+
+// degrade records a part of the page as degraded
+func (b *dependencyBudget) degrade(part string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !slices.Contains(b.degraded, part) {
+		b.degraded = append(b.degraded, part)
+	}
+}
+
+// degradedParts returns the parts of the page which were degraded, in the order they failed
+func (b *dependencyBudget) degradedParts() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return slices.Clone(b.degraded)
+}
+
+// setHeader lists any degraded parts in the response headers, so must be called before the response is written
+func (b *dependencyBudget) setHeader(w http.ResponseWriter) {
+	if parts := b.degradedParts(); len(parts) > 0 {
+		w.Header().Set(degradedPartsHeader, strings.Join(parts, ", "))
+	}
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDependencyBudget(t *testing.T) {
+	Convey("Given a dependency budget", t, func() {
+		cfg := config.Config{
+			RequestBudget:             time.Second,
+			CriticalDependencyTimeout: 100 * time.Millisecond,
+			OptionalDependencyTimeout: 10 * time.Millisecond,
+		}
+		budget, ctx, cancel := newDependencyBudget(context.Background(), cfg)
+		defer cancel()
+
+		Convey("When a critical call fails", func() {
+			expectedErr := errors.New("dataset not found")
+			err := budget.critical(ctx, func(ctx context.Context) error {
+				return expectedErr
+			})
+
+			Convey("Then its error is returned and no parts are degraded", func() {
+				So(err, ShouldEqual, expectedErr)
+				So(budget.degradedParts(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a critical call has a deadline", func() {
+			var deadline time.Time
+			err := budget.critical(ctx, func(ctx context.Context) error {
+				deadline, _ = ctx.Deadline()
+				return nil
+			})
+
+			Convey("Then the deadline is the critical dependency timeout", func() {
+				So(err, ShouldBeNil)
+				So(time.Until(deadline), ShouldBeLessThanOrEqualTo, cfg.CriticalDependencyTimeout)
+			})
+		})
+
+		Convey("When optional calls fail or run past their timeout", func() {
+			ok := budget.optional(ctx, partNavigation, func(ctx context.Context) error {
+				return errors.New("navigation cache is empty")
+			})
+			timedOut := budget.optional(ctx, partFileSizes, func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			repeated := budget.optional(ctx, partNavigation, func(ctx context.Context) error {
+				return errors.New("navigation cache is empty")
+			})
+			succeeded := budget.optional(ctx, partBreadcrumb, func(ctx context.Context) error {
+				return nil
+			})
+
+			Convey("Then the failed parts are reported once each, in order", func() {
+				So(ok, ShouldBeFalse)
+				So(timedOut, ShouldBeFalse)
+				So(repeated, ShouldBeFalse)
+				So(succeeded, ShouldBeTrue)
+				So(budget.degradedParts(), ShouldResemble, []string{partNavigation, partFileSizes})
+			})
+
+			Convey("And the response header lists the degraded parts", func() {
+				w := httptest.NewRecorder()
+				budget.setHeader(w)
+				So(w.Header().Get(degradedPartsHeader), ShouldEqual, "navigation, file-sizes")
+			})
+		})
+
+		Convey("When no parts are degraded", func() {
+			w := httptest.NewRecorder()
+			budget.setHeader(w)
+
+			Convey("Then the response header is not set", func() {
+				So(w.Header().Values(degradedPartsHeader), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a config without timeouts", t, func() {
+		budget, ctx, cancel := newDependencyBudget(context.Background(), config.Config{})
+		defer cancel()
+
+		Convey("When an optional call is made", func() {
+			var hasDeadline bool
+			budget.optional(ctx, partHomepageContent, func(ctx context.Context) error {
+				_, hasDeadline = ctx.Deadline()
+				return nil
+			})
+
+			Convey("Then it is only bounded by the request context", func() {
+				So(hasDeadline, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// Constants used by the dimension options page
+const (
+	dimensionOptionsBatchSize = 1000
+	dimensionOptionsPageSize  = 100
+	queryStrSearch            = "q"
+	queryStrSort              = "sort"
+	queryStrPage              = "page"
+	sortByCode                = "code"
+	sortByLabel               = "label"
+)
+
+// DimensionOptions lists every option of a dimension of a filterable dataset version, with search, sorting and pagination
+func DimensionOptions(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		dimensionOptions(w, req, dc, zc, rend, cfg, collectionID, userAccessToken, lang)
+	})
+}
+
+// DimensionOptionsCSV exports the options of a dimension as CSV, applying the same search and sorting as the options page
+func DimensionOptionsCSV(dc clients.DatasetAPISdkClient) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		dimensionOptionsCSV(w, req, dc, collectionID, userAccessToken)
+	})
+}
+
+func dimensionOptions(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, collectionID, userAccessToken, lang string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+	vars := mux.Vars(req)
+	datasetID := vars["datasetID"]
+	editionID := vars["editionID"]
+	versionID := vars["versionID"]
+
+	headers := dpDatasetApiSdk.Headers{
+		CollectionID: collectionID,
+		AccessToken:  userAccessToken,
+	}
+
+	var datasetDetails dpDatasetApiModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetDetails, err = getFilterableDataset(ctx, dc, headers, datasetID)
+		return err
+	})
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	var dimension dpDatasetApiModels.Dimension
+	var options []dpDatasetApiModels.PublicDimensionOption
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		dimension, options, err = getDimensionWithOptions(ctx, dc, headers, datasetID, editionID, versionID, vars["dimensionName"])
+		return err
+	})
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	query, sortBy := getDimensionOptionsQuery(req)
+	matchingOptions := sortDimensionOptions(searchDimensionOptions(options, query), sortBy)
+
+	totalPages := (len(matchingOptions) + dimensionOptionsPageSize - 1) / dimensionOptionsPageSize
+	currentPage, err := getPageNumber(req.URL.Query().Get(queryStrPage), totalPages)
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	basePage := rend.NewBasePageModel()
+	m := mapper.CreateDimensionOptionsPage(basePage, req, datasetDetails, dimension, matchingOptions, len(options), query, sortBy,
+		currentPage, totalPages, dimensionOptionsPageSize, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, "")
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
+	rend.BuildPage(w, m, "dimension-options")
+}
+
+func dimensionOptionsCSV(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, collectionID, userAccessToken string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	datasetID := vars["datasetID"]
+	editionID := vars["editionID"]
+	versionID := vars["versionID"]
+
+	headers := dpDatasetApiSdk.Headers{
+		CollectionID: collectionID,
+		AccessToken:  userAccessToken,
+	}
+
+	if _, err := getFilterableDataset(ctx, dc, headers, datasetID); err != nil {
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	dimension, options, err := getDimensionWithOptions(ctx, dc, headers, datasetID, editionID, versionID, vars["dimensionName"])
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	query, sortBy := getDimensionOptionsQuery(req)
+	matchingOptions := sortDimensionOptions(searchDimensionOptions(options, query), sortBy)
+
+	filename := fmt.Sprintf("%s-%s-v%s-%s-options.csv", datasetID, editionID, versionID, dimension.Name)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	csvWriter := csv.NewWriter(w)
+	records := [][]string{{"code", "label"}}
+	for i := range matchingOptions {
+		records = append(records, []string{csvCell(matchingOptions[i].Option), csvCell(matchingOptions[i].Label)})
+	}
+	if err = csvWriter.WriteAll(records); err != nil {
+		log.Error(ctx, "failed to write dimension options csv", errors.Wrap(err, "failed to write csv"), log.Data{"dimension": dimension.Name})
+	}
+}
+
+// getFilterableDataset returns the dataset, or errDatasetTypeNotSupported if it is a static dataset, which has no
+// dimension options
+func getFilterableDataset(ctx context.Context, dc clients.DatasetAPISdkClient, headers dpDatasetApiSdk.Headers, datasetID string) (dpDatasetApiModels.Dataset, error) {
+	datasetDetails, err := dc.GetDataset(ctx, headers, datasetID)
+	if err != nil {
+		return datasetDetails, err
+	}
+
+	if datasetDetails.Type == DatasetTypeStatic {
+		log.Error(ctx, "handler does not support static datasets", errDatasetTypeNotSupported)
+		return datasetDetails, errDatasetTypeNotSupported
+	}
+	return datasetDetails, nil
+}
+
+// csvCell returns value escaped for a cell of an exported CSV. Values starting with a character which a spreadsheet
+// would read as the start of a formula are prefixed with an apostrophe, so that they are shown as text.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// getDimensionWithOptions returns the named dimension of a version along with all of its options, requesting them
+// from the dataset API in batches
+func getDimensionWithOptions(ctx context.Context, dc clients.DatasetAPISdkClient, headers dpDatasetApiSdk.Headers, datasetID, editionID, versionID, dimensionName string) (dimension dpDatasetApiModels.Dimension, options []dpDatasetApiModels.PublicDimensionOption, err error) {
+	dimensions, err := dc.GetVersionDimensions(ctx, headers, datasetID, editionID, versionID)
+	if err != nil {
+		return dimension, nil, err
+	}
+
+	found := false
+	for i := range dimensions.Items {
+		if dimensions.Items[i].Name == dimensionName {
+			dimension = dimensions.Items[i]
+			found = true
+			break
+		}
+	}
+	if !found {
+		log.Error(ctx, "dimension not found in version", errDimensionNotFound, log.Data{"dimension": dimensionName})
+		return dimension, nil, errDimensionNotFound
+	}
+
+	for offset := 0; ; offset += dimensionOptionsBatchSize {
+		q := dpDatasetApiSdk.QueryParams{Offset: offset, Limit: dimensionOptionsBatchSize}
+		batch, err := dc.GetVersionDimensionOptions(ctx, headers, datasetID, editionID, versionID, dimensionName, &q)
+		if err != nil {
+			return dimension, nil, err
+		}
+		options = append(options, batch.Items...)
+		if len(batch.Items) < dimensionOptionsBatchSize {
+			break
+		}
+	}
+
+	return dimension, options, nil
+}
+
+// getDimensionOptionsQuery returns the search query and the sort order requested for the dimension options
+func getDimensionOptionsQuery(req *http.Request) (query, sortBy string) {
+	query = strings.TrimSpace(req.URL.Query().Get(queryStrSearch))
+	sortBy = req.URL.Query().Get(queryStrSort)
+	if sortBy != sortByLabel {
+		sortBy = sortByCode
+	}
+	return query, sortBy
+}
+
+// searchDimensionOptions returns the options whose label or code contain the query, ignoring case
+func searchDimensionOptions(items []dpDatasetApiModels.PublicDimensionOption, query string) []dpDatasetApiModels.PublicDimensionOption {
+	if query == "" {
+		return items
+	}
+
+	query = strings.ToLower(query)
+	matching := []dpDatasetApiModels.PublicDimensionOption{}
+	for i := range items {
+		if strings.Contains(strings.ToLower(items[i].Label), query) || strings.Contains(strings.ToLower(items[i].Option), query) {
+			matching = append(matching, items[i])
+		}
+	}
+	return matching
+}
+
+// sortDimensionOptions sorts the options by code, using sortOptionsByCode, or alphabetically by label
+func sortDimensionOptions(items []dpDatasetApiModels.PublicDimensionOption, sortBy string) []dpDatasetApiModels.PublicDimensionOption {
+	sorted := sortOptionsByCode(items)
+	if sortBy == sortByLabel {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].Label) < strings.ToLower(sorted[j].Label)
+		})
+	}
+	return sorted
+}
+
+// getPageNumber returns the requested page number, defaulting to the first page
+func getPageNumber(page string, totalPages int) (int, error) {
+	if page == "" {
+		return 1, nil
+	}
+
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil || pageNumber < 1 || pageNumber > max(totalPages, 1) {
+		return 0, errInvalidPageNumber
+	}
+	return pageNumber, nil
+}
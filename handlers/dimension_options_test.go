@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/dimensionoptions"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDimensionOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
+	headers := dpDatasetApiSdk.Headers{
+		CollectionID: collectionID,
+		AccessToken:  userAuthToken,
+	}
+	const optionsPath = "/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/dimensions/{dimensionName}/options"
+
+	dimensions := dpDatasetApiSdk.VersionDimensionsList{
+		Items: []dpDatasetApiModels.Dimension{{Name: "geography", Label: "Geography"}},
+	}
+
+	// 1050 options are returned across two batches, with codes 0 to 1049 in reverse order
+	firstBatch := dpDatasetApiSdk.VersionDimensionOptionsList{}
+	secondBatch := dpDatasetApiSdk.VersionDimensionOptionsList{}
+	for i := 1049; i >= 0; i-- {
+		option := dpDatasetApiModels.PublicDimensionOption{Option: fmt.Sprint(i), Label: fmt.Sprintf("Area %d", i)}
+		if len(firstBatch.Items) < dimensionOptionsBatchSize {
+			firstBatch.Items = append(firstBatch.Items, option)
+		} else {
+			secondBatch.Items = append(secondBatch.Items, option)
+		}
+	}
+
+	expectOptions := func(mockClient *clients.MockDatasetAPISdkClient) {
+		mockClient.EXPECT().GetVersionDimensions(ctx, headers, "12345", "2021", "1").Return(dimensions, nil)
+		mockClient.EXPECT().GetVersionDimensionOptions(ctx, headers, "12345", "2021", "1", "geography",
+			&dpDatasetApiSdk.QueryParams{Offset: 0, Limit: dimensionOptionsBatchSize}).Return(firstBatch, nil)
+		mockClient.EXPECT().GetVersionDimensionOptions(ctx, headers, "12345", "2021", "1", "geography",
+			&dpDatasetApiSdk.QueryParams{Offset: dimensionOptionsBatchSize, Limit: dimensionOptionsBatchSize}).Return(secondBatch, nil)
+	}
+
+	Convey("test dimension options page", t, func() {
+		Convey("test dimension options returns 200 and all options sorted by code when rendered successfully", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{Title: "Census"}, nil)
+			expectOptions(mockClient)
+
+			var page dimensionoptions.Page
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "dimension-options").Do(func(w io.Writer, pageModel interface{}, templateName string) {
+				page = pageModel.(dimensionoptions.Page)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options?page=11", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath, DimensionOptions(mockClient, mockZebedeeClient, mockRend, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(page.Data.TotalOptions, ShouldEqual, 1050)
+			So(page.Data.MatchingOptions, ShouldEqual, 1050)
+			So(page.Pagination.CurrentPage, ShouldEqual, 11)
+			So(page.Pagination.TotalPages, ShouldEqual, 11)
+			So(page.Data.Options, ShouldHaveLength, 50)
+			So(page.Data.Options[0].Code, ShouldEqual, "1000")
+			So(page.Data.Options[49].Code, ShouldEqual, "1049")
+		})
+
+		Convey("test dimension options filters by the search query and sorts by label", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			expectOptions(mockClient)
+
+			var page dimensionoptions.Page
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "dimension-options").Do(func(w io.Writer, pageModel interface{}, templateName string) {
+				page = pageModel.(dimensionoptions.Page)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options?q=AREA+10&sort=label", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath, DimensionOptions(mockClient, mockZebedeeClient, mockRend, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(page.Data.Query, ShouldEqual, "AREA 10")
+			So(page.Data.SortBy, ShouldEqual, sortByLabel)
+			So(page.Data.MatchingOptions, ShouldEqual, 61)
+			So(page.Data.Options[0].Label, ShouldEqual, "Area 10")
+			So(page.Data.Options[1].Label, ShouldEqual, "Area 100")
+			So(page.Data.Options[2].Label, ShouldEqual, "Area 1000")
+		})
+
+		Convey("test dimension options returns 404 when the dimension is not in the version", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			mockClient.EXPECT().GetVersionDimensions(ctx, headers, "12345", "2021", "1").Return(dimensions, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/sex/options", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath, DimensionOptions(mockClient, nil, nil, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("test dimension options returns 404 when dataset type is static", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{Type: DatasetTypeStatic}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath, DimensionOptions(mockClient, nil, nil, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("test dimension options returns 400 when the page number is out of range", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			expectOptions(mockClient)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options?page=12", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath, DimensionOptions(mockClient, nil, nil, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+
+	Convey("test dimension options csv export", t, func() {
+		Convey("test dimension options csv returns the matching options as an attachment", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			expectOptions(mockClient)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options.csv?q=area+104", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath+".csv", DimensionOptionsCSV(mockClient))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+			So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="12345-2021-v1-geography-options.csv"`)
+			So(w.Body.String(), ShouldEqual, "code,label\n104,Area 104\n1040,Area 1040\n1041,Area 1041\n1042,Area 1042\n1043,Area 1043\n1044,Area 1044\n1045,Area 1045\n1046,Area 1046\n1047,Area 1047\n1048,Area 1048\n1049,Area 1049\n")
+		})
+
+		Convey("test dimension options csv escapes options which a spreadsheet would read as formulas", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			mockClient.EXPECT().GetVersionDimensions(ctx, headers, "12345", "2021", "1").Return(dimensions, nil)
+			mockClient.EXPECT().GetVersionDimensionOptions(ctx, headers, "12345", "2021", "1", "geography",
+				&dpDatasetApiSdk.QueryParams{Offset: 0, Limit: dimensionOptionsBatchSize}).
+				Return(dpDatasetApiSdk.VersionDimensionOptionsList{Items: []dpDatasetApiModels.PublicDimensionOption{
+					{Option: "1", Label: "=HYPERLINK(\"http://example.com\")"},
+					{Option: "2", Label: "+44"},
+					{Option: "3", Label: "-1"},
+					{Option: "@4", Label: "Area 4"},
+				}}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options.csv", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath+".csv", DimensionOptionsCSV(mockClient))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldEqual, "code,label\n1,\"'=HYPERLINK(\"\"http://example.com\"\")\"\n2,'+44\n3,'-1\n'@4,Area 4\n")
+		})
+
+		Convey("test dimension options csv returns 404 when dataset type is static", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{Type: DatasetTypeStatic}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/dimensions/geography/options.csv", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(optionsPath+".csv", DimensionOptionsCSV(mockClient))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestGetPageNumber(t *testing.T) {
+	Convey("Given a requested page number", t, func() {
+		Convey("When it is empty, the first page is returned", func() {
+			page, err := getPageNumber("", 3)
+			So(err, ShouldBeNil)
+			So(page, ShouldEqual, 1)
+		})
+
+		Convey("When it is within range, it is returned", func() {
+			page, err := getPageNumber("3", 3)
+			So(err, ShouldBeNil)
+			So(page, ShouldEqual, 3)
+		})
+
+		Convey("When there are no results, the first page is still valid", func() {
+			page, err := getPageNumber("1", 0)
+			So(err, ShouldBeNil)
+			So(page, ShouldEqual, 1)
+		})
+
+		Convey("When it is not a valid page, an error is returned", func() {
+			for _, p := range []string{"0", "4", "-1", "abc"} {
+				_, err := getPageNumber(p, 3)
+				So(err, ShouldEqual, errInvalidPageNumber)
+			}
+		})
+	})
+}
@@ -1,35 +1,44 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // EditionsList will load a list of editions for a filterable dataset
-func EditionsList(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, apiRouterVersion string) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		editionsList(w, req, dc, zc, rend, collectionID, lang, apiRouterVersion, userAccessToken)
+func EditionsList(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		editionsList(w, req, dc, zc, rend, cfg, collectionID, lang, apiRouterVersion, userAccessToken)
 	})
 }
 
-func editionsList(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, collectionID, lang, apiRouterVersion, userAccessToken string) {
+func editionsList(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, collectionID, lang, apiRouterVersion, userAccessToken string) {
 	vars := mux.Vars(req)
 	datasetID := vars["datasetID"]
-	ctx := req.Context()
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
 
 	headers := dpDatasetApiSdk.Headers{
 		AccessToken:  userAccessToken,
 		CollectionID: collectionID,
 	}
 
-	datasetDetails, err := dc.GetDataset(ctx, headers, datasetID)
+	var datasetDetails dpDatasetApiModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetDetails, err = dc.GetDataset(ctx, headers, datasetID)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, w, err)
 		return
@@ -42,14 +51,17 @@ func editionsList(w http.ResponseWriter, req *http.Request, dc clients.DatasetAP
 	}
 
 	queryParams := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}
-	datasetEditions, err := dc.GetEditions(ctx, headers, datasetID, &queryParams)
-	if err != nil {
-		if err, ok := err.(clients.ClientError); ok {
-			if err.Code() != http.StatusNotFound {
-				setStatusCode(ctx, w, err)
-				return
-			}
+	var datasetEditions dpDatasetApiSdk.EditionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetEditions, err = dc.GetEditions(ctx, headers, datasetID, &queryParams)
+		if clientErr, ok := err.(clients.ClientError); ok && clientErr.Code() == http.StatusNotFound {
+			return nil
 		}
+		return err
+	})
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
 	}
 
 	// redirect to latest version if number of editions is one or less
@@ -63,21 +75,26 @@ func editionsList(w http.ResponseWriter, req *http.Request, dc clients.DatasetAP
 	}
 
 	// Fetch homepage content
-	homepageContent, err := zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
 	// Build page context
 	basePage := rend.NewBasePageModel()
 	// Update basePage common parameters
 	mapper.UpdateBasePage(&basePage, datasetDetails, homepageContent, false, lang, req)
 
-	bc, err := zc.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, datasetDetails.Links.Taxonomy.HRef)
-	if err != nil {
-		log.Warn(ctx, "unable to get breadcrumb for dataset uri", log.FormatErrors([]error{err}), log.Data{"taxonomy_url": datasetDetails.Links.Taxonomy.HRef})
-	}
+	var bc []zebedee.Breadcrumb
+	budget.optional(ctx, partBreadcrumb, func(ctx context.Context) (err error) {
+		bc, err = zc.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, datasetDetails.Links.Taxonomy.HRef)
+		return err
+	})
 
 	m := mapper.CreateEditionsList(ctx, basePage, req, datasetDetails, datasetEditions, datasetID, bc, apiRouterVersion)
+	m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, "")
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
 	rend.BuildPage(w, m, "edition-list")
 }
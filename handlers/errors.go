@@ -3,6 +3,9 @@ package handlers
 import (
 	"errors"
 	"net/http"
+
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
 )
 
 // List of errors used within the handlers package
@@ -10,11 +13,20 @@ var (
 	errDatasetTypeNotSupported  = errors.New("dataset type is not supported")
 	errDatasetHasNoTopics       = errors.New("no topics found for dataset")
 	errMissingLatestVersionLink = errors.New("latest version link is missing from dataset API response")
+	errDimensionNotFound        = errors.New("dimension not found in version")
+	errInvalidPageNumber        = errors.New("invalid page number")
+	errInvalidCustomSelection   = errors.New("invalid custom dataset selection")
 )
 
 // Map of errors to HTTP status codes
 var errorToStatusCodeMap = map[error]int{
-	errDatasetTypeNotSupported:  http.StatusNotFound,
-	errDatasetHasNoTopics:       http.StatusInternalServerError,
-	errMissingLatestVersionLink: http.StatusInternalServerError,
+	errDatasetTypeNotSupported:    http.StatusNotFound,
+	errDatasetHasNoTopics:         http.StatusInternalServerError,
+	errMissingLatestVersionLink:   http.StatusInternalServerError,
+	errDimensionNotFound:          http.StatusNotFound,
+	errInvalidPageNumber:          http.StatusBadRequest,
+	csrf.ErrInvalidToken:          http.StatusForbidden,
+	workflow.ErrUnknownTransition: http.StatusNotFound,
+	workflow.ErrPermissionDenied:  http.StatusForbidden,
+	workflow.ErrReasonRequired:    http.StatusBadRequest,
 }
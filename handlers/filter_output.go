@@ -8,31 +8,34 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // FilterOutput will load a filtered landing page
-func FilterOutput(zc clients.ZebedeeClient, fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, rend clients.RenderClient, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		filterOutput(w, req, zc, dc, fc, pc, rend, cfg, collectionID, lang, apiRouterVersion, userAccessToken)
+func FilterOutput(zc clients.ZebedeeClient, fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, rend clients.RenderClient, cacheList *cache.List, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		filterOutput(w, req, zc, dc, fc, pc, rend, cacheList, cfg, collectionID, lang, apiRouterVersion, userAccessToken)
 	})
 }
 
 // nolint:gocognit,gocyclo // Legacy code
-func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, dc clients.DatasetAPISdkClient, fc clients.FilterClient, pc clients.PopulationClient, rend clients.RenderClient, cfg config.Config, collectionID, lang, apiRouterVersion, userAccessToken string) {
+func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, dc clients.DatasetAPISdkClient, fc clients.FilterClient, pc clients.PopulationClient, rend clients.RenderClient, cacheList *cache.List, cfg config.Config, collectionID, lang, apiRouterVersion, userAccessToken string) {
 	var form = req.URL.Query().Get("f")
 	var format = req.URL.Query().Get("format")
 	var isValidationError bool
@@ -42,7 +45,6 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 	var filterOutput filter.Model
 	var dimDescriptions population.GetDimensionsResponse
 	var sdc *cantabular.GetBlockedAreaCountResult
-	var areaTypeID, parent string
 	var dimCategories population.GetDimensionCategoriesResponse
 	var pop population.GetPopulationTypeResponse
 	var dimIds, nonAreaDimIds, areaOpts []string
@@ -51,12 +53,21 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 	var isSpinner = req.URL.Query().Get("spinner") == "true"
 
 	vars := mux.Vars(req)
-	ctx := req.Context()
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
 	datasetID := vars["datasetID"]
 	edition := vars["editionID"]
 	version := vars["versionID"]
 	filterOutputID := vars["filterOutputID"]
 
+	start := time.Now()
+	defer func() {
+		log.Info(ctx, "filter output page request completed", log.Data{
+			"filter_output_id": filterOutputID,
+			"duration":         time.Since(start).String(),
+		})
+	}()
+
 	headers := dpDatasetApiSdk.Headers{
 		AccessToken:  userAccessToken,
 		CollectionID: collectionID,
@@ -65,7 +76,10 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		filterOutput, fErr = fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+		fErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			filterOutput, err = fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+			return err
+		})
 		for i := range filterOutput.Dimensions {
 			dimension := &filterOutput.Dimensions[i]
 
@@ -91,18 +105,27 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 	wg.Add(3)
 	go func() {
 		defer wg.Done()
-		datasetModel, dmErr = dc.GetDataset(ctx, headers, datasetID)
+		dmErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			datasetModel, err = dc.GetDataset(ctx, headers, datasetID)
+			return err
+		})
 	}()
 
 	go func() {
 		defer wg.Done()
 		q := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}
-		allVers, versErr = dc.GetVersions(ctx, headers, datasetID, edition, &q)
+		versErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			allVers, err = dc.GetVersions(ctx, headers, datasetID, edition, &q)
+			return err
+		})
 	}()
 
 	go func() {
 		defer wg.Done()
-		ver, verErr = dc.GetVersion(ctx, headers, datasetID, edition, version)
+		verErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			ver, err = dc.GetVersion(ctx, headers, datasetID, edition, version)
+			return err
+		})
 	}()
 
 	wg.Wait()
@@ -123,12 +146,15 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 	wg.Add(3)
 	go func() {
 		defer wg.Done()
-		dimDescriptions, dErr = pc.GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: userAccessToken,
-			},
-			PopulationType: filterOutput.PopulationType,
-			DimensionIDs:   dimIds,
+		dErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			dimDescriptions, err = pc.GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: userAccessToken,
+				},
+				PopulationType: filterOutput.PopulationType,
+				DimensionIDs:   dimIds,
+			})
+			return err
 		})
 		if dErr != nil {
 			log.Error(ctx, "failed to get dimension descriptions", dErr, log.Data{
@@ -142,27 +168,33 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 
 	go func() {
 		defer wg.Done()
-		pop, pErr = pc.GetPopulationType(ctx, population.GetPopulationTypeInput{
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: userAccessToken,
-			},
-			PopulationType: filterOutput.PopulationType,
+		pErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+			pop, err = pc.GetPopulationType(ctx, population.GetPopulationTypeInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: userAccessToken,
+				},
+				PopulationType: filterOutput.PopulationType,
+			})
+			return err
 		})
 	}()
 
 	go func() {
 		defer wg.Done()
 		if len(nonAreaDimIds) > 0 {
-			dimCategories, dcErr = pc.GetDimensionCategories(ctx, population.GetDimensionCategoryInput{
-				AuthTokens: population.AuthTokens{
-					UserAuthToken: userAccessToken,
-				},
-				PaginationParams: population.PaginationParams{
-					Limit:  1000,
-					Offset: 0,
-				},
-				PopulationType: filterOutput.PopulationType,
-				Dimensions:     nonAreaDimIds,
+			dcErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+				dimCategories, err = pc.GetDimensionCategories(ctx, population.GetDimensionCategoryInput{
+					AuthTokens: population.AuthTokens{
+						UserAuthToken: userAccessToken,
+					},
+					PaginationParams: population.PaginationParams{
+						Limit:  1000,
+						Offset: 0,
+					},
+					PopulationType: filterOutput.PopulationType,
+					Dimensions:     nonAreaDimIds,
+				})
+				return err
 			})
 		} else {
 			dimCategories = population.GetDimensionCategoriesResponse{}
@@ -227,7 +259,20 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 		return options, len(options), nil
 	}
 
+	var areaLabelCache *cache.AreaLabelCache
+	var blockedAreaCounts *cache.BlockedAreaCountCache
+	if cacheList != nil {
+		areaLabelCache = cacheList.AreaLabels
+		blockedAreaCounts = cacheList.BlockedAreaCounts
+	}
+	areaLabels := areaLabelResolver{
+		pc:              pc,
+		cache:           areaLabelCache,
+		userAccessToken: userAccessToken,
+	}
+
 	var hasNoAreaOptions bool
+	var areaMutex sync.Mutex
 	getAreaOptions := func(dim filter.ModelDimension) ([]string, int, error) {
 		q := filter.QueryParams{
 			Limit: 500,
@@ -260,148 +305,91 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 				options = append(options, area.Label)
 			}
 
+			areaMutex.Lock()
 			hasNoAreaOptions = true
+			areaMutex.Unlock()
 			return options, areas.TotalCount, nil
 		}
 
-		var wg sync.WaitGroup
-		areaErrs := make([]error, len(opts.Items))
-		optsIDs := []string{}
-		totalCount := opts.TotalCount
-		for i, opt := range opts.Items {
-			wg.Add(1)
-			go func(opt filter.DimensionOption, i int) {
-				defer wg.Done()
-				optsIDs = append(optsIDs, opt.Option)
-				var areaTypeID string
-				if dim.FilterByParent != "" {
-					areaTypeID = dim.FilterByParent
-				} else {
-					areaTypeID = dim.ID
-				}
-
-				area, err := pc.GetArea(ctx, population.GetAreaInput{
-					AuthTokens: population.AuthTokens{
-						UserAuthToken: userAccessToken,
-					},
-					PopulationType: filterOutput.PopulationType,
-					AreaType:       areaTypeID,
-					Area:           opt.Option,
-				})
-
-				if err != nil {
-					areaErrs[i] = err
-				}
-
-				options = append(options, area.Area.Label)
-			}(opt, i)
+		areaOptIDs := make([]string, 0, len(opts.Items))
+		for _, opt := range opts.Items {
+			areaOptIDs = append(areaOptIDs, opt.Option)
 		}
-		wg.Wait()
 
-		var hasErrs bool
-		for _, err := range areaErrs {
-			if err != nil {
-				log.Error(ctx, "failed to get areas for options", err, log.Data{
-					"dimension_name": dim.Name,
-					"options":        opts.Items,
-				})
-				hasErrs = true
-			}
+		areaType := dim.ID
+		if dim.FilterByParent != "" {
+			areaType = dim.FilterByParent
 		}
 
-		if hasErrs {
-			return nil, 0, fmt.Errorf("failed to get dimension areas")
+		options, err = areaLabels.resolve(ctx, filterOutput.PopulationType, areaType, areaOptIDs)
+		if err != nil {
+			return nil, 0, err
 		}
-		areaOpts = optsIDs
+		areaMutex.Lock()
+		areaOpts = areaOptIDs
+		areaMutex.Unlock()
 
-		return options, totalCount, nil
+		return options, opts.TotalCount, nil
 	}
 
 	getOptions := func(dim filter.ModelDimension) ([]string, int, error) {
 		if dim.IsAreaType != nil && *dim.IsAreaType {
-			areaTypeID = dim.ID
-			parent = dim.FilterByParent
 			return getAreaOptions(dim)
 		}
 
 		return getDimensionOptions(dim)
 	}
 
-	getDimensionCategorisations := func(populationType string, dimension string) (int, error) {
-		cats, err := pc.GetCategorisations(ctx, population.GetCategorisationsInput{
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: userAccessToken,
-			},
-			PaginationParams: population.PaginationParams{
-				Limit: 1000,
-			},
-			PopulationType: populationType,
-			Dimension:      dimension,
-		})
-		return cats.TotalCount, err
-	}
-
-	var fDims []model.FilterDimension
-	for i := len(filterOutput.Dimensions) - 1; i >= 0; i-- {
-		options, count, err := getOptions(filterOutput.Dimensions[i])
+	// the options and categorisations of each dimension are requested concurrently, and the dimensions are listed in
+	// reverse order
+	fDims := make([]model.FilterDimension, len(filterOutput.Dimensions))
+	err := forEachDimension(ctx, len(filterOutput.Dimensions), func(ctx context.Context, i int) error {
+		dim := filterOutput.Dimensions[i]
+		options, count, err := getOptions(dim)
 		if err != nil {
-			log.Error(ctx, "failed to get options for dimension", err, log.Data{"dimension_name": filterOutput.Dimensions[i].Name})
-			setStatusCode(ctx, w, err)
-			return
+			log.Error(ctx, "failed to get options for dimension", err, log.Data{"dimension_name": dim.Name})
+			return err
 		}
 
 		categorisationCount := 0
-		if !helpers.IsBoolPtr(filterOutput.Dimensions[i].IsAreaType) {
-			categorisationCount, err = getDimensionCategorisations(filterOutput.PopulationType, filterOutput.Dimensions[i].Name)
-		}
-		if err != nil {
-			log.Error(ctx, "failed to get categorisations for dimension", err, log.Data{"dimension_name": filterOutput.Dimensions[i].Name})
-			setStatusCode(ctx, w, err)
-			return
+		if !helpers.IsBoolPtr(dim.IsAreaType) {
+			categorisationCount, err = getCategorisationCount(ctx, pc, userAccessToken, filterOutput.PopulationType, dim.Name)
+			if err != nil {
+				log.Error(ctx, "failed to get categorisations for dimension", err, log.Data{"dimension_name": dim.Name})
+				return err
+			}
 		}
 
 		filterOutput.Dimensions[i].Options = options
-		fDims = append(fDims, model.FilterDimension{
+		fDims[len(filterOutput.Dimensions)-1-i] = model.FilterDimension{
 			ModelDimension:      filterOutput.Dimensions[i],
 			OptionsCount:        count,
 			CategorisationCount: categorisationCount,
-		})
+		}
+		return nil
+	})
+	if err != nil {
+		setStatusCode(ctx, w, err)
+		return
 	}
 
 	if strings.Contains(datasetModel.Type, "multivariate") {
-		sort.Slice(dimIds, func(i, j int) bool {
-			return dimIds[i] == areaTypeID || dimIds[i] == parent
-		})
-
-		if parent != "" {
-			areaTypeID = parent
-		}
-
-		// set default coverage
-		if len(areaOpts) == 0 {
-			areaOpts = []string{"K04000001"}
-			areaTypeID = "nat"
-		}
-
 		if isSpinner {
 			sdc = &cantabular.GetBlockedAreaCountResult{}
 		} else {
-			sdc, sErr = pc.GetBlockedAreaCount(ctx, population.GetBlockedAreaCountInput{
-				AuthTokens: population.AuthTokens{
-					UserAuthToken: userAccessToken,
-				},
-				PopulationType: filterOutput.PopulationType,
-				Variables:      dimIds,
-				Filter: population.Filter{
-					Codes:    areaOpts,
-					Variable: areaTypeID,
-				}})
+			sdcInput := getBlockedAreaCountInput(userAccessToken, filterOutput, areaOpts)
+			sErr = budget.critical(ctx, func(ctx context.Context) (err error) {
+				sdc, err = blockedAreaCounts.Load(ctx, filterOutputID, func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+					return pc.GetBlockedAreaCount(ctx, sdcInput)
+				})
+				return err
+			})
 			if sErr != nil {
 				log.Error(ctx, "failed to get blocked area count", sErr, log.Data{
 					"population_type": filterOutput.PopulationType,
-					"variables":       dimIds,
-					"area_codes":      areaOpts,
-					"area_type_id":    areaTypeID,
+					"variables":       sdcInput.Variables,
+					"area_codes":      sdcInput.Filter.Codes,
+					"area_type_id":    sdcInput.Filter.Variable,
 				})
 				setStatusCode(ctx, w, sErr)
 				return
@@ -428,10 +416,11 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 		}
 	}
 
-	homepageContent, err := zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
 	showAll := req.URL.Query()[queryStrKey]
 	basePage := rend.NewBasePageModel()
@@ -439,7 +428,10 @@ func filterOutput(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeCl
 		lang, showAll, isValidationError, hasNoAreaOptions, filterOutput, fDims, homepageContent.ServiceMessage, homepageContent.EmergencyBanner,
 		cfg.EnableMultivariate, dimDescriptions, *sdc, pop)
 	m.DatasetLandingPage.OSRLogo = helpers.GetOSRLogoDetails(m.Language)
+	m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, ver.State)
 
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
 	rend.BuildPage(w, m, "census-landing")
 }
 
@@ -451,3 +443,44 @@ func logError(ctx context.Context, w http.ResponseWriter, err error, msg string,
 	}
 	return false
 }
+
+// getBlockedAreaCountInput returns the input used to request the statistical disclosure control result of a filter
+// output for the selected areas. The area type is listed first, and national coverage is used if no areas are selected.
+func getBlockedAreaCountInput(userAccessToken string, filterOutput filter.Model, areaOpts []string) population.GetBlockedAreaCountInput {
+	var areaTypeID, parent string
+	dimIds := make([]string, 0, len(filterOutput.Dimensions))
+	for i := range filterOutput.Dimensions {
+		dimension := &filterOutput.Dimensions[i]
+		dimIds = append(dimIds, dimension.ID)
+		if helpers.IsBoolPtr(dimension.IsAreaType) && areaTypeID == "" {
+			areaTypeID = dimension.ID
+			parent = dimension.FilterByParent
+		}
+	}
+
+	sort.Slice(dimIds, func(i, j int) bool {
+		return dimIds[i] == areaTypeID || dimIds[i] == parent
+	})
+
+	if parent != "" {
+		areaTypeID = parent
+	}
+
+	// set default coverage
+	if len(areaOpts) == 0 {
+		areaOpts = []string{"K04000001"}
+		areaTypeID = "nat"
+	}
+
+	return population.GetBlockedAreaCountInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: userAccessToken,
+		},
+		PopulationType: filterOutput.PopulationType,
+		Variables:      dimIds,
+		Filter: population.Filter{
+			Codes:    areaOpts,
+			Variable: areaTypeID,
+		},
+	}
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// RouteFilterOutputEvents names the routes streaming the events of a filter output, so that they can be kept out of
+// the middleware which cannot flush a stream
+const RouteFilterOutputEvents = "filter-output-events"
+
+// Filter output server-sent event names
+const (
+	filterOutputEventDownload = "download"
+	filterOutputEventSDC      = "sdc"
+	filterOutputEventComplete = "complete"
+	filterOutputEventTimeout  = "timeout"
+)
+
+// requiredDownloadFormats are the formats which must be available before the downloads of a filter output are shown
+var requiredDownloadFormats = []string{"csv", "csvw", "txt"}
+
+// downloadEvent is the data of a download event, sent when a format of a filter output is ready to download
+type downloadEvent struct {
+	Format    string `json:"format"`
+	URL       string `json:"url"`
+	Size      string `json:"size"`
+	SizeLabel string `json:"size_label"`
+}
+
+// sdcEvent is the data of an sdc event, holding the statistical disclosure control result of a filter output and the
+// localised panel the page shows for it
+type sdcEvent struct {
+	*cantabular.GetBlockedAreaCountResult
+	Panel string   `json:"panel,omitempty"`
+	Body  []string `json:"body,omitempty"`
+}
+
+// FilterOutputEvents streams server-sent events as the downloads of a filter output become ready, along with the
+// statistical disclosure control result for multivariate datasets. The result is cached by filter output, so it is
+// only requested once however many pages and streams show it.
+func FilterOutputEvents(fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, cacheList *cache.List, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		var blockedAreaCounts *cache.BlockedAreaCountCache
+		if cacheList != nil {
+			blockedAreaCounts = cacheList.BlockedAreaCounts
+		}
+		filterOutputEvents(w, req, fc, pc, dc, blockedAreaCounts, cfg, collectionID, lang, userAccessToken)
+	})
+}
+
+func filterOutputEvents(w http.ResponseWriter, req *http.Request, fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, blockedAreaCounts *cache.BlockedAreaCountCache, cfg config.Config, collectionID, lang, userAccessToken string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterOutputID := vars["filterOutputID"]
+	logData := log.Data{"filter_output_id": filterOutputID}
+
+	filterOutput, err := fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+	if logError(ctx, w, err, "failed to get filter output", logData) {
+		return
+	}
+
+	datasetID := vars["datasetID"]
+	if datasetID == "" {
+		datasetID = filterOutput.Dataset.DatasetID
+	}
+	datasetModel, err := dc.GetDataset(ctx, dpDatasetApiSdk.Headers{CollectionID: collectionID, AccessToken: userAccessToken}, datasetID)
+	if logError(ctx, w, err, "failed to get dataset", log.Data{"dataset": datasetID}) {
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// the stream outlives the server's write timeout, so the deadline is extended to cover it where supported
+	if err = rc.SetWriteDeadline(time.Now().Add(cfg.FilterOutputEventsTimeout + cfg.FilterOutputEventsInterval)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Warn(ctx, "failed to extend write deadline for filter output events", log.FormatErrors([]error{err}), logData)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data interface{}) bool {
+		if err := writeEvent(w, rc, event, data); err != nil {
+			log.Warn(ctx, "failed to write filter output event", log.FormatErrors([]error{err}), log.Data{"filter_output_id": filterOutputID, "event": event})
+			return false
+		}
+		return true
+	}
+
+	if strings.Contains(datasetModel.Type, "multivariate") {
+		sdc, err := blockedAreaCounts.Load(ctx, filterOutputID, func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+			return getFilterOutputSDC(ctx, fc, pc, collectionID, userAccessToken, filterOutput)
+		})
+		if err != nil {
+			log.Error(ctx, "failed to get blocked area count", err, logData)
+		} else if !send(filterOutputEventSDC, newSDCEvent(sdc, lang)) {
+			return
+		}
+	}
+
+	sent := make(map[string]bool)
+	timeout := time.NewTimer(cfg.FilterOutputEventsTimeout)
+	defer timeout.Stop()
+	poll := time.NewTicker(cfg.FilterOutputEventsInterval)
+	defer poll.Stop()
+
+	for {
+		for _, event := range getReadyDownloads(filterOutput.Downloads) {
+			if sent[event.Format] {
+				continue
+			}
+			if !send(filterOutputEventDownload, event) {
+				return
+			}
+			sent[event.Format] = true
+		}
+
+		if hasRequiredDownloads(sent) {
+			send(filterOutputEventComplete, struct{}{})
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout.C:
+			log.Warn(ctx, "filter output downloads were not ready before the events timed out", logData)
+			send(filterOutputEventTimeout, struct{}{})
+			return
+		case <-poll.C:
+			output, err := fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+			if err != nil {
+				log.Warn(ctx, "failed to poll filter output", log.FormatErrors([]error{err}), logData)
+				continue
+			}
+			filterOutput = output
+		}
+	}
+}
+
+// getFilterOutputSDC returns the statistical disclosure control result for the areas selected in a filter output
+func getFilterOutputSDC(ctx context.Context, fc clients.FilterClient, pc clients.PopulationClient, collectionID, userAccessToken string, filterOutput filter.Model) (*cantabular.GetBlockedAreaCountResult, error) {
+	areaOpts, err := getFilterOutputAreaOptions(ctx, fc, collectionID, userAccessToken, filterOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.GetBlockedAreaCount(ctx, getBlockedAreaCountInput(userAccessToken, filterOutput, areaOpts))
+}
+
+// getFilterOutputAreaOptions returns the codes of the areas selected in the area type dimension of a filter output
+func getFilterOutputAreaOptions(ctx context.Context, fc clients.FilterClient, collectionID, userAccessToken string, filterOutput filter.Model) ([]string, error) {
+	var areaOpts []string
+	for i := range filterOutput.Dimensions {
+		dimension := &filterOutput.Dimensions[i]
+		if !helpers.IsBoolPtr(dimension.IsAreaType) {
+			continue
+		}
+
+		q := filter.QueryParams{Limit: 500}
+		opts, _, err := fc.GetDimensionOptions(ctx, userAccessToken, "", collectionID, filterOutput.FilterID, dimension.Name, &q)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get options for dimension: %w", err)
+		}
+		for _, opt := range opts.Items {
+			areaOpts = append(areaOpts, opt.Option)
+		}
+		break
+	}
+
+	return areaOpts, nil
+}
+
+// newSDCEvent returns the data of an sdc event for a statistical disclosure control result
+func newSDCEvent(sdc *cantabular.GetBlockedAreaCountResult, lang string) sdcEvent {
+	if sdc == nil {
+		sdc = &cantabular.GetBlockedAreaCountResult{}
+	}
+	event := sdcEvent{GetBlockedAreaCountResult: sdc}
+	if panels := mapper.MapFilterOutputSDCPanel(*sdc, lang); len(panels) > 0 {
+		event.Panel = panels[0].FuncGetPanelType()
+		event.Body = panels[0].Body
+	}
+	return event
+}
+
+// getReadyDownloads returns the downloads of a filter output which are available, ordered by format
+func getReadyDownloads(downloads map[string]filter.Download) []downloadEvent {
+	var events []downloadEvent
+	for format, download := range downloads {
+		if download.URL == "" || download.Skipped {
+			continue
+		}
+		// the size is labelled as the page labels it, leaving the label empty if the size is not a number of bytes
+		sizeLabel, _ := helper.HumanSize(download.Size)
+		events = append(events, downloadEvent{
+			Format:    strings.ToLower(format),
+			URL:       download.URL,
+			Size:      download.Size,
+			SizeLabel: sizeLabel,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Format < events[j].Format
+	})
+	return events
+}
+
+// hasRequiredDownloads reports whether all of the required download formats have been sent
+func hasRequiredDownloads(sent map[string]bool) bool {
+	for _, format := range requiredDownloadFormats {
+		if !sent[format] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeEvent writes a server-sent event with JSON data and flushes it to the client
+func writeEvent(w http.ResponseWriter, rc *http.ResponseController, event string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event data")
+	}
+	if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return errors.Wrap(err, "failed to write event")
+	}
+	return rc.Flush()
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilterOutputEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	cfg := initialiseMockConfig()
+	cfg.FilterOutputEventsInterval = time.Millisecond
+	cfg.FilterOutputEventsTimeout = time.Second
+
+	const eventsPath = "/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/events"
+
+	pending := filter.Model{
+		FilterID:       "filter-1",
+		PopulationType: "UR",
+		Dimensions: []filter.ModelDimension{
+			{Name: "ltla", ID: "ltla", IsAreaType: toBoolPtr(true)},
+			{Name: "sex", ID: "sex", IsAreaType: toBoolPtr(false)},
+		},
+		Downloads: map[string]filter.Download{
+			"CSV": {URL: "https://download.ons.gov.uk/output.csv", Size: "1234"},
+		},
+	}
+	ready := pending
+	ready.Downloads = map[string]filter.Download{
+		"CSV":  {URL: "https://download.ons.gov.uk/output.csv", Size: "1234"},
+		"CSVW": {URL: "https://download.ons.gov.uk/output.csvw", Size: "56"},
+		"TXT":  {URL: "https://download.ons.gov.uk/output.txt", Size: "78"},
+		"XLS":  {Skipped: true},
+	}
+
+	Convey("Given a multivariate filter output whose downloads are being created", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		gomock.InOrder(
+			mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(pending, nil),
+			mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(filter.Model{}, errors.New("filter api error")),
+			mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(ready, nil),
+		)
+		mockFc.EXPECT().GetDimensionOptions(gomock.Any(), userAuthToken, "", collectionID, "filter-1", "ltla", &filter.QueryParams{Limit: 500}).
+			Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "E06000001"}}}, "", nil)
+
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(gomock.Any(), gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Type: "cantabular_multivariate_table"}, nil)
+
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetBlockedAreaCount(gomock.Any(), population.GetBlockedAreaCountInput{
+			AuthTokens:     population.AuthTokens{UserAuthToken: userAuthToken},
+			PopulationType: "UR",
+			Variables:      []string{"ltla", "sex"},
+			Filter:         population.Filter{Codes: []string{"E06000001"}, Variable: "ltla"},
+		}).Return(&cantabular.GetBlockedAreaCountResult{Passed: 1, Blocked: 0, Total: 1}, nil).Times(1)
+
+		cacheList := &cache.List{BlockedAreaCounts: cache.NewBlockedAreaCountCache(time.Hour, 10, time.Second)}
+
+		Convey("When the events are requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/events", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(eventsPath, FilterOutputEvents(mockFc, mockPc, mockDc, cacheList, cfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the SDC result and its panel and each download are streamed, followed by a complete event", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/event-stream")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "no-cache")
+				So(w.Body.String(), ShouldEqual, strings.Join([]string{
+					"event: sdc\ndata: {\"passed\":1,\"blocked\":0,\"total\":1,\"panel\":\"success\",\"body\":[\"1 area available\"]}\n\n",
+					"event: download\ndata: {\"format\":\"csv\",\"url\":\"https://download.ons.gov.uk/output.csv\",\"size\":\"1234\",\"size_label\":\"1.2 KB\"}\n\n",
+					"event: download\ndata: {\"format\":\"csvw\",\"url\":\"https://download.ons.gov.uk/output.csvw\",\"size\":\"56\",\"size_label\":\"56 B\"}\n\n",
+					"event: download\ndata: {\"format\":\"txt\",\"url\":\"https://download.ons.gov.uk/output.txt\",\"size\":\"78\",\"size_label\":\"78 B\"}\n\n",
+					"event: complete\ndata: {}\n\n",
+				}, ""))
+			})
+
+			Convey("And the events are requested again once the downloads are ready", func() {
+				mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(ready, nil)
+				mockDc.EXPECT().GetDataset(gomock.Any(), gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Type: "cantabular_multivariate_table"}, nil)
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/events", http.NoBody))
+
+				Convey("Then the cached SDC result is streamed without requesting it again", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.String(), ShouldStartWith, "event: sdc\ndata: {\"passed\":1,")
+				})
+			})
+		})
+	})
+
+	Convey("Given a filter output for a dataset which is not multivariate whose downloads are never ready", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(filter.Model{}, nil).MinTimes(1)
+
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(gomock.Any(), gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Type: "cantabular_flexible_table"}, nil)
+
+		Convey("When the events are requested", func() {
+			timeoutCfg := cfg
+			timeoutCfg.FilterOutputEventsTimeout = 20 * time.Millisecond
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/events", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(eventsPath, FilterOutputEvents(mockFc, clients.NewMockPopulationClient(mockCtrl), mockDc, &cache.List{}, timeoutCfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then only a timeout event is streamed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, "event: timeout\ndata: {}\n\n")
+			})
+		})
+	})
+
+	Convey("Given the filter output cannot be found", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(filter.Model{}, &testCliError{})
+
+		Convey("When the events are requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/events", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(eventsPath, FilterOutputEvents(mockFc, nil, nil, nil, cfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the status code is 404 and no events are streamed", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Header().Get("Content-Type"), ShouldNotEqual, "text/event-stream")
+			})
+		})
+	})
+}
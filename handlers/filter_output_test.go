@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/golang/mock/gomock"
@@ -189,7 +191,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 
@@ -260,7 +262,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 
@@ -326,7 +328,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890?f=get-data&format=csv", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 
@@ -397,7 +399,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890?f=get-data&format=doc", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 
@@ -467,7 +469,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890?f=bob", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 
@@ -515,7 +517,7 @@ func TestFilterOutputHandler(t *testing.T) {
 				req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 				router := mux.NewRouter()
-				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 				router.ServeHTTP(w, req)
 				Convey("Then the status code is 200", func() {
@@ -589,7 +591,7 @@ func TestFilterOutputHandler(t *testing.T) {
 				req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 				router := mux.NewRouter()
-				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 				router.ServeHTTP(w, req)
 				Convey("Then the status code is 200", func() {
@@ -667,7 +669,7 @@ func TestFilterOutputHandler(t *testing.T) {
 					req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 					router := mux.NewRouter()
-					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, nil, cfg, ""))
 
 					router.ServeHTTP(w, req)
 					Convey("Then the status code is 200", func() {
@@ -746,7 +748,7 @@ func TestFilterOutputHandler(t *testing.T) {
 					req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 					router := mux.NewRouter()
-					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 					router.ServeHTTP(w, req)
 					Convey("Then the status code is 200", func() {
@@ -831,7 +833,7 @@ func TestFilterOutputHandler(t *testing.T) {
 					req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 					router := mux.NewRouter()
-					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+					router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 					router.ServeHTTP(w, req)
 					Convey("Then the status code is 200", func() {
@@ -880,7 +882,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -927,7 +929,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -974,7 +976,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -1021,7 +1023,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -1076,7 +1078,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -1134,7 +1136,76 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
+
+			router.ServeHTTP(w, req)
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When pc.GetCategorisations fails for one of the dimensions", func() {
+			mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockFc := clients.NewMockFilterClient(mockCtrl)
+			mockPc := clients.NewMockPopulationClient(mockCtrl)
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+
+			mockDc.
+				EXPECT().
+				GetDataset(ctx, headers, "12345").
+				Return(dpDatasetApiModels.Dataset{
+					Type: "multivariate",
+				}, nil)
+			mockDc.
+				EXPECT().
+				GetVersions(ctx, headers, "12345", "2021", &dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}).
+				Return(versions, nil)
+			mockDc.
+				EXPECT().
+				GetVersion(ctx, headers, "12345", "2021", "1").
+				Return(versions.Items[0], nil)
+
+			mockFc.
+				EXPECT().
+				GetOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(filter.Model{
+					Dimensions: []filter.ModelDimension{
+						{Name: "Dim A", ID: "Dim A", IsAreaType: toBoolPtr(false)},
+						{Name: "Dim B", ID: "Dim B", IsAreaType: toBoolPtr(false)},
+						{Name: "Dim C", ID: "Dim C", IsAreaType: toBoolPtr(false)},
+					},
+				}, nil)
+
+			mockPc.
+				EXPECT().
+				GetDimensionsDescription(ctx, gomock.Any()).
+				Return(population.GetDimensionsResponse{}, nil)
+			mockPc.
+				EXPECT().
+				GetDimensionCategories(ctx, gomock.Any()).
+				Return(population.GetDimensionCategoriesResponse{
+					PaginationResponse: population.PaginationResponse{TotalCount: 1},
+					Categories:         mockDimensionCategories,
+				}, nil)
+			mockPc.
+				EXPECT().
+				GetCategorisations(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+					if input.Dimension == "Dim B" {
+						return population.GetCategorisationsResponse{}, errors.New("Internal error")
+					}
+					return population.GetCategorisationsResponse{PaginationResponse: population.PaginationResponse{TotalCount: 2}}, nil
+				}).MinTimes(1).MaxTimes(3)
+			mockPc.
+				EXPECT().
+				GetPopulationType(gomock.Any(), gomock.Any()).
+				Return(population.GetPopulationTypeResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -1187,7 +1258,7 @@ func TestFilterOutputHandler(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, cfg, ""))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg, ""))
 
 			router.ServeHTTP(w, req)
 			Convey("Then the status code is 500", func() {
@@ -1256,7 +1327,7 @@ func TestFilterOutputHandler(t *testing.T) {
 				req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890", http.NoBody)
 
 				router := mux.NewRouter()
-				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, clients.NewMockRenderClient(mockCtrl), cfg, ""))
+				router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}", FilterOutput(mockZebedeeClient, mockFc, mockPc, mockDc, clients.NewMockRenderClient(mockCtrl), &cache.List{}, cfg, ""))
 
 				router.ServeHTTP(w, req)
 				Convey("Then the status code is 500", func() {
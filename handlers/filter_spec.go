@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/filterspec"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxFilterSpecBytes  = 1024 * 1024 // 1MB allows for specs containing every area of the largest area types
+	filterSpecFormField = "spec"
+	mimeMultipartForm   = "multipart/form-data"
+	mimeURLEncodedForm  = "application/x-www-form-urlencoded"
+)
+
+// FilterOutputSpec downloads the specification of a filter output so that it can be shared and imported later
+func FilterOutputSpec(fc clients.FilterClient) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		filterOutputID := mux.Vars(req)["filterOutputID"]
+		ctx := req.Context()
+
+		fo, err := fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+		if err != nil {
+			log.Error(ctx, "unable to get filter output", err, log.Data{"filter_output_id": filterOutputID})
+			setStatusCode(ctx, w, err)
+			return
+		}
+
+		b, err := json.MarshalIndent(filterspec.New(fo), "", "  ")
+		if err != nil {
+			setStatusCode(ctx, w, fmt.Errorf("failed to marshal filter specification: %w", err))
+			return
+		}
+
+		filename := fmt.Sprintf("filter-output-%s-spec.json", filterOutputID)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		if _, err = w.Write(b); err != nil {
+			log.Error(ctx, "failed to write filter specification response", err, log.Data{"filter_output_id": filterOutputID})
+		}
+	})
+}
+
+// ImportFilterSpecForm will load the page importing the selections of a previously downloaded filter output
+func ImportFilterSpecForm(zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+		defer cancel()
+
+		renderImportFilterSpec(ctx, budget, w, req, zc, rend, lang, collectionID, userAccessToken, "", "", http.StatusOK)
+	})
+}
+
+// ImportFilterSpec creates a new filter from a previously downloaded filter output specification. The specification
+// can be submitted in the spec field of the import form, sent as the request body or uploaded as the spec field of a
+// multipart form. Specifications which cannot be imported are shown on the import form with a localised error.
+func ImportFilterSpec(fc clients.FilterClient, pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+		defer cancel()
+
+		spec, err := readFilterSpec(w, req)
+		if err != nil {
+			log.Error(ctx, "unable to read filter specification", err)
+			status, errorKey := http.StatusBadRequest, "ImportFilterSpecErrorUnreadable"
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				status, errorKey = http.StatusRequestEntityTooLarge, "ImportFilterSpecErrorTooLarge"
+			}
+			renderImportFilterSpec(ctx, budget, w, req, zc, rend, lang, collectionID, userAccessToken, submittedFilterSpec(req), errorKey, status)
+			return
+		}
+
+		if err = spec.Validate(); err != nil {
+			log.Error(ctx, "filter specification failed validation", err, log.Data{"dataset": spec.Dataset})
+			renderImportFilterSpec(ctx, budget, w, req, zc, rend, lang, collectionID, userAccessToken, submittedFilterSpec(req), "ImportFilterSpecErrorInvalid", http.StatusBadRequest)
+			return
+		}
+
+		err = validateFilterSpecSelection(ctx, budget, pc, userAccessToken, spec)
+		if errors.Is(err, errInvalidCustomSelection) {
+			log.Warn(ctx, "filter specification has an unavailable selection", log.FormatErrors([]error{err}), log.Data{"dataset": spec.Dataset})
+			renderImportFilterSpec(ctx, budget, w, req, zc, rend, lang, collectionID, userAccessToken, submittedFilterSpec(req), "ImportFilterSpecErrorUnavailable", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Error(ctx, "failed to validate filter specification selection", err, log.Data{"dataset": spec.Dataset})
+			setStatusCode(ctx, w, err)
+			return
+		}
+
+		dims := spec.FilterDimensions()
+		fid := ""
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			if spec.Custom {
+				fid, _, err = fc.CreateFlexibleBlueprintCustom(ctx, userAccessToken, "", "", filter.CreateFlexBlueprintCustomRequest{
+					Dataset: filter.Dataset{
+						DatasetID: spec.Dataset.ID,
+						Edition:   spec.Dataset.Edition,
+						Version:   spec.Dataset.Version,
+					},
+					Dimensions:     dims,
+					PopulationType: spec.PopulationType,
+					CollectionID:   collectionID,
+				})
+				return err
+			}
+			fid, _, err = fc.CreateFlexibleBlueprint(ctx, userAccessToken, "", "", collectionID, spec.Dataset.ID, spec.Dataset.Edition, strconv.Itoa(spec.Dataset.Version), dims, spec.PopulationType)
+			return err
+		})
+		if err != nil {
+			log.Error(ctx, "unable to create filter from specification", err, log.Data{"dataset": spec.Dataset})
+			setStatusCode(ctx, w, err)
+			return
+		}
+
+		filterPath := createFilterPath(fid, url.QueryEscape(req.URL.Query().Get("dimension")))
+
+		log.Info(ctx, "created filter id from specification", log.Data{"filter_id": fid})
+		http.Redirect(w, req, filterPath, http.StatusSeeOther)
+	})
+}
+
+// renderImportFilterSpec renders the import form with the given status, showing the localised error of errorKey and
+// the submitted specification when the import failed
+func renderImportFilterSpec(ctx context.Context, budget *dependencyBudget, w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, rend clients.RenderClient, lang, collectionID, userAccessToken, spec, errorKey string, status int) {
+	token, err := csrf.Token(w, req)
+	if logError(ctx, w, err, "failed to create csrf token", nil) {
+		return
+	}
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	basePage := rend.NewBasePageModel()
+	m := mapper.CreateImportFilterSpecPage(basePage, req, lang, spec, filterSpecFormField, errorKey, token, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
+	w.WriteHeader(status)
+	rend.BuildPage(w, m, "import-filter-spec")
+}
+
+// submittedFilterSpec returns the specification submitted in the import form, if it was, to be shown again for
+// correction
+func submittedFilterSpec(req *http.Request) string {
+	if req.PostForm == nil {
+		return ""
+	}
+	return req.PostForm.Get(filterSpecFormField)
+}
+
+// validateFilterSpecSelection checks the population type, area type and dimensions of a specification against the
+// population types API, along with the area type its areas are filtered by. errInvalidCustomSelection is wrapped in
+// the returned error when any of them are not available.
+func validateFilterSpecSelection(ctx context.Context, budget *dependencyBudget, pc clients.PopulationClient, userAccessToken string, spec filterspec.Spec) error {
+	selection := customDatasetSelection{PopulationType: spec.PopulationType}
+	var parent string
+	for i := range spec.Dimensions {
+		dimension := &spec.Dimensions[i]
+		if dimension.IsAreaType {
+			selection.AreaType = dimension.Name
+			parent = dimension.FilterByParent
+			continue
+		}
+		selection.Dimensions = append(selection.Dimensions, dimension.Name)
+	}
+
+	if _, err := validateCustomDatasetSelection(ctx, budget, pc, userAccessToken, selection); err != nil {
+		return err
+	}
+
+	var areaTypes population.GetAreaTypesResponse
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		areaTypes, err = pc.GetAreaTypes(ctx, population.GetAreaTypesInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: userAccessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+			PopulationType: spec.PopulationType,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, areaType := range []string{selection.AreaType, parent} {
+		if areaType != "" && !slices.ContainsFunc(areaTypes.AreaTypes, func(a population.AreaType) bool { return a.ID == areaType }) {
+			return fmt.Errorf("%w: %q is not an area type", errInvalidCustomSelection, areaType)
+		}
+	}
+
+	return nil
+}
+
+// readFilterSpec decodes the specification from the import form, a multipart form upload or the request body
+func readFilterSpec(w http.ResponseWriter, req *http.Request) (spec filterspec.Spec, err error) {
+	req.Body = http.MaxBytesReader(w, req.Body, maxFilterSpecBytes)
+
+	var r io.Reader = req.Body
+	switch contentType := req.Header.Get("Content-Type"); {
+	case strings.HasPrefix(contentType, mimeURLEncodedForm):
+		if err = req.ParseForm(); err != nil {
+			return spec, err
+		}
+		r = strings.NewReader(req.PostForm.Get(filterSpecFormField))
+	case strings.HasPrefix(contentType, mimeMultipartForm):
+		if err = req.ParseMultipartForm(maxFilterSpecBytes); err != nil {
+			return spec, err
+		}
+		file, _, formErr := req.FormFile(filterSpecFormField)
+		if formErr != nil {
+			return spec, formErr
+		}
+		defer file.Close()
+		r = file
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&spec); err != nil {
+		return spec, err
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/filterspec"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/importspec"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilterOutputSpec(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const specPath = "/datasets/create/filter-outputs/{filterOutputID}/spec.json"
+
+	Convey("Given a filter output", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(filter.Model{
+			Dataset:        filter.Dataset{DatasetID: "TS008", Edition: "2021", Version: 1},
+			PopulationType: "UR",
+			Dimensions: []filter.ModelDimension{
+				{Name: "ltla", IsAreaType: toBoolPtr(true), Options: []string{"E06000001"}},
+			},
+		}, nil)
+
+		Convey("When the specification is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/create/filter-outputs/67890/spec.json", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(specPath, FilterOutputSpec(mockFc))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the specification is downloaded as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="filter-output-67890-spec.json"`)
+
+				var spec filterspec.Spec
+				So(json.Unmarshal(w.Body.Bytes(), &spec), ShouldBeNil)
+				So(spec.Dataset, ShouldResemble, filterspec.Dataset{ID: "TS008", Edition: "2021", Version: 1})
+				So(spec.Dimensions, ShouldResemble, []filterspec.Dimension{{Name: "ltla", IsAreaType: true, Options: []string{"E06000001"}}})
+			})
+		})
+	})
+
+	Convey("Given the filter output cannot be found", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(gomock.Any(), userAuthToken, "", "", collectionID, "67890").Return(filter.Model{}, &testCliError{})
+
+		Convey("When the specification is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/create/filter-outputs/67890/spec.json", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(specPath, FilterOutputSpec(mockFc))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the status code is 404", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestImportFilterSpec(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	spec := `{
+		"spec_version": 1,
+		"dataset": {"id": "TS008", "edition": "2021", "version": 1},
+		"population_type": "UR",
+		"custom": false,
+		"dimensions": [
+			{"name": "ltla", "is_area_type": true, "filter_by_parent": "rgn", "options": ["E12000001"]},
+			{"name": "sex", "is_area_type": false, "options": []}
+		]
+	}`
+	expectedDims := []filter.ModelDimension{
+		{Name: "ltla", IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "rgn", Options: []string{"E12000001"}},
+		{Name: "sex", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+	}
+	areaTypes := population.GetAreaTypesResponse{
+		AreaTypes: []population.AreaType{{ID: "ltla"}, {ID: "rgn"}},
+	}
+
+	expectValidation := func(pc *clients.MockPopulationClient) {
+		pc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(population.GetPopulationTypesResponse{
+			Items: []population.PopulationType{{Name: "UR"}},
+		}, nil)
+		pc.EXPECT().GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
+			AuthTokens:     population.AuthTokens{UserAuthToken: userAuthToken},
+			PopulationType: "UR",
+			DimensionIDs:   []string{"ltla", "sex"},
+		}).Return(population.GetDimensionsResponse{
+			Dimensions: []population.Dimension{{ID: "ltla"}, {ID: "sex"}},
+		}, nil)
+		pc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{
+			Items: []population.Dimension{{ID: "sex"}},
+		}, nil)
+	}
+
+	expectForm := func(zc *clients.MockZebedeeClient, rend *clients.MockRenderClient, page *importspec.Page) {
+		zc.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+		rend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		rend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "import-filter-spec").Do(func(_ io.Writer, m interface{}, _ string) {
+			*page = m.(importspec.Page)
+		})
+	}
+
+	serveImport := func(fc clients.FilterClient, pc clients.PopulationClient, zc clients.ZebedeeClient, rend clients.RenderClient, req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router := mux.NewRouter()
+		router.HandleFunc("/datasets/create/import", ImportFilterSpec(fc, pc, zc, rend, cfg))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	Convey("Given the import form is requested", t, func() {
+		zc := clients.NewMockZebedeeClient(mockCtrl)
+		rend := clients.NewMockRenderClient(mockCtrl)
+		var page importspec.Page
+		expectForm(zc, rend, &page)
+
+		Convey("When the page is rendered", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/create/import", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/create/import", ImportFilterSpecForm(zc, rend, cfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then an empty form is rendered with the token of the csrf cookie set", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(page.Data.CSRFToken, ShouldEqual, cookies[0].Value)
+				So(page.Data.Spec, ShouldBeEmpty)
+				So(page.Error.Title, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a valid specification sent as the request body", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		expectValidation(mockPc)
+		mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+		mockFc.EXPECT().CreateFlexibleBlueprint(gomock.Any(), userAuthToken, "", "", collectionID, "TS008", "2021", "1", expectedDims, "UR").
+			Return("12345", "", nil)
+
+		Convey("When the specification is imported", func() {
+			req := httptest.NewRequest("POST", "/datasets/create/import?dimension=ltla", strings.NewReader(spec))
+			req.Header.Set("Content-Type", "application/json")
+			w := serveImport(mockFc, mockPc, nil, nil, req)
+
+			Convey("Then a filter is created and the user is redirected to it", func() {
+				So(w.Code, ShouldEqual, http.StatusSeeOther)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions/ltla")
+			})
+		})
+	})
+
+	Convey("Given a valid specification submitted in the import form", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		expectValidation(mockPc)
+		mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+		mockFc.EXPECT().CreateFlexibleBlueprint(gomock.Any(), userAuthToken, "", "", collectionID, "TS008", "2021", "1", expectedDims, "UR").
+			Return("12345", "", nil)
+
+		Convey("When the specification is imported", func() {
+			req := httptest.NewRequest("POST", "/datasets/create/import", strings.NewReader(url.Values{"spec": {spec}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := serveImport(mockFc, mockPc, nil, nil, req)
+
+			Convey("Then a filter is created and the user is redirected to it", func() {
+				So(w.Code, ShouldEqual, http.StatusSeeOther)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions")
+			})
+		})
+	})
+
+	Convey("Given a valid custom specification uploaded as a file", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		expectValidation(mockPc)
+		mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+		mockFc.EXPECT().CreateFlexibleBlueprintCustom(gomock.Any(), userAuthToken, "", "", filter.CreateFlexBlueprintCustomRequest{
+			Dataset:        filter.Dataset{DatasetID: "TS008", Edition: "2021", Version: 1},
+			Dimensions:     expectedDims,
+			PopulationType: "UR",
+			CollectionID:   collectionID,
+		}).Return("12345", "", nil)
+
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		part, err := mw.CreateFormFile("spec", "spec.json")
+		So(err, ShouldBeNil)
+		_, err = part.Write([]byte(strings.Replace(spec, `"custom": false`, `"custom": true`, 1)))
+		So(err, ShouldBeNil)
+		So(mw.Close(), ShouldBeNil)
+
+		Convey("When the specification is imported", func() {
+			req := httptest.NewRequest("POST", "/datasets/create/import", body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			w := serveImport(mockFc, mockPc, nil, nil, req)
+
+			Convey("Then a custom filter is created and the user is redirected to it", func() {
+				So(w.Code, ShouldEqual, http.StatusSeeOther)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions")
+			})
+		})
+	})
+
+	Convey("Given specifications which cannot be imported", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		zc := clients.NewMockZebedeeClient(mockCtrl)
+		rend := clients.NewMockRenderClient(mockCtrl)
+		var page importspec.Page
+
+		tests := map[string]struct {
+			body     string
+			errorKey string
+		}{
+			"malformed JSON": {`{"spec_version": `, "ImportFilterSpecErrorUnreadable"},
+			"unknown fields": {`{"spec_version": 1, "unexpected": true}`, "ImportFilterSpecErrorUnreadable"},
+			"no area type":   {strings.Replace(spec, `"is_area_type": true`, `"is_area_type": false`, 1), "ImportFilterSpecErrorInvalid"},
+		}
+
+		for name, test := range tests {
+			Convey("When a specification with "+name+" is submitted in the import form", func() {
+				expectForm(zc, rend, &page)
+				req := httptest.NewRequest("POST", "/datasets/create/import", strings.NewReader(url.Values{"spec": {test.body}}.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				w := serveImport(mockFc, mockPc, zc, rend, req)
+
+				Convey("Then the form is shown again with a 400 status, the error and the submitted specification", func() {
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+					So(page.Error.ErrorItems, ShouldHaveLength, 1)
+					So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, test.errorKey)
+					So(page.Data.Spec, ShouldEqual, test.body)
+				})
+			})
+		}
+
+		Convey("When a specification with an area type which is not available is imported", func() {
+			expectValidation(mockPc)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(population.GetAreaTypesResponse{
+				AreaTypes: []population.AreaType{{ID: "ltla"}},
+			}, nil)
+			expectForm(zc, rend, &page)
+			req := httptest.NewRequest("POST", "/datasets/create/import", strings.NewReader(spec))
+			w := serveImport(mockFc, mockPc, zc, rend, req)
+
+			Convey("Then the form is shown again with a 400 status and no filter is created", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "ImportFilterSpecErrorUnavailable")
+			})
+		})
+
+		Convey("When a specification with a dimension which is not available is imported", func() {
+			mockPc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(population.GetPopulationTypesResponse{
+				Items: []population.PopulationType{{Name: "UR"}},
+			}, nil)
+			mockPc.EXPECT().GetDimensionsDescription(ctx, gomock.Any()).Return(population.GetDimensionsResponse{
+				Dimensions: []population.Dimension{{ID: "ltla"}},
+			}, nil)
+			expectForm(zc, rend, &page)
+			req := httptest.NewRequest("POST", "/datasets/create/import", strings.NewReader(spec))
+			w := serveImport(mockFc, mockPc, zc, rend, req)
+
+			Convey("Then the form is shown again with a 400 status and no filter is created", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "ImportFilterSpecErrorUnavailable")
+			})
+		})
+
+		Convey("When a specification larger than the limit is imported", func() {
+			expectForm(zc, rend, &page)
+			req := httptest.NewRequest("POST", "/datasets/create/import", strings.NewReader(`{"population_type": "`+strings.Repeat("a", maxFilterSpecBytes)+`"}`))
+			w := serveImport(mockFc, mockPc, zc, rend, req)
+
+			Convey("Then the form is shown again with a 413 status", func() {
+				So(w.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+				So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "ImportFilterSpecErrorTooLarge")
+			})
+		})
+	})
+}
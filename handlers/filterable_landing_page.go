@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
@@ -15,17 +14,19 @@ import (
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/diff"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // FilterableLanding will load a filterable landing page
 func FilterableLanding(dc clients.DatasetAPISdkClient, pc clients.PopulationClient, rend clients.RenderClient, zc clients.ZebedeeClient, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
 		filterableLanding(w, req, dc, pc, rend, zc, cfg, collectionID, lang, apiRouterVersion, userAccessToken)
 	})
 }
@@ -44,7 +45,8 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 	downloadServiceAuthToken := ""
 	taxonomyURL := ""
 
-	ctx := request.Context()
+	budget, ctx, cancel := newDependencyBudget(request.Context(), cfg)
+	defer cancel()
 	vars := mux.Vars(request)
 
 	datasetID := vars["datasetID"]
@@ -62,7 +64,11 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 	}
 
 	// Fetch the dataset
-	datasetDetails, err := dc.GetDataset(ctx, headers, datasetID)
+	var datasetDetails dpDatasetApiModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetDetails, err = dc.GetDataset(ctx, headers, datasetID)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -90,7 +96,11 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 
 	// Fetch versions associated with dataset and redirect to latest if specific version isn't requested
 	getVersionsQueryParams := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}
-	versionsList, err := dc.GetVersions(ctx, headers, datasetID, editionID, &getVersionsQueryParams)
+	var versionsList dpDatasetApiSdk.VersionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		versionsList, err = dc.GetVersions(ctx, headers, datasetID, editionID, &getVersionsQueryParams)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -113,7 +123,11 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 		return
 	}
 
-	version, err := dc.GetVersionV2(ctx, headers, datasetID, editionID, versionID)
+	var version dpDatasetApiModels.Version
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		version, err = dc.GetVersionV2(ctx, headers, datasetID, editionID, versionID)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -133,9 +147,23 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 	}
 
 	// Fetch homepage content
-	homepageContent, err := zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	// Show publishers reviewing the version in a collection how it differs from the version already published
+	var versionDiffPanels []census.Panel
+	if showVersionDiff(cfg, collectionID, version) {
+		var publishedVersion int
+		var changes []diff.Change
+		if budget.optional(ctx, partVersionDiff, func(ctx context.Context) (err error) {
+			publishedVersion, changes, err = publishedVersionDiff(ctx, dc, userAccessToken, datasetID, editionID, datasetDetails, version)
+			return err
+		}) {
+			versionDiffPanels = append(versionDiffPanels, mapper.MapVersionDiffPanel(publishedVersion, changes, lang))
+		}
 	}
 
 	// Build page context
@@ -147,7 +175,10 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 	if datasetDetails.Type == DatasetTypeNomis {
 		dims = dpDatasetApiSdk.VersionDimensionsList{Items: nil}
 	} else {
-		dims, err = dc.GetVersionDimensions(ctx, headers, datasetID, editionID, versionID)
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			dims, err = dc.GetVersionDimensions(ctx, headers, datasetID, editionID, versionID)
+			return err
+		})
 		if err != nil {
 			setStatusCode(ctx, responseWriter, err)
 			return
@@ -162,7 +193,11 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 		// Load from constant
 		numOpts = numOptsSummary
 	}
-	opts, err := getOptionsSummary(ctx, dc, userAccessToken, collectionID, datasetID, editionID, versionID, dims, numOpts)
+	var opts []dpDatasetApiSdk.VersionDimensionOptionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		opts, err = getOptionsSummary(ctx, dc, userAccessToken, collectionID, datasetID, editionID, versionID, dims, numOpts)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -171,11 +206,15 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 	if strings.Contains(datasetDetails.Type, "cantabular") {
 		idOfVersionBasedOn := version.IsBasedOn.ID
 		// population client stuff
-		pop, err := populationClient.GetPopulationType(ctx, population.GetPopulationTypeInput{
-			PopulationType: idOfVersionBasedOn,
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: userAccessToken,
-			},
+		var pop population.GetPopulationTypeResponse
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			pop, err = populationClient.GetPopulationType(ctx, population.GetPopulationTypeInput{
+				PopulationType: idOfVersionBasedOn,
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: userAccessToken,
+				},
+			})
+			return err
 		})
 		if err != nil {
 			log.Error(ctx, "failed to get population types", err)
@@ -192,6 +231,9 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 
 		m := mapper.CreateCensusLandingPage(basePage, datasetDetails, version, opts, categorisationsMap, allVersions, showAll, cfg.EnableMultivariate, pop)
 		m.DatasetLandingPage.OSRLogo = helpers.GetOSRLogoDetails(m.Language)
+		m.DatasetLandingPage.Panels = append(versionDiffPanels, m.DatasetLandingPage.Panels...)
+		m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, version.State)
+		m.CSPNonce = security.Nonce(ctx)
 
 		pageModel = m
 		templateName = "census-landing"
@@ -201,10 +243,10 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 			if datasetDetails.Links.Taxonomy != nil {
 				taxonomyURL = datasetDetails.Links.Taxonomy.HRef
 			}
-			bc, err = zebedeeClient.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, taxonomyURL)
-			if err != nil {
-				log.Warn(ctx, "unable to get breadcrumb for dataset uri", log.FormatErrors([]error{err}), log.Data{"taxonomy_url": taxonomyURL})
-			}
+			budget.optional(ctx, partBreadcrumb, func(ctx context.Context) (err error) {
+				bc, err = zebedeeClient.GetBreadcrumb(ctx, userAccessToken, collectionID, lang, taxonomyURL)
+				return err
+			})
 		}
 		// filterable landing mapper
 		m := mapper.CreateFilterableLandingPage(ctx, basePage, datasetDetails, version, datasetID, opts,
@@ -225,14 +267,22 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 		}
 
 		// Add metadata file to list of downloads
-		metadata, err := dc.GetVersionMetadata(ctx, headers, datasetID, editionID, versionID)
+		var metadata dpDatasetApiModels.Metadata
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			metadata, err = dc.GetVersionMetadata(ctx, headers, datasetID, editionID, versionID)
+			return err
+		})
 		if err != nil {
 			setStatusCode(ctx, responseWriter, err)
 			return
 		}
 
 		// get metadata file content. If a dimension has too many options, ignore the error and a size 0 will be shown to the user
-		textBytes, err := getText(ctx, dc, headers, datasetID, editionID, versionID, metadata, dims)
+		var textBytes []byte
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			textBytes, err = getText(ctx, dc, headers, datasetID, editionID, versionID, metadata, dims)
+			return err
+		})
 		if err != nil {
 			if err != errTooManyOptions {
 				setStatusCode(ctx, responseWriter, err)
@@ -247,6 +297,9 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 		})
 
 		m.DatasetLandingPage.OSRLogo = helpers.GetOSRLogoDetails(m.Language)
+		m.DatasetLandingPage.Panels = versionDiffPanels
+		m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, version.State)
+		m.CSPNonce = security.Nonce(ctx)
 
 		pageModel = m
 		if datasetDetails.Type == DatasetTypeNomis {
@@ -256,42 +309,35 @@ func filterableLanding(responseWriter http.ResponseWriter, request *http.Request
 		}
 	}
 	// Render the page
+	budget.setHeader(responseWriter)
 	renderClient.BuildPage(responseWriter, pageModel, templateName)
 }
 
+// getDimensionCategorisationCountMap returns the number of categorisations of each dimension that is not an area type,
+// keyed by dimension ID. If the count cannot be requested the dimension is treated as having a single categorisation.
 func getDimensionCategorisationCountMap(ctx context.Context, pc clients.PopulationClient, userAccessToken, populationType string, dims []dpDatasetApiModels.Dimension) map[string]int {
-	m := make(map[string]int)
-	var mutex sync.Mutex
-	var wg sync.WaitGroup
-
-	//nolint:gocritic //don't want to modify this goroutine
-	for _, dim := range dims {
-		if !helpers.IsBoolPtr(dim.IsAreaType) {
-			wg.Add(1)
-			go func(dim dpDatasetApiModels.Dimension) {
-				defer wg.Done()
-				cats, err := pc.GetCategorisations(ctx, population.GetCategorisationsInput{
-					AuthTokens: population.AuthTokens{
-						UserAuthToken: userAccessToken,
-					},
-					PaginationParams: population.PaginationParams{
-						Limit: 1000,
-					},
-					PopulationType: populationType,
-					Dimension:      dim.ID,
-				})
-				defer mutex.Unlock()
-				mutex.Lock()
+	var nonAreaDims []string
+	for i := range dims {
+		if !helpers.IsBoolPtr(dims[i].IsAreaType) {
+			nonAreaDims = append(nonAreaDims, dims[i].ID)
+		}
+	}
 
-				if err != nil {
-					m[dim.ID] = 1
-				} else {
-					m[dim.ID] = cats.TotalCount
-				}
-			}(dim)
+	counts := make([]int, len(nonAreaDims))
+	// errors are not returned, so that a failed dimension does not stop the others being counted
+	_ = forEachDimension(ctx, len(nonAreaDims), func(ctx context.Context, i int) error {
+		count, err := getCategorisationCount(ctx, pc, userAccessToken, populationType, nonAreaDims[i])
+		if err != nil {
+			count = 1
 		}
+		counts[i] = count
+		return nil
+	})
+
+	m := make(map[string]int, len(nonAreaDims))
+	for i, dimID := range nonAreaDims {
+		m[dimID] = counts[i]
 	}
-	wg.Wait()
 
 	return m
 }
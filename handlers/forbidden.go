@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	locales "github.com/ONSdigital/dp-frontend-dataset-controller/locale"
+)
+
+// Forbidden renders the page served when a form is submitted without a valid CSRF token, asking the user to go back
+// and submit it again from the page it is on
+func Forbidden(rend clients.RenderClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		lang := locales.FromRequest(req)
+
+		m := rend.NewBasePageModel()
+		m.Language = lang
+		m.Error.Title = helper.Localise("ForbiddenTitle", lang, 1)
+		m.Error.Description = helper.Localise("ForbiddenDescription", lang, 1)
+		rend.BuildErrorPage(w, m, http.StatusForbidden)
+	}
+}
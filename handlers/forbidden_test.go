@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestForbidden(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cfg := initialiseMockConfig()
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given a Welsh speaking client whose form was rejected without a valid token", t, func() {
+		var page core.Page
+		rend := clients.NewMockRenderClient(mockCtrl)
+		rend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		rend.EXPECT().BuildErrorPage(gomock.Any(), gomock.Any(), http.StatusForbidden).Do(func(_ io.Writer, m core.Page, _ int) {
+			page = m
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/datasets/create", http.NoBody)
+		req.AddCookie(&http.Cookie{Name: request.LocaleCookieKey, Value: "cy"})
+
+		Convey("When the page is rendered", func() {
+			Forbidden(rend).ServeHTTP(w, req)
+
+			Convey("Then it asks them to submit the form again in their language", func() {
+				So(page.Language, ShouldEqual, "cy")
+				So(page.Error.Title, ShouldEqual, "Nid oedd modd anfon y ffurflen")
+				So(page.Error.Description, ShouldContainSubstring, "Ewch yn ôl")
+			})
+		})
+	})
+}
@@ -4,15 +4,21 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	locales "github.com/ONSdigital/dp-frontend-dataset-controller/locale"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	dpHandlers "github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
@@ -28,6 +34,12 @@ const (
 	queryStrKey          = "showAll"
 	formQueryGetData     = "get-data"
 
+	// maxConcurrentDimensionRequests is the number of dimensions whose options are requested from the dataset API at once
+	maxConcurrentDimensionRequests = 4
+	// maxConcurrentAreaTypeRequests is the number of area types whose blocked area counts are requested from the
+	// population API at once
+	maxConcurrentAreaTypeRequests = 4
+
 	// Dataset types
 	DatasetTypeNomis  = "nomis"
 	DatasetTypeStatic = "static"
@@ -39,6 +51,14 @@ const (
 
 var errTooManyOptions = errors.New("too many options in dimension")
 
+// controllerHandler wraps the dp-net controller handler to give the handler the language resolved by the locale
+// middleware, which supports every configured language rather than only English and Welsh
+func controllerHandler(controllerHandlerFunc dpHandlers.ControllerHandlerFunc) http.HandlerFunc {
+	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, _, collectionID, accessToken string) {
+		controllerHandlerFunc(w, req, locales.FromRequest(req), collectionID, accessToken)
+	})
+}
+
 func setStatusCode(ctx context.Context, w http.ResponseWriter, err error) {
 	status := http.StatusInternalServerError
 
@@ -70,6 +90,29 @@ func setStatusCode(ctx context.Context, w http.ResponseWriter, err error) {
 	w.WriteHeader(status)
 }
 
+// isNotFound reports whether err is a response from an API client saying the requested resource was not found
+func isNotFound(err error) bool {
+	if clientErr, ok := err.(clients.ClientError); ok {
+		return clientErr.Code() == http.StatusNotFound
+	}
+	if datasetErr, ok := err.(dpDatasetApiModels.Error); ok {
+		return datasetErr.Code == strconv.Itoa(http.StatusNotFound)
+	}
+	return false
+}
+
+// redirectToCanonicalTopic permanently redirects the request to the same path under the canonical topic slug,
+// preserving any query string
+func redirectToCanonicalTopic(w http.ResponseWriter, r *http.Request, canonicalTopicSlug string) {
+	redirectURL := url.URL{
+		Path:     helpers.ReplaceFirstPathSegment(r.URL.Path, canonicalTopicSlug),
+		RawQuery: r.URL.RawQuery,
+	}
+
+	//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
+	http.Redirect(w, r, redirectURL.String(), http.StatusMovedPermanently)
+}
+
 // getOptionsSummary requests a maximum of numOpts for each dimension, and returns the array of Options structs for each dimension, each one containing up to numOpts options.
 func getOptionsSummary(ctx context.Context, dc clients.DatasetAPISdkClient, userAccessToken, collectionID, datasetID, edition, version string, dimensions dpDatasetApiSdk.VersionDimensionsList, numOpts int) (opts []dpDatasetApiSdk.VersionDimensionOptionsList, err error) {
 	headers := dpDatasetApiSdk.Headers{
@@ -77,63 +120,152 @@ func getOptionsSummary(ctx context.Context, dc clients.DatasetAPISdkClient, user
 		AccessToken:  userAccessToken,
 	}
 
-	for i := range dimensions.Items {
-		dimension := &dimensions.Items[i]
-
-		// for time and age, request all the options (assumed less than maxAgeAndTimeOptions)
-		if dimension.Name == mapper.DimensionTime || dimension.Name == mapper.DimensionAge {
-			// query with limit maxAgeAndTimeOptions
-			q := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: maxAgeAndTimeOptions}
-			opt, err := dc.GetVersionDimensionOptions(ctx, headers, datasetID, edition, version, dimension.Name, &q)
-			if err != nil {
-				return opts, err
-			}
-
-			totalCount := len(opt.Items)
-
-			if totalCount > maxAgeAndTimeOptions {
+	return getDimensionOptions(ctx, dc, headers, datasetID, edition, version, dimensions, func(dimension *dpDatasetApiModels.Dimension, opt dpDatasetApiSdk.VersionDimensionOptionsList) error {
+		// for time and age, all the options are requested (assumed less than maxAgeAndTimeOptions)
+		if isAgeOrTime(dimension) {
+			if totalCount := len(opt.Items); totalCount > maxAgeAndTimeOptions {
 				log.Warn(ctx, "total number of options is greater than the requested number", log.Data{"max_age_and_time_options": maxAgeAndTimeOptions, "total_count": totalCount})
 			}
-
-			opts = append(opts, opt)
-			continue
 		}
-
-		// for other dimensions, cap the number of options to numOpts
-		q := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: numOpts}
-		opt, err := dc.GetVersionDimensionOptions(ctx, headers, datasetID, edition, version, dimension.Name, &q)
-		if err != nil {
-			return opts, err
+		return nil
+	}, func(dimension *dpDatasetApiModels.Dimension) int {
+		if isAgeOrTime(dimension) {
+			return maxAgeAndTimeOptions
 		}
-		opts = append(opts, opt)
-	}
-	return opts, nil
+		// for other dimensions, cap the number of options to numOpts
+		return numOpts
+	})
 }
 
 // getText gets a byte array containing the metadata content, based on options returned by dataset API.
 // If a dimension has more than maxMetadataOptions, an error will be returned
 func getText(ctx context.Context, dc clients.DatasetAPISdkClient, headers dpDatasetApiSdk.Headers, datasetID, editionID, versionID string,
 	metadata dpDatasetApiModels.Metadata, dimensions dpDatasetApiSdk.VersionDimensionsList) ([]byte, error) {
+	opts, err := getDimensionOptions(ctx, dc, headers, datasetID, editionID, versionID, dimensions, func(dimension *dpDatasetApiModels.Dimension, opt dpDatasetApiSdk.VersionDimensionOptionsList) error {
+		if len(opt.Items) > maxMetadataOptions {
+			return errTooManyOptions
+		}
+		return nil
+	}, func(dimension *dpDatasetApiModels.Dimension) int {
+		return maxMetadataOptions
+	})
+	if err == errTooManyOptions {
+		return []byte{}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	var b bytes.Buffer
 
 	b.WriteString(metadata.ToString())
 	b.WriteString("Dimensions:\n")
 
-	for i := range dimensions.Items {
+	for i := range opts {
+		b.WriteString(opts[i].ToString())
+	}
+
+	return b.Bytes(), nil
+}
+
+// getDimensionOptions requests the options of every dimension concurrently using forEachDimension. The results are
+// returned in the same order as the dimensions. The first error returned by the dataset API or by check cancels the
+// outstanding requests and is returned. The time taken for each dimension is logged.
+func getDimensionOptions(ctx context.Context, dc clients.DatasetAPISdkClient, headers dpDatasetApiSdk.Headers, datasetID, editionID, versionID string,
+	dimensions dpDatasetApiSdk.VersionDimensionsList, check func(*dpDatasetApiModels.Dimension, dpDatasetApiSdk.VersionDimensionOptionsList) error,
+	limit func(*dpDatasetApiModels.Dimension) int) ([]dpDatasetApiSdk.VersionDimensionOptionsList, error) {
+	opts := make([]dpDatasetApiSdk.VersionDimensionOptionsList, len(dimensions.Items))
+	durations := make([]time.Duration, len(dimensions.Items))
+	start := time.Now()
+
+	err := forEachDimension(ctx, len(dimensions.Items), func(ctx context.Context, i int) error {
 		dimension := &dimensions.Items[i]
-		q := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: maxMetadataOptions}
-		options, err := dc.GetVersionDimensionOptions(ctx, headers, datasetID, editionID, versionID, dimension.Name, &q)
+		dimensionStart := time.Now()
+
+		q := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: limit(dimension)}
+		opt, err := dc.GetVersionDimensionOptions(ctx, headers, datasetID, editionID, versionID, dimension.Name, &q)
+		durations[i] = time.Since(dimensionStart)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if len(options.Items) > maxMetadataOptions {
-			return []byte{}, errTooManyOptions
+		if err = check(dimension, opt); err != nil {
+			return err
 		}
 
-		b.WriteString(options.ToString())
+		opts[i] = opt
+		return nil
+	})
+
+	timings := make(map[string]string, len(dimensions.Items))
+	for i := range dimensions.Items {
+		if durations[i] > 0 {
+			timings[dimensions.Items[i].Name] = durations[i].String()
+		}
+	}
+	log.Info(ctx, "requested dimension options", log.Data{
+		"dataset_id":  datasetID,
+		"edition":     editionID,
+		"version":     versionID,
+		"timings":     timings,
+		"total_time":  time.Since(start).String(),
+		"concurrency": maxConcurrentDimensionRequests,
+	})
+
+	if err != nil {
+		return nil, err
 	}
+	return opts, nil
+}
 
-	return b.Bytes(), nil
+// forEachDimension calls fn for each of n dimensions concurrently, with at most maxConcurrentDimensionRequests calls
+// in flight at once. The context passed to fn is cancelled when any call returns an error, the remaining calls are
+// skipped and the first error is returned.
+func forEachDimension(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	return forEachConcurrently(ctx, n, maxConcurrentDimensionRequests, fn)
+}
+
+// forEachAreaType calls fn for each of n area types concurrently, with at most maxConcurrentAreaTypeRequests calls in
+// flight at once. As with forEachDimension, the first error cancels the remaining calls and is returned.
+func forEachAreaType(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	return forEachConcurrently(ctx, n, maxConcurrentAreaTypeRequests, fn)
+}
+
+// forEachConcurrently calls fn for each of n items with at most limit calls in flight at once, cancelling the context
+// passed to the remaining calls when any returns an error
+func forEachConcurrently(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			// skip the call if another item has already failed
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx, i)
+		})
+	}
+
+	return g.Wait()
+}
+
+// getCategorisationCount returns the number of categorisations of a dimension of a population type
+func getCategorisationCount(ctx context.Context, pc clients.PopulationClient, userAccessToken, populationType, dimension string) (int, error) {
+	cats, err := pc.GetCategorisations(ctx, population.GetCategorisationsInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: userAccessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: populationType,
+		Dimension:      dimension,
+	})
+	return cats.TotalCount, err
+}
+
+func isAgeOrTime(dimension *dpDatasetApiModels.Dimension) bool {
+	return dimension.Name == mapper.DimensionTime || dimension.Name == mapper.DimensionAge
 }
 
 func handleRequestForZebedeeJSONData(ctx context.Context, w http.ResponseWriter, zc clients.ZebedeeClient, path, userAccessToken string) (wasZebedeeRequest bool) {
@@ -5,11 +5,21 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions/permissionstest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
 	"github.com/golang/mock/gomock"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -92,6 +102,22 @@ func TestUnitHandlers(t *testing.T) {
 	})
 }
 
+func TestRedirectToCanonicalTopic(t *testing.T) {
+	Convey("Given a request for a static dataset under a non-canonical topic slug", t, func() {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/wrong-topic/datasets/cpih/editions/2024/versions/1?f=get-data&format=csv", http.NoBody)
+
+		Convey("When redirectToCanonicalTopic is called", func() {
+			redirectToCanonicalTopic(w, r, "economy")
+
+			Convey("Then the request is permanently redirected to the canonical topic, preserving the query string", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/economy/datasets/cpih/editions/2024/versions/1?f=get-data&format=csv")
+			})
+		})
+	})
+}
+
 func TestSortOptionsByCode(t *testing.T) {
 	Convey("Population categories are sorted", t, func() {
 		getCategoryList := func(items []population.DimensionCategoryItem) []string {
@@ -200,6 +226,39 @@ func TestSortOptionsByCode(t *testing.T) {
 	})
 }
 
+// testIssuer signs the JWT tokens of test users, so permissions are checked without a live identity service
+var testIssuer = mustNewTestIssuer()
+
+func mustNewTestIssuer() *permissionstest.Issuer {
+	issuer, err := permissionstest.NewIssuer()
+	if err != nil {
+		panic(err)
+	}
+	return issuer
+}
+
+func mustNewTestToken(userID string, groups ...string) string {
+	token, err := testIssuer.Token(userID, groups...)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+// newTestPermissionsChecker returns a permissions checker letting admins approve any dataset, and admins and
+// publishers edit any dataset
+func newTestPermissionsChecker() *permissions.Checker {
+	return permissions.NewChecker(testIssuer, permissionstest.NewPermissionsChecker(permissionsAPISDK.Bundle{
+		permissions.DatasetsApprove: {
+			"groups/role-admin": {{}},
+		},
+		permissions.DatasetsEdit: {
+			"groups/role-admin":     {{}},
+			"groups/role-publisher": {{}},
+		},
+	}))
+}
+
 func initialiseMockConfig() config.Config {
 	return config.Config{
 		PatternLibraryAssetsPath: "http://localhost:9000/dist",
@@ -207,3 +266,242 @@ func initialiseMockConfig() config.Config {
 		SupportedLanguages:       []string{"en", "cy"},
 	}
 }
+
+func TestGetOptionsSummary(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := coreContext.Background()
+	headers := dpDatasetApiSdk.Headers{CollectionID: collectionID, AccessToken: userAuthToken}
+
+	dimensions := dpDatasetApiSdk.VersionDimensionsList{}
+	for _, name := range []string{"geography", "time", "aggregate", "age", "sex", "industry", "occupation", "region"} {
+		dimensions.Items = append(dimensions.Items, dpDatasetApiModels.Dimension{Name: name})
+	}
+
+	Convey("Given a dataset version with more dimensions than can be requested at once", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+
+		var inFlight, maxInFlight int32
+		mockClient.EXPECT().GetVersionDimensionOptions(gomock.Any(), headers, "cpih01", "time-series", "1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ coreContext.Context, _ dpDatasetApiSdk.Headers, _, _, _, dimension string, q *dpDatasetApiSdk.QueryParams) (dpDatasetApiSdk.VersionDimensionOptionsList, error) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					highest := atomic.LoadInt32(&maxInFlight)
+					if current <= highest || atomic.CompareAndSwapInt32(&maxInFlight, highest, current) {
+						break
+					}
+				}
+
+				// vary the response time by dimension so the responses arrive out of order
+				time.Sleep(time.Duration(len(dimension)) * time.Millisecond)
+				return dpDatasetApiSdk.VersionDimensionOptionsList{
+					Items: []dpDatasetApiModels.PublicDimensionOption{{Name: dimension, Option: strconv.Itoa(q.Limit)}},
+				}, nil
+			}).Times(len(dimensions.Items))
+
+		Convey("When the options summary is requested", func() {
+			opts, err := getOptionsSummary(ctx, mockClient, userAuthToken, collectionID, "cpih01", "time-series", "1", dimensions, numOptsSummary)
+
+			Convey("Then the options are returned in the order of the dimensions", func() {
+				So(err, ShouldBeNil)
+				So(opts, ShouldHaveLength, len(dimensions.Items))
+				for i := range dimensions.Items {
+					So(opts[i].Items[0].Name, ShouldEqual, dimensions.Items[i].Name)
+				}
+			})
+
+			Convey("Then all of the options are requested for time and age only", func() {
+				So(opts[1].Items[0].Option, ShouldEqual, strconv.Itoa(maxAgeAndTimeOptions))
+				So(opts[3].Items[0].Option, ShouldEqual, strconv.Itoa(maxAgeAndTimeOptions))
+				So(opts[0].Items[0].Option, ShouldEqual, strconv.Itoa(numOptsSummary))
+			})
+
+			Convey("Then no more than the maximum number of requests are made at once", func() {
+				So(atomic.LoadInt32(&maxInFlight), ShouldBeLessThanOrEqualTo, maxConcurrentDimensionRequests)
+			})
+		})
+	})
+
+	Convey("Given the dataset API fails for one dimension", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		apiErr := errors.New("dataset api error")
+
+		var cancelled int32
+		mockClient.EXPECT().GetVersionDimensionOptions(gomock.Any(), headers, "cpih01", "time-series", "1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(reqCtx coreContext.Context, _ dpDatasetApiSdk.Headers, _, _, _, dimension string, _ *dpDatasetApiSdk.QueryParams) (dpDatasetApiSdk.VersionDimensionOptionsList, error) {
+				if dimension == "geography" {
+					// fail once the requests for the other dimensions are in flight
+					time.Sleep(10 * time.Millisecond)
+					return dpDatasetApiSdk.VersionDimensionOptionsList{}, apiErr
+				}
+				select {
+				case <-reqCtx.Done():
+					atomic.AddInt32(&cancelled, 1)
+					return dpDatasetApiSdk.VersionDimensionOptionsList{}, reqCtx.Err()
+				case <-time.After(time.Second):
+					return dpDatasetApiSdk.VersionDimensionOptionsList{}, nil
+				}
+			}).MinTimes(1).MaxTimes(maxConcurrentDimensionRequests)
+
+		Convey("When the options summary is requested", func() {
+			opts, err := getOptionsSummary(ctx, mockClient, userAuthToken, collectionID, "cpih01", "time-series", "1", dimensions, numOptsSummary)
+
+			Convey("Then the first error is returned and the outstanding requests are cancelled", func() {
+				So(err, ShouldEqual, apiErr)
+				So(opts, ShouldBeNil)
+				So(atomic.LoadInt32(&cancelled), ShouldEqual, maxConcurrentDimensionRequests-1)
+			})
+		})
+	})
+}
+
+func TestGetText(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := coreContext.Background()
+	headers := dpDatasetApiSdk.Headers{CollectionID: collectionID, AccessToken: userAuthToken}
+	dimensions := dpDatasetApiSdk.VersionDimensionsList{
+		Items: []dpDatasetApiModels.Dimension{{Name: "geography"}, {Name: "time"}},
+	}
+
+	Convey("Given the options of every dimension are returned", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		for i := range dimensions.Items {
+			name := dimensions.Items[i].Name
+			mockClient.EXPECT().GetVersionDimensionOptions(gomock.Any(), headers, "cpih01", "time-series", "1", name, &dpDatasetApiSdk.QueryParams{Offset: 0, Limit: maxMetadataOptions}).
+				Return(dpDatasetApiSdk.VersionDimensionOptionsList{Items: []dpDatasetApiModels.PublicDimensionOption{{Name: name, Label: name + " label"}}}, nil)
+		}
+
+		Convey("When the metadata text is requested", func() {
+			b, err := getText(ctx, mockClient, headers, "cpih01", "time-series", "1", dpDatasetApiModels.Metadata{}, dimensions)
+
+			Convey("Then the options are written in the order of the dimensions", func() {
+				So(err, ShouldBeNil)
+				text := string(b)
+				So(text, ShouldContainSubstring, "Dimensions:\n")
+				So(strings.Index(text, "geography label"), ShouldBeLessThan, strings.Index(text, "time label"))
+			})
+		})
+	})
+
+	Convey("Given a dimension has more options than can be written", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		tooMany := dpDatasetApiSdk.VersionDimensionOptionsList{Items: make([]dpDatasetApiModels.PublicDimensionOption, maxMetadataOptions+1)}
+		mockClient.EXPECT().GetVersionDimensionOptions(gomock.Any(), headers, "cpih01", "time-series", "1", gomock.Any(), gomock.Any()).
+			Return(tooMany, nil).MinTimes(1).MaxTimes(len(dimensions.Items))
+
+		Convey("When the metadata text is requested", func() {
+			b, err := getText(ctx, mockClient, headers, "cpih01", "time-series", "1", dpDatasetApiModels.Metadata{}, dimensions)
+
+			Convey("Then errTooManyOptions is returned", func() {
+				So(err, ShouldEqual, errTooManyOptions)
+				So(b, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestForEachDimension(t *testing.T) {
+	ctx := coreContext.Background()
+
+	Convey("Given a function to call for each dimension", t, func() {
+		var calls int32
+		results := make([]int, 10)
+
+		Convey("When every call succeeds", func() {
+			err := forEachDimension(ctx, len(results), func(ctx coreContext.Context, i int) error {
+				atomic.AddInt32(&calls, 1)
+				results[i] = i * i
+				return nil
+			})
+
+			Convey("Then it is called once for each dimension", func() {
+				So(err, ShouldBeNil)
+				So(atomic.LoadInt32(&calls), ShouldEqual, len(results))
+				So(results[3], ShouldEqual, 9)
+				So(results[9], ShouldEqual, 81)
+			})
+		})
+
+		Convey("When a call fails", func() {
+			callErr := errors.New("dimension error")
+			err := forEachDimension(ctx, len(results), func(ctx coreContext.Context, i int) error {
+				atomic.AddInt32(&calls, 1)
+				if i == 0 {
+					return callErr
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+			Convey("Then the error is returned and the remaining dimensions are skipped", func() {
+				So(err, ShouldEqual, callErr)
+				So(atomic.LoadInt32(&calls), ShouldBeLessThanOrEqualTo, maxConcurrentDimensionRequests)
+			})
+		})
+	})
+}
+
+func TestForEachAreaType(t *testing.T) {
+	ctx := coreContext.Background()
+
+	Convey("Given a function to call for each area type", t, func() {
+		var inFlight, maxInFlight int32
+		results := make([]int, 10)
+
+		Convey("When every call succeeds", func() {
+			err := forEachAreaType(ctx, len(results), func(ctx coreContext.Context, i int) error {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					highest := atomic.LoadInt32(&maxInFlight)
+					if current <= highest || atomic.CompareAndSwapInt32(&maxInFlight, highest, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				results[i] = i * i
+				return nil
+			})
+
+			Convey("Then it is called once for each area type with no more than the maximum number of calls at once", func() {
+				So(err, ShouldBeNil)
+				So(results[9], ShouldEqual, 81)
+				So(atomic.LoadInt32(&maxInFlight), ShouldBeLessThanOrEqualTo, maxConcurrentAreaTypeRequests)
+			})
+		})
+	})
+}
+
+func TestGetDimensionCategorisationCountMap(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := coreContext.Background()
+
+	Convey("Given a version with area type and other dimensions", t, func() {
+		isAreaType := true
+		dims := []dpDatasetApiModels.Dimension{
+			{ID: "ltla", IsAreaType: &isAreaType},
+			{ID: "sex"},
+			{ID: "age"},
+		}
+
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetCategorisations(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ coreContext.Context, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+				if input.Dimension == "age" {
+					return population.GetCategorisationsResponse{}, errors.New("population api error")
+				}
+				return population.GetCategorisationsResponse{PaginationResponse: population.PaginationResponse{TotalCount: 3}}, nil
+			}).Times(2)
+
+		Convey("When the categorisation counts are requested", func() {
+			m := getDimensionCategorisationCountMap(ctx, mockPc, userAuthToken, "UR", dims)
+
+			Convey("Then only the dimensions that are not area types are counted, and a failed count defaults to 1", func() {
+				So(m, ShouldResemble, map[string]int{"sex": 3, "age": 1})
+			})
+		})
+	})
+}
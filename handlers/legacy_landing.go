@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -13,9 +14,9 @@ import (
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/dp-net/v3/handlers/response"
-	"github.com/ONSdigital/dp-net/v3/request"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -35,7 +36,7 @@ type legacyLandingPage struct {
 
 // LegacyLanding will load a zebedee landing page
 func LegacyLanding(zc clients.ZebedeeClient, dc clients.APIClientsGoDatasetClient, fc clients.FilesAPIClient, rend clients.RenderClient, cacheList *cache.List, cfg config.Config) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
 		lp := legacyLandingPage{
 			ZebedeeClient:   zc,
 			DatasetClient:   dc,
@@ -53,50 +54,56 @@ func LegacyLanding(zc clients.ZebedeeClient, dc clients.APIClientsGoDatasetClien
 
 func (lp legacyLandingPage) Build(w http.ResponseWriter, req *http.Request) {
 	path := req.URL.Path
-	ctx := req.Context()
 
-	if handleRequestForZebedeeJSONData(ctx, w, lp.ZebedeeClient, path, lp.UserAccessToken) {
+	if handleRequestForZebedeeJSONData(req.Context(), w, lp.ZebedeeClient, path, lp.UserAccessToken) {
 		return
 	}
 
-	dlp, err := lp.getDatasetLandingPage(ctx, path)
-	if err != nil {
-		setStatusCode(ctx, w, err)
-		return
-	}
+	budget, ctx, cancel := newDependencyBudget(req.Context(), lp.Config)
+	defer cancel()
 
-	bc, err := lp.getBreadcrumb(ctx, dlp.URI)
+	var dlp zebedee.DatasetLandingPage
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dlp, err = lp.getDatasetLandingPage(ctx, path)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, w, err)
 		return
 	}
 
-	homepageContent, err := lp.getHomepageContent(ctx)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var bc []zebedee.Breadcrumb
+	budget.optional(ctx, partBreadcrumb, func(ctx context.Context) (err error) {
+		bc, err = lp.getBreadcrumb(ctx, dlp.URI)
+		return err
+	})
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = lp.getHomepageContent(ctx)
+		return err
+	})
 
-	datasets, err := lp.getDatasets(ctx, dlp, log.Data{"path": path})
+	datasets, err := lp.getDatasets(ctx, budget, dlp, log.Data{"path": path})
 	if err != nil {
 		setStatusCode(ctx, w, err)
 		return
 	}
 
-	lp.getRelatedDatasetLinks(req.Context(), &dlp)
+	lp.getRelatedDatasetLinks(ctx, budget, &dlp)
 
 	// get cached navigation data
-	locale := request.GetLocaleCode(req)
-	navigationCache, err := lp.CacheList.Navigation.GetNavigationData(ctx, locale)
-	if err != nil {
-		log.Error(ctx, "failed to get navigation cache", err)
-		setStatusCode(ctx, w, err)
-		return
-	}
+	var navigationCache *topicModel.Navigation
+	budget.optional(ctx, partNavigation, func(ctx context.Context) (err error) {
+		navigationCache, err = lp.CacheList.Navigation.GetNavigationData(ctx, lp.Language)
+		return err
+	})
 
 	basePage := lp.RenderClient.NewBasePageModel()
 	m := mapper.CreateLegacyDatasetLanding(ctx, basePage, req, dlp, bc, datasets, lp.Language, homepageContent.ServiceMessage, homepageContent.EmergencyBanner, navigationCache)
 
 	m.DatasetLandingPage.OSRLogo = helpers.GetOSRLogoDetails(m.Language)
+	m.Preview = mapper.MapPreview(lp.Config.IsPublishing, lp.CollectionID, "")
 
 	b, err := json.Marshal(m)
 	if err != nil {
@@ -107,11 +114,13 @@ func (lp legacyLandingPage) Build(w http.ResponseWriter, req *http.Request) {
 
 	generatedETag := response.GenerateETag(b, true)
 	response.SetETag(w, generatedETag)
+	budget.setHeader(w)
 
+	m.CSPNonce = security.Nonce(ctx)
 	lp.RenderClient.BuildPage(w, m, "static-legacy")
 }
 
-func (lp legacyLandingPage) getDatasets(ctx context.Context, dlp zebedee.DatasetLandingPage, logData log.Data) ([]zebedee.Dataset, error) {
+func (lp legacyLandingPage) getDatasets(ctx context.Context, budget *dependencyBudget, dlp zebedee.DatasetLandingPage, logData log.Data) ([]zebedee.Dataset, error) {
 	datasets := make([]zebedee.Dataset, len(dlp.Datasets))
 	errs, ctx := errgroup.WithContext(ctx)
 	for i := range dlp.Datasets {
@@ -119,17 +128,20 @@ func (lp legacyLandingPage) getDatasets(ctx context.Context, dlp zebedee.Dataset
 		ld := logData
 		ld["dataset_uri"] = dlp.Datasets[i].URI
 		errs.Go(func() error {
-			d, err := lp.ZebedeeClient.GetDataset(ctx, lp.UserAccessToken, lp.CollectionID, lp.Language, dlp.Datasets[i].URI)
+			var d zebedee.Dataset
+			err := budget.critical(ctx, func(ctx context.Context) (err error) {
+				d, err = lp.ZebedeeClient.GetDataset(ctx, lp.UserAccessToken, lp.CollectionID, lp.Language, dlp.Datasets[i].URI)
+				return err
+			})
 			if err != nil {
 				log.Error(ctx, "zebedee client legacy dataset returned an error", err, ld)
 				return errors.Wrap(err, "zebedee client legacy dataset returned an error")
 			}
 
-			d, err = addFileSizesToDataset(ctx, lp.FilesAPIClient, d, lp.UserAccessToken)
-			if err != nil {
-				log.Error(ctx, "failed to get file size from files API", err, ld)
-				return errors.Wrap(err, "failed to get file size from files API")
-			}
+			budget.optional(ctx, partFileSizes, func(ctx context.Context) (err error) {
+				d, err = addFileSizesToDataset(ctx, lp.FilesAPIClient, d, lp.UserAccessToken)
+				return err
+			})
 
 			datasets[i] = d
 			return nil
@@ -139,13 +151,18 @@ func (lp legacyLandingPage) getDatasets(ctx context.Context, dlp zebedee.Dataset
 	return datasets, errs.Wait()
 }
 
+// addFileSizesToDataset sets the size of each download and supplementary file of the dataset from the files API. Any
+// sizes which cannot be found are left empty to be rendered as unavailable, and the first error is returned.
 func addFileSizesToDataset(ctx context.Context, fc clients.FilesAPIClient, d zebedee.Dataset, authToken string) (zebedee.Dataset, error) {
+	var firstErr error
+
 	for i, download := range d.Downloads {
 		if download.URI != "" {
 			md, err := fc.GetFile(ctx, download.URI, authToken)
 			if err != nil {
-				d.Downloads[i].Size = "0"
-				return d, err
+				d.Downloads[i].Size = ""
+				firstErr = cmp.Or(firstErr, err)
+				continue
 			}
 
 			fileSize := strconv.FormatUint(md.SizeInBytes, 10)
@@ -157,7 +174,9 @@ func addFileSizesToDataset(ctx context.Context, fc clients.FilesAPIClient, d zeb
 		if supplementaryFile.URI != "" {
 			md, err := fc.GetFile(ctx, supplementaryFile.URI, authToken)
 			if err != nil {
-				return d, err
+				d.SupplementaryFiles[i].Size = ""
+				firstErr = cmp.Or(firstErr, err)
+				continue
 			}
 
 			fileSize := strconv.FormatUint(md.SizeInBytes, 10)
@@ -165,7 +184,7 @@ func addFileSizesToDataset(ctx context.Context, fc clients.FilesAPIClient, d zeb
 		}
 	}
 
-	return d, nil
+	return d, firstErr
 }
 
 func (lp legacyLandingPage) getDatasetLandingPage(ctx context.Context, path string) (zebedee.DatasetLandingPage, error) {
@@ -180,7 +199,9 @@ func (lp legacyLandingPage) getHomepageContent(ctx context.Context) (zebedee.Hom
 	return lp.ZebedeeClient.GetHomepageContent(ctx, lp.UserAccessToken, lp.CollectionID, lp.Language, homepagePath)
 }
 
-func (lp legacyLandingPage) getRelatedDatasetLinks(ctx context.Context, dlp *zebedee.DatasetLandingPage) {
+// getRelatedDatasetLinks titles the related filterable datasets from the dataset API. Any which cannot be found keep
+// the link from zebedee as a placeholder.
+func (lp legacyLandingPage) getRelatedDatasetLinks(ctx context.Context, budget *dependencyBudget, dlp *zebedee.DatasetLandingPage) {
 	relatedFilterableDatasets := make([]zebedee.Link, len(dlp.RelatedFilterableDatasets))
 	var wg sync.WaitGroup
 
@@ -190,14 +211,16 @@ func (lp legacyLandingPage) getRelatedDatasetLinks(ctx context.Context, dlp *zeb
 		go func(ctx context.Context, i int, dc clients.APIClientsGoDatasetClient, relatedFilterableDataset zebedee.Link) {
 			defer wg.Done()
 
-			d, err := dc.GetByPath(ctx, lp.UserAccessToken, "", lp.CollectionID, relatedFilterableDataset.URI)
-			if err != nil {
-				// log error but continue to map data. any datasets that fail won't get mapped and won't be displayed on frontend
-				log.Error(ctx, "error fetching dataset details", err, log.Data{"dataset": relatedFilterableDataset.URI})
-				return
-			}
+			relatedFilterableDatasets[i] = relatedFilterableDataset
+			budget.optional(ctx, partRelatedDatasets, func(ctx context.Context) error {
+				d, err := dc.GetByPath(ctx, lp.UserAccessToken, "", lp.CollectionID, relatedFilterableDataset.URI)
+				if err != nil {
+					return err
+				}
 
-			relatedFilterableDatasets[i] = zebedee.Link{Title: d.Title, URI: relatedFilterableDataset.URI}
+				relatedFilterableDatasets[i] = zebedee.Link{Title: d.Title, URI: relatedFilterableDataset.URI}
+				return nil
+			})
 		}(ctx, i, lp.DatasetClient, relatedFilterableDataset)
 	}
 
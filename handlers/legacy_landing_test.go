@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/files"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
@@ -98,10 +99,15 @@ func TestLegacyLanding(t *testing.T) {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
 		})
 
-		Convey("test status 500 returned when zebedee client returns error retrieving breadcrumb", func() {
+		Convey("test page rendered without breadcrumb when zebedee client returns error retrieving breadcrumb", func() {
 			dlp := zebedee.DatasetLandingPage{URI: "https://helloworld.com"}
 			mockZebedeeClient.EXPECT().GetDatasetLandingPage(ctx, userAuthToken, collectionID, locale, "/somelegacypage").Return(dlp, nil)
 			mockZebedeeClient.EXPECT().GetBreadcrumb(ctx, userAuthToken, collectionID, locale, dlp.URI).Return(nil, errors.New("something went wrong"))
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "static-legacy")
 
 			w := httptest.NewRecorder()
 			req, err := http.NewRequest("GET", "/somelegacypage", http.NoBody)
@@ -111,9 +117,42 @@ func TestLegacyLanding(t *testing.T) {
 			mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
 			So(err, ShouldBeNil)
 
-			LegacyLanding(mockZebedeeClient, mockDatasetClient, mockFilesAPIClient, nil, mockCacheList, cfg).ServeHTTP(w, req)
+			LegacyLanding(mockZebedeeClient, mockDatasetClient, mockFilesAPIClient, mockRend, mockCacheList, cfg).ServeHTTP(w, req)
 
-			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(degradedPartsHeader), ShouldEqual, partBreadcrumb)
+		})
+
+		Convey("test related filterable datasets keep their zebedee links when the dataset API returns an error", func() {
+			relatedLink := zebedee.Link{Title: "Related dataset", URI: "/datasets/related"}
+			dlp := zebedee.DatasetLandingPage{URI: "https://helloworld.com", RelatedFilterableDatasets: []zebedee.Link{relatedLink}}
+			mockZebedeeClient.EXPECT().GetDatasetLandingPage(ctx, userAuthToken, collectionID, locale, "/somelegacypage").Return(dlp, nil)
+			mockZebedeeClient.EXPECT().GetBreadcrumb(ctx, userAuthToken, collectionID, locale, dlp.URI)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+			mockDatasetClient.EXPECT().GetByPath(ctx, userAuthToken, "", collectionID, relatedLink.URI).Return(dataset.DatasetDetails{}, errors.New("dataset API unavailable"))
+
+			var actualPageModel mapper.StaticDatasetLandingPage
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "static-legacy").Do(func(w io.Writer, pageModel interface{}, templateName string) {
+				actualPageModel = pageModel.(mapper.StaticDatasetLandingPage)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "/somelegacypage", http.NoBody)
+			So(err, ShouldBeNil)
+
+			ctxOther := context.Background()
+			mockCacheList, err := cache.GetMockCacheList(ctxOther, cfg.SupportedLanguages)
+			So(err, ShouldBeNil)
+
+			LegacyLanding(mockZebedeeClient, mockDatasetClient, mockFilesAPIClient, mockRend, mockCacheList, cfg).ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(degradedPartsHeader), ShouldEqual, partRelatedDatasets)
+			So(actualPageModel.DatasetLandingPage.Related.FilterableDatasets, ShouldHaveLength, 1)
+			So(actualPageModel.DatasetLandingPage.Related.FilterableDatasets[0].Title, ShouldEqual, relatedLink.Title)
+			So(actualPageModel.DatasetLandingPage.Related.FilterableDatasets[0].URI, ShouldEqual, relatedLink.URI)
 		})
 	})
 }
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// VersionLint handles requests for the metadata checks of a static dataset version as JSON, for automated QA
+func VersionLint(datasetAPIClient clients.DatasetAPISdkClient, linter *lint.Linter) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, accessToken string) {
+		versionLint(r, w, datasetAPIClient, linter, lang, collectionID, accessToken)
+	})
+}
+
+func versionLint(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, linter *lint.Linter, lang, collectionID, accessToken string) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	datasetID := vars["datasetID"]
+	editionID := vars["editionID"]
+	versionID := vars["versionID"]
+
+	logData := log.Data{
+		"datasetID": datasetID,
+		"editionID": editionID,
+		"versionID": versionID,
+	}
+
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: accessToken, CollectionID: collectionID}
+
+	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	if err != nil {
+		log.Error(ctx, "failed to fetch dataset", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	if dataset.Type != DatasetTypeStatic {
+		log.Error(ctx, "dataset is not of type static", errDatasetTypeNotSupported, logData)
+		setStatusCode(ctx, w, errDatasetTypeNotSupported)
+		return
+	}
+
+	version, err := datasetAPIClient.GetVersionV2(ctx, datasetAPIClientHeaders, datasetID, editionID, versionID)
+	if err != nil {
+		log.Error(ctx, "failed to fetch version", err, logData)
+		setStatusCode(ctx, w, err)
+		return
+	}
+
+	report := mapper.MapLintReport(datasetID, version, linter.Check(dataset, version), lang)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error(ctx, "failed to encode lint report to JSON", err, logData)
+	}
+}
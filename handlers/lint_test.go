@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVersionLint(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	ctx := gomock.Any()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDatasetClient := clients.NewMockDatasetAPISdkClient(ctrl)
+	linter, err := lint.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	datasetID := "static-dataset"
+	editionID := "2025"
+	versionID := "1"
+	headers := datasetAPISDK.Headers{AccessToken: testUserAccessToken, CollectionID: "collection-1"}
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/topic1-slug/datasets/%s/editions/%s/versions/%s/lint", datasetID, editionID, versionID), http.NoBody)
+		return mux.SetURLVars(r, map[string]string{
+			"topic":     "topic1-slug",
+			"datasetID": datasetID,
+			"editionID": editionID,
+			"versionID": versionID,
+		})
+	}
+
+	Convey("Given a static dataset version with gaps in its metadata", t, func() {
+		mockDatasetClient.EXPECT().GetDataset(ctx, headers, datasetID).
+			Return(datasetAPIModels.Dataset{
+				ID:          datasetID,
+				Type:        DatasetTypeStatic,
+				Contacts:    []datasetAPIModels.ContactDetails{{Email: "cpi@ons.gov.uk"}},
+				QMI:         &datasetAPIModels.GeneralDetails{HRef: "/qmi"},
+				NextRelease: "15 April 2026",
+			}, nil)
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, headers, datasetID, editionID, versionID).
+			Return(datasetAPIModels.Version{
+				Edition:       editionID,
+				Version:       1,
+				State:         datasetAPIModels.AssociatedState,
+				UsageNotes:    &[]datasetAPIModels.UsageNote{{Title: "Coverage", Note: "Covers the UK"}},
+				Distributions: &[]datasetAPIModels.Distribution{{Format: datasetAPIModels.DistributionFormatCSV}},
+			}, nil)
+
+		Convey("When its metadata checks are requested", func() {
+			w := httptest.NewRecorder()
+			versionLint(newRequest(), w, mockDatasetClient, linter, "en", "collection-1", testUserAccessToken)
+
+			Convey("Then the failed checks are returned as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+
+				var report map[string]interface{}
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+				So(report, ShouldResemble, map[string]interface{}{
+					"dataset_id": datasetID,
+					"edition":    editionID,
+					"version":    float64(1),
+					"state":      "associated",
+					"passed":     false,
+					"failures": []interface{}{
+						map[string]interface{}{
+							"rule":     "distribution-size",
+							"severity": "error",
+							"subjects": []interface{}{"CSV"},
+							"message":  "Downloads have no file size: CSV",
+						},
+					},
+				})
+			})
+		})
+	})
+
+	Convey("Given a dataset which is not static", t, func() {
+		mockDatasetClient.EXPECT().GetDataset(ctx, headers, datasetID).
+			Return(datasetAPIModels.Dataset{ID: datasetID, Type: "filterable"}, nil)
+
+		Convey("When its metadata checks are requested", func() {
+			w := httptest.NewRecorder()
+			versionLint(newRequest(), w, mockDatasetClient, linter, "en", "collection-1", testUserAccessToken)
+
+			Convey("Then the response status code should be 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+
+	Convey("Given the version cannot be fetched", t, func() {
+		mockDatasetClient.EXPECT().GetDataset(ctx, headers, datasetID).
+			Return(datasetAPIModels.Dataset{ID: datasetID, Type: DatasetTypeStatic}, nil)
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, headers, datasetID, editionID, versionID).
+			Return(datasetAPIModels.Version{}, errors.New("dataset API unavailable"))
+
+		Convey("When its metadata checks are requested", func() {
+			w := httptest.NewRecorder()
+			versionLint(newRequest(), w, mockDatasetClient, linter, "en", "collection-1", testUserAccessToken)
+
+			Convey("Then the response status code should be 500 Internal Server Error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
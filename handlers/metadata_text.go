@@ -6,14 +6,13 @@ import (
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
-	"github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
 // MetadataText generates a metadata text file
 func MetadataText(dc clients.DatasetAPISdkClient, cfg config.Config) http.HandlerFunc {
-	return handlers.ControllerHandler(func(responseWriter http.ResponseWriter, request *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(responseWriter http.ResponseWriter, request *http.Request, lang, collectionID, userAccessToken string) {
 		metadataText(responseWriter, request, dc, cfg, userAccessToken, collectionID)
 	})
 }
@@ -1,32 +1,44 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
-// PostCreateCustomDataset controls creating a custom dataset using a population type
-func PostCreateCustomDataset(fc clients.FilterClient) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		postCreateCustomDataset(w, req, fc, lang, collectionID, userAccessToken)
+// PostCreateCustomDataset controls creating a custom dataset using a population type, or the population type, area
+// type and dimensions confirmed from a custom dataset link
+func PostCreateCustomDataset(fc clients.FilterClient, pc clients.PopulationClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		postCreateCustomDataset(w, req, fc, pc, cfg, lang, collectionID, userAccessToken)
 	})
 }
 
-func postCreateCustomDataset(w http.ResponseWriter, req *http.Request, fc clients.FilterClient, lang, collectionID, userAccessToken string) {
-	ctx := req.Context()
-
+func postCreateCustomDataset(w http.ResponseWriter, req *http.Request, fc clients.FilterClient, pc clients.PopulationClient, cfg config.Config, lang, collectionID, userAccessToken string) {
 	form, err := parseChangeDimensionForm(req)
 	if err != nil {
 		http.Redirect(w, req, "/datasets/create?error=true", http.StatusMovedPermanently)
 		return
 	}
 
-	filterID, err := fc.CreateCustomFilter(ctx, userAccessToken, "", form.PopulationType)
+	if form.AreaType != "" || len(form.Dimensions) > 0 {
+		createCustomDatasetFromSelection(w, req, pc, fc, cfg, collectionID, userAccessToken, form)
+		return
+	}
+
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+
+	var filterID string
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		filterID, err = fc.CreateCustomFilter(ctx, userAccessToken, "", form.PopulationType)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to create new custom filter", err, log.Data{
 			"population-type": form.PopulationType,
@@ -38,24 +50,17 @@ func postCreateCustomDataset(w http.ResponseWriter, req *http.Request, fc client
 	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
 }
 
-// createCustomDatasetForm represents form-data for the PostCreateCustomDataset handler.
-type postCreateCustomDatasetForm struct {
-	PopulationType string
-}
-
-// parseChangeDimensionForm parses form data from a http.Request into a changeDimensionForm.
-func parseChangeDimensionForm(req *http.Request) (postCreateCustomDatasetForm, error) {
+// parseChangeDimensionForm parses form data from a http.Request into a customDatasetSelection.
+func parseChangeDimensionForm(req *http.Request) (customDatasetSelection, error) {
 	err := req.ParseForm()
 	if err != nil {
-		return postCreateCustomDatasetForm{}, fmt.Errorf("error parsing form: %w", err)
+		return customDatasetSelection{}, fmt.Errorf("error parsing form: %w", err)
 	}
 
-	pop := req.FormValue("populationType")
-	if pop == "" {
-		return postCreateCustomDatasetForm{}, errors.New("missing required value 'populationType'")
+	selection := parseCustomDatasetSelection(req.PostForm)
+	if selection.PopulationType == "" {
+		return customDatasetSelection{}, errors.New("missing required value 'populationType'")
 	}
 
-	return postCreateCustomDatasetForm{
-		PopulationType: pop,
-	}, nil
+	return selection, nil
 }
@@ -9,7 +9,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
@@ -19,6 +22,7 @@ func TestPostCreateCustomDataset(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
 
 	Convey("test PostCreateCustomDataset", t, func() {
 		Convey("happy path creates a filter id and redirects to filter page", func() {
@@ -42,7 +46,7 @@ func TestPostCreateCustomDataset(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc))
+			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc, clients.NewMockPopulationClient(mockCtrl), cfg))
 			router.ServeHTTP(w, req)
 
 			// THEN - we are rerouted to the filter review page (without the filter client being called)
@@ -53,6 +57,91 @@ func TestPostCreateCustomDataset(t *testing.T) {
 			So(location, ShouldEqual, "/filters/12345/dimensions")
 		})
 
+		Convey("confirmed selections from a custom dataset link create a custom filter and redirect to its page", func() {
+			// GIVEN - a valid request with the selections of a custom dataset link
+			formData := url.Values{}
+			formData.Add("populationType", "UR")
+			formData.Add("area_type", "ltla")
+			formData.Add("dimensions", "sex,age_6a")
+			encodedFormData := formData.Encode()
+
+			req := httptest.NewRequest(http.MethodPost, "/datasets/create", strings.NewReader(encodedFormData))
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+
+			// AND - a population client mocked to describe the selections
+			mockPc := clients.NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(population.GetPopulationTypesResponse{
+				Items: []population.PopulationType{{Name: "UR"}},
+			}, nil)
+			mockPc.EXPECT().GetDimensionsDescription(ctx, gomock.Any()).Return(population.GetDimensionsResponse{
+				Dimensions: []population.Dimension{{ID: "ltla"}, {ID: "sex"}, {ID: "age_6a"}},
+			}, nil)
+			mockPc.EXPECT().GetCategorisations(ctx, gomock.Any()).DoAndReturn(func(_ interface{}, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+				return population.GetCategorisationsResponse{Items: []population.Dimension{{ID: input.Dimension}}}, nil
+			}).Times(2)
+			mockPc.EXPECT().GetPopulationTypeMetadata(ctx, gomock.Any()).Return(population.GetPopulationTypeMetadataResponse{
+				PopulationType:   "UR",
+				DefaultDatasetID: "TS009",
+				Edition:          "2021",
+				Version:          1,
+			}, nil)
+
+			// AND - a filter client mocked to return a happy response
+			mockFc := clients.NewMockFilterClient(mockCtrl)
+			mockFc.
+				EXPECT().
+				CreateFlexibleBlueprintCustom(ctx, gomock.Any(), "", "", filter.CreateFlexBlueprintCustomRequest{
+					Dataset: filter.Dataset{DatasetID: "TS009", Edition: "2021", Version: 1},
+					Dimensions: []filter.ModelDimension{
+						{Name: "ltla", IsAreaType: helpers.ToBoolPtr(true)},
+						{Name: "sex", IsAreaType: helpers.ToBoolPtr(false)},
+						{Name: "age_6a", IsAreaType: helpers.ToBoolPtr(false)},
+					},
+					PopulationType: "UR",
+				}).
+				Return("12345", "", nil)
+
+			// WHEN - we post the request
+			w := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc, mockPc, cfg))
+			router.ServeHTTP(w, req)
+
+			// THEN - we are rerouted to the filter page
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions")
+		})
+
+		Convey("confirmed selections which are no longer valid redirect to page with error=true", func() {
+			// GIVEN - a request with a population type which does not exist
+			formData := url.Values{}
+			formData.Add("populationType", "XX")
+			formData.Add("area_type", "ltla")
+			encodedFormData := formData.Encode()
+
+			req := httptest.NewRequest(http.MethodPost, "/datasets/create", strings.NewReader(encodedFormData))
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+
+			mockPc := clients.NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetPopulationTypes(ctx, gomock.Any()).Return(population.GetPopulationTypesResponse{
+				Items: []population.PopulationType{{Name: "UR"}},
+			}, nil)
+
+			// WHEN - we post the request
+			w := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/create", PostCreateCustomDataset(clients.NewMockFilterClient(mockCtrl), mockPc, cfg))
+			router.ServeHTTP(w, req)
+
+			// THEN - we are rerouted to the create page with an error and no filter is created
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(w.Header().Get("Location"), ShouldEqual, "/datasets/create?error=true")
+		})
+
 		Convey("redirects to page with error=true if form data invalid", func() {
 			// GIVEN - a form where the populationType field is missing
 			formData := url.Values{}
@@ -73,7 +162,7 @@ func TestPostCreateCustomDataset(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc))
+			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc, clients.NewMockPopulationClient(mockCtrl), cfg))
 			router.ServeHTTP(w, req)
 
 			// THEN - we are rerouted to the filter review page
@@ -103,7 +192,7 @@ func TestPostCreateCustomDataset(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc))
+			router.HandleFunc("/datasets/create", PostCreateCustomDataset(mockFc, clients.NewMockPopulationClient(mockCtrl), cfg))
 			router.ServeHTTP(w, req)
 
 			// THEN - the client should not be redirected
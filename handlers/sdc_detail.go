@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// FilterOutputSDC will load the statistical disclosure control detail page of a multivariate filter output, showing
+// the blocked areas of each area type and how changes to the dimensions would affect them. The blocked area counts
+// are cached by filter output, so viewing the page again does not request them again.
+func FilterOutputSDC(zc clients.ZebedeeClient, fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, rend clients.RenderClient, cacheList *cache.List, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		var blockedAreaCounts *cache.BlockedAreaCountCache
+		if cacheList != nil {
+			blockedAreaCounts = cacheList.BlockedAreaCounts
+		}
+		filterOutputSDC(w, req, zc, fc, pc, dc, rend, blockedAreaCounts, cfg, collectionID, lang, userAccessToken)
+	})
+}
+
+func filterOutputSDC(w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient, fc clients.FilterClient, pc clients.PopulationClient, dc clients.DatasetAPISdkClient, rend clients.RenderClient,
+	blockedAreaCounts *cache.BlockedAreaCountCache, cfg config.Config, collectionID, lang, userAccessToken string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+	vars := mux.Vars(req)
+	filterOutputID := vars["filterOutputID"]
+	logData := log.Data{"filter_output_id": filterOutputID}
+
+	var filterOutput filter.Model
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		filterOutput, err = fc.GetOutput(ctx, userAccessToken, "", "", collectionID, filterOutputID)
+		return err
+	})
+	if logError(ctx, w, err, "failed to get filter output", logData) {
+		return
+	}
+
+	datasetID := vars["datasetID"]
+	if datasetID == "" {
+		datasetID = filterOutput.Dataset.DatasetID
+	}
+	var datasetModel dpDatasetApiModels.Dataset
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetModel, err = dc.GetDataset(ctx, dpDatasetApiSdk.Headers{CollectionID: collectionID, AccessToken: userAccessToken}, datasetID)
+		return err
+	})
+	if logError(ctx, w, err, "failed to get dataset", log.Data{"dataset": datasetID}) {
+		return
+	}
+
+	if !strings.Contains(datasetModel.Type, "multivariate") {
+		log.Error(ctx, "statistical disclosure control is only available for multivariate datasets", errDatasetTypeNotSupported, logData)
+		setStatusCode(ctx, w, errDatasetTypeNotSupported)
+		return
+	}
+
+	var areaOpts []string
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		areaOpts, err = getFilterOutputAreaOptions(ctx, fc, collectionID, userAccessToken, filterOutput)
+		return err
+	})
+	if logError(ctx, w, err, "failed to get filter output areas", logData) {
+		return
+	}
+
+	var sdc *cantabular.GetBlockedAreaCountResult
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		sdc, err = blockedAreaCounts.Load(ctx, filterOutputID, func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+			return pc.GetBlockedAreaCount(ctx, getBlockedAreaCountInput(userAccessToken, filterOutput, areaOpts))
+		})
+		return err
+	})
+	if logError(ctx, w, err, "failed to get blocked area count", logData) {
+		return
+	}
+
+	var areaTypes population.GetAreaTypesResponse
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		areaTypes, err = pc.GetAreaTypes(ctx, population.GetAreaTypesInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: userAccessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+			PopulationType: filterOutput.PopulationType,
+		})
+		return err
+	})
+	if logError(ctx, w, err, "failed to get area types", log.Data{"population_type": filterOutput.PopulationType}) {
+		return
+	}
+
+	areaTypeSDC, err := getAreaTypeSDC(ctx, budget, pc, blockedAreaCounts, userAccessToken, filterOutputID, filterOutput, areaTypes.AreaTypes)
+	if logError(ctx, w, err, "failed to get blocked area counts for area types", logData) {
+		return
+	}
+
+	blockedWithout, categorisationCounts, err := getDimensionSDC(ctx, budget, pc, blockedAreaCounts, userAccessToken, filterOutputID, filterOutput, areaOpts)
+	if logError(ctx, w, err, "failed to get blocked area counts for dimensions", logData) {
+		return
+	}
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	csrfToken, err := csrf.Token(w, req)
+	if logError(ctx, w, err, "failed to create csrf token", logData) {
+		return
+	}
+
+	basePage := rend.NewBasePageModel()
+	m := mapper.CreateSDCDetailPage(basePage, req, lang, datasetModel, filterOutput, *sdc, areaTypes.AreaTypes, areaTypeSDC, blockedWithout, categorisationCounts,
+		homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	m.CSRFToken = csrfToken
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
+	rend.BuildPage(w, m, "sdc-detail")
+}
+
+// getAreaTypeSDC returns the statistical disclosure control result for every area of each area type, keyed by area
+// type ID, if the filter output's area type was changed to that area type. Each result is cached under the filter
+// output ID followed by the area type.
+func getAreaTypeSDC(ctx context.Context, budget *dependencyBudget, pc clients.PopulationClient, blockedAreaCounts *cache.BlockedAreaCountCache, userAccessToken, filterOutputID string,
+	filterOutput filter.Model, areaTypes []population.AreaType) (map[string]cantabular.GetBlockedAreaCountResult, error) {
+	var mutex sync.Mutex
+	results := make(map[string]cantabular.GetBlockedAreaCountResult, len(areaTypes))
+
+	err := forEachAreaType(ctx, len(areaTypes), func(ctx context.Context, i int) error {
+		areaTypeID := areaTypes[i].ID
+		var sdc *cantabular.GetBlockedAreaCountResult
+		err := budget.critical(ctx, func(ctx context.Context) (err error) {
+			sdc, err = blockedAreaCounts.Load(ctx, filterOutputID+"/area-types/"+areaTypeID, func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+				return pc.GetBlockedAreaCount(ctx, getBlockedAreaCountInput(userAccessToken, withAreaType(filterOutput, areaTypeID), nil))
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		results[areaTypeID] = *sdc
+		mutex.Unlock()
+		return nil
+	})
+
+	return results, err
+}
+
+// getDimensionSDC returns, keyed by dimension name, the number of the selected areas which would be blocked if each
+// dimension was removed from the filter output, along with the number of categorisations of each dimension. Each
+// blocked area count is cached under the filter output ID followed by the dimension removed.
+func getDimensionSDC(ctx context.Context, budget *dependencyBudget, pc clients.PopulationClient, blockedAreaCounts *cache.BlockedAreaCountCache, userAccessToken, filterOutputID string,
+	filterOutput filter.Model, areaOpts []string) (blockedWithout, categorisationCounts map[string]int, err error) {
+	var dims []filter.ModelDimension
+	for i := range filterOutput.Dimensions {
+		if !helpers.IsBoolPtr(filterOutput.Dimensions[i].IsAreaType) {
+			dims = append(dims, filterOutput.Dimensions[i])
+		}
+	}
+
+	var mutex sync.Mutex
+	blockedWithout = make(map[string]int, len(dims))
+	categorisationCounts = make(map[string]int, len(dims))
+
+	err = forEachDimension(ctx, len(dims), func(ctx context.Context, i int) error {
+		name := dims[i].Name
+		var sdc *cantabular.GetBlockedAreaCountResult
+		err := budget.critical(ctx, func(ctx context.Context) (err error) {
+			sdc, err = blockedAreaCounts.Load(ctx, filterOutputID+"/without/"+name, func(ctx context.Context) (*cantabular.GetBlockedAreaCountResult, error) {
+				return pc.GetBlockedAreaCount(ctx, getBlockedAreaCountInput(userAccessToken, withoutDimension(filterOutput, name), areaOpts))
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			count, err = getCategorisationCount(ctx, pc, userAccessToken, filterOutput.PopulationType, name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		blockedWithout[name] = sdc.Blocked
+		categorisationCounts[name] = count
+		mutex.Unlock()
+		return nil
+	})
+
+	return blockedWithout, categorisationCounts, err
+}
+
+// withAreaType returns a copy of the filter output with its area type dimension replaced by the given area type
+func withAreaType(filterOutput filter.Model, areaTypeID string) filter.Model {
+	filterOutput.Dimensions = slices.Clone(filterOutput.Dimensions)
+	for i := range filterOutput.Dimensions {
+		dimension := &filterOutput.Dimensions[i]
+		if helpers.IsBoolPtr(dimension.IsAreaType) {
+			dimension.ID = areaTypeID
+			dimension.Name = areaTypeID
+			dimension.FilterByParent = ""
+		}
+	}
+	return filterOutput
+}
+
+// withoutDimension returns a copy of the filter output without the named dimension
+func withoutDimension(filterOutput filter.Model, name string) filter.Model {
+	filterOutput.Dimensions = slices.DeleteFunc(slices.Clone(filterOutput.Dimensions), func(dimension filter.ModelDimension) bool {
+		return dimension.Name == name
+	})
+	return filterOutput
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/sdcdetail"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilterOutputSDC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	const sdcPath = "/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/sdc"
+	authTokens := population.AuthTokens{UserAuthToken: userAuthToken}
+	nationalCoverage := population.Filter{Codes: []string{"K04000001"}, Variable: "nat"}
+
+	filterOutput := filter.Model{
+		FilterID:       "filter-1",
+		PopulationType: "UR",
+		Dimensions: []filter.ModelDimension{
+			{Name: "ltla", ID: "ltla", IsAreaType: toBoolPtr(true)},
+			{Name: "sex", ID: "sex", IsAreaType: toBoolPtr(false)},
+		},
+	}
+
+	Convey("Given a multivariate filter output with blocked areas", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(ctx, userAuthToken, "", "", collectionID, "67890").Return(filterOutput, nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, userAuthToken, "", collectionID, "filter-1", "ltla", &filter.QueryParams{Limit: 500}).
+			Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "E06000001"}}}, "", nil)
+
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(ctx, gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Title: "Census", Type: "cantabular_multivariate_table"}, nil)
+
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetBlockedAreaCount(ctx, population.GetBlockedAreaCountInput{
+			AuthTokens:     authTokens,
+			PopulationType: "UR",
+			Variables:      []string{"ltla", "sex"},
+			Filter:         population.Filter{Codes: []string{"E06000001"}, Variable: "ltla"},
+		}).Return(&cantabular.GetBlockedAreaCountResult{Passed: 0, Blocked: 1, Total: 1}, nil)
+		mockPc.EXPECT().GetAreaTypes(ctx, population.GetAreaTypesInput{
+			AuthTokens:       authTokens,
+			PaginationParams: population.PaginationParams{Limit: 1000},
+			PopulationType:   "UR",
+		}).Return(population.GetAreaTypesResponse{AreaTypes: []population.AreaType{
+			{ID: "ctry", Label: "Countries"},
+			{ID: "ltla", Label: "Lower tier local authorities"},
+		}}, nil)
+		mockPc.EXPECT().GetBlockedAreaCount(ctx, population.GetBlockedAreaCountInput{
+			AuthTokens:     authTokens,
+			PopulationType: "UR",
+			Variables:      []string{"ctry", "sex"},
+			Filter:         nationalCoverage,
+		}).Return(&cantabular.GetBlockedAreaCountResult{Passed: 2, Blocked: 0, Total: 2}, nil)
+		mockPc.EXPECT().GetBlockedAreaCount(ctx, population.GetBlockedAreaCountInput{
+			AuthTokens:     authTokens,
+			PopulationType: "UR",
+			Variables:      []string{"ltla", "sex"},
+			Filter:         nationalCoverage,
+		}).Return(&cantabular.GetBlockedAreaCountResult{Passed: 300, Blocked: 31, Total: 331}, nil)
+		mockPc.EXPECT().GetBlockedAreaCount(ctx, population.GetBlockedAreaCountInput{
+			AuthTokens:     authTokens,
+			PopulationType: "UR",
+			Variables:      []string{"ltla"},
+			Filter:         population.Filter{Codes: []string{"E06000001"}, Variable: "ltla"},
+		}).Return(&cantabular.GetBlockedAreaCountResult{Passed: 1, Blocked: 0, Total: 1}, nil)
+		mockPc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{
+			PaginationResponse: population.PaginationResponse{TotalCount: 2},
+		}, nil)
+
+		mockZc := clients.NewMockZebedeeClient(mockCtrl)
+		mockZc.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+
+		var page sdcdetail.Page
+		mockRend := clients.NewMockRenderClient(mockCtrl)
+		mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "sdc-detail").Do(func(w io.Writer, pageModel interface{}, templateName string) {
+			page = pageModel.(sdcdetail.Page)
+		})
+
+		Convey("When the SDC detail page is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/sdc", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(sdcPath, FilterOutputSDC(mockZc, mockFc, mockPc, mockDc, mockRend, &cache.List{}, cfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the counts of each area type and the effect of removing each dimension are shown", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(page.Data.AreaTypes, ShouldResemble, []sdcdetail.AreaTypeCount{
+					{ID: "ctry", Label: "Countries", Passed: 2, Blocked: 0, Total: 2},
+					{ID: "ltla", Label: "Lower tier local authorities", Passed: 300, Blocked: 31, Total: 331, IsSelected: true},
+				})
+				So(page.Data.Suggestions, ShouldResemble, []sdcdetail.Suggestion{
+					{DimensionName: "sex", DimensionTitle: "sex", BlockedWithout: 0, CategorisationCount: 2},
+				})
+			})
+		})
+	})
+
+	Convey("Given a multivariate filter output whose blocked area counts have been cached", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(ctx, userAuthToken, "", "", collectionID, "67890").Return(filterOutput, nil).Times(2)
+		mockFc.EXPECT().GetDimensionOptions(ctx, userAuthToken, "", collectionID, "filter-1", "ltla", &filter.QueryParams{Limit: 500}).
+			Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "E06000001"}}}, "", nil).Times(2)
+
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(ctx, gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Title: "Census", Type: "cantabular_multivariate_table"}, nil).Times(2)
+
+		mockPc := clients.NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(population.GetAreaTypesResponse{AreaTypes: []population.AreaType{
+			{ID: "ctry", Label: "Countries"},
+			{ID: "ltla", Label: "Lower tier local authorities"},
+		}}, nil).Times(2)
+		mockPc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{
+			PaginationResponse: population.PaginationResponse{TotalCount: 2},
+		}, nil).Times(2)
+		// once each for the filter output itself, both area types and the filter output without sex, so requesting them
+		// again fails the test
+		mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 1, Blocked: 0, Total: 1}, nil).Times(4)
+
+		mockZc := clients.NewMockZebedeeClient(mockCtrl)
+		mockZc.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/").Times(2)
+
+		mockRend := clients.NewMockRenderClient(mockCtrl)
+		mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain)).Times(2)
+		mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "sdc-detail").Times(2)
+
+		router := mux.NewRouter()
+		cacheList := &cache.List{BlockedAreaCounts: cache.NewBlockedAreaCountCache(time.Minute, 100, 0)}
+		router.HandleFunc(sdcPath, FilterOutputSDC(mockZc, mockFc, mockPc, mockDc, mockRend, cacheList, cfg))
+
+		Convey("When the SDC detail page is requested twice", func() {
+			first := httptest.NewRecorder()
+			router.ServeHTTP(first, httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/sdc", http.NoBody))
+			second := httptest.NewRecorder()
+			router.ServeHTTP(second, httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/sdc", http.NoBody))
+
+			Convey("Then the second view is shown from the cached blocked area counts", func() {
+				So(first.Code, ShouldEqual, http.StatusOK)
+				So(second.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given a filter output of a dataset which is not multivariate", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetOutput(ctx, userAuthToken, "", "", collectionID, "67890").Return(filterOutput, nil)
+
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(ctx, gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Type: "cantabular_flexible_table"}, nil)
+
+		Convey("When the SDC detail page is requested", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/sdc", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc(sdcPath, FilterOutputSDC(nil, mockFc, nil, mockDc, nil, &cache.List{}, cfg))
+			router.ServeHTTP(w, req)
+
+			Convey("Then the status code is 404", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
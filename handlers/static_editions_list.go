@@ -1,27 +1,32 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
 	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	dpHandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // StaticEditionsList handles requests for the editions list page of static datasets
 func StaticEditionsList(datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, apiRouterVersion string) http.HandlerFunc {
-	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, userAccessToken string) {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, userAccessToken string) {
 		staticEditionsList(r, w, datasetAPIClient, renderClient, zebedeeClient, topicAPIClient, cfg, apiRouterVersion, userAccessToken, lang, collectionID)
 	})
 }
 
 func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, apiRouterVersion, userAccessToken, lang, collectionID string) {
-	ctx := r.Context()
+	budget, ctx, cancel := newDependencyBudget(r.Context(), cfg)
+	defer cancel()
 
 	vars := mux.Vars(r)
 	topicSlug := vars["topic"]
@@ -34,9 +39,13 @@ func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient
 		"editionID": editionID,
 	}
 
-	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: userAccessToken}
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: userAccessToken, CollectionID: collectionID}
 
-	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	var dataset datasetAPIModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch dataset", err, logData)
 		setStatusCode(ctx, w, err)
@@ -49,7 +58,11 @@ func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient
 		return
 	}
 
-	topicList, err := clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, userAccessToken)
+	var topicList []*topicModel.Topic
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		topicList, err = clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, userAccessToken)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch topics", err, logData)
 		setStatusCode(ctx, w, err)
@@ -64,17 +77,13 @@ func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient
 
 	expectedTopicSlug := topicList[0].Slug
 
-	// If the URL topic slug doesn't match the dataset's primary topic slug, redirect to the expected one
-	if expectedTopicSlug != topicSlug {
+	// If the URL topic slug doesn't match any of the dataset's topic slugs, permanently redirect to the primary (canonical) one
+	if !helpers.HasStringInSlice(topicSlug, helpers.ExtractTopicSlugs(topicList)) {
 		logData["providedTopicSlug"] = topicSlug
 		logData["expectedTopicSlug"] = expectedTopicSlug
-		log.Info(ctx, "incorrect topic slug provided, redirecting to correct topic", logData)
-
-		// Reconstruct the request path with the expected topic slug
-		redirectPath := helpers.ReplaceFirstPathSegment(r.URL.Path, expectedTopicSlug)
+		log.Info(ctx, "incorrect topic slug provided, redirecting to canonical topic", logData)
 
-		//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
-		http.Redirect(w, r, redirectPath, http.StatusFound)
+		redirectToCanonicalTopic(w, r, expectedTopicSlug)
 		return
 	}
 
@@ -93,7 +102,11 @@ func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient
 		return
 	}
 
-	editions, err := datasetAPIClient.GetEditions(ctx, datasetAPIClientHeaders, datasetID, &datasetAPISDK.QueryParams{Limit: 1000})
+	var editions datasetAPISDK.EditionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		editions, err = datasetAPIClient.GetEditions(ctx, datasetAPIClientHeaders, datasetID, &datasetAPISDK.QueryParams{Limit: 1000})
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch editions list", err, logData)
 		setStatusCode(ctx, w, err)
@@ -115,15 +128,18 @@ func staticEditionsList(r *http.Request, w http.ResponseWriter, datasetAPIClient
 	}
 
 	// Fetch homepage content
-	homepageContent, err := zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		logData["homepageContentError"] = err
-		log.Warn(ctx, "failed to get homepage content", logData)
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
 	// Build and render the page
 	basePage := renderClient.NewBasePageModel()
 	mapper.UpdateBasePage(&basePage, dataset, homepageContent, false, lang, r)
-	pageModel := mapper.CreateEditionsListForStaticDatasetType(ctx, basePage, r, dataset, editions, datasetID, apiRouterVersion, topicList)
+	pageModel := mapper.CreateEditionsListForStaticDatasetType(ctx, basePage, r, dataset, editions, datasetID, apiRouterVersion, topicList, topicSlug)
+	pageModel.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, "")
+	budget.setHeader(w)
+	pageModel.CSPNonce = security.Nonce(ctx)
 	renderClient.BuildPage(w, pageModel, templateNameStaticEditionsList)
 }
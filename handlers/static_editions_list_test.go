@@ -181,7 +181,7 @@ func TestStaticEditionsList(t *testing.T) {
 		})
 	})
 
-	Convey("When the first topic in the dataset does not match the topic in the URL", t, func() {
+	Convey("When none of the topics in the dataset match the topic in the URL", t, func() {
 		mockDatasetClient.EXPECT().GetDataset(ctx, testUserDatasetSDKHeaders, datasetID).
 			Return(dataset, nil)
 
@@ -199,8 +199,8 @@ func TestStaticEditionsList(t *testing.T) {
 
 		staticEditionsList(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, apiRouterVersion, testUserAccessToken, lang, collectionID)
 
-		Convey("Then the response status code should be 302 Found and redirect to correct topic", func() {
-			So(w.Code, ShouldEqual, http.StatusFound)
+		Convey("Then the response status code should be 301 Moved Permanently and redirect to the canonical topic", func() {
+			So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 			So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/%s/datasets/%s", testTopic1.Slug, datasetID))
 		})
 	})
@@ -1,29 +1,38 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
-	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
 	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/diff"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
-	dpHandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
+	topicModel "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // StaticLanding handles requests for the landing page of static datasets
-func StaticLanding(datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, authMiddleware authorisation.Middleware) http.HandlerFunc {
-	return dpHandlers.ControllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, userAccessToken string) {
-		staticLanding(r, w, datasetAPIClient, renderClient, zebedeeClient, topicAPIClient, cfg, authMiddleware, userAccessToken, lang, collectionID)
+func StaticLanding(datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, permissionsChecker *permissions.Checker, linter *lint.Linter) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, r *http.Request, lang, collectionID, userAccessToken string) {
+		staticLanding(r, w, datasetAPIClient, renderClient, zebedeeClient, topicAPIClient, cfg, permissionsChecker, linter, userAccessToken, lang, collectionID)
 	})
 }
 
-func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, authMiddleware authorisation.Middleware, userAccessToken, lang, collectionID string) {
-	ctx := r.Context()
+func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clients.DatasetAPISdkClient, renderClient clients.RenderClient, zebedeeClient clients.ZebedeeClient, topicAPIClient clients.TopicAPIClient, cfg config.Config, permissionsChecker *permissions.Checker, linter *lint.Linter, userAccessToken, lang, collectionID string) {
+	budget, ctx, cancel := newDependencyBudget(r.Context(), cfg)
+	defer cancel()
 
 	vars := mux.Vars(r)
 	topicSlug := vars["topic"]
@@ -41,9 +50,13 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 		"versionID": versionID,
 	}
 
-	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: userAccessToken}
+	datasetAPIClientHeaders := datasetAPISDK.Headers{AccessToken: userAccessToken, CollectionID: collectionID}
 
-	dataset, err := datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+	var dataset datasetAPIModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = datasetAPIClient.GetDataset(ctx, datasetAPIClientHeaders, datasetID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch dataset", err, logData)
 		setStatusCode(ctx, w, err)
@@ -56,7 +69,11 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 		return
 	}
 
-	topicList, err := clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, userAccessToken)
+	var topicList []*topicModel.Topic
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		topicList, err = clients.FetchTopics(ctx, topicAPIClient, dataset.Topics, cfg.IsPublishing, userAccessToken)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch topics", err, logData)
 		setStatusCode(ctx, w, err)
@@ -71,17 +88,13 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 
 	expectedTopicSlug := topicList[0].Slug
 
-	// If the URL topic slug doesn't match the dataset's primary topic slug, redirect to the expected one
-	if expectedTopicSlug != topicSlug {
+	// If the URL topic slug doesn't match any of the dataset's topic slugs, permanently redirect to the primary (canonical) one
+	if !helpers.HasStringInSlice(topicSlug, helpers.ExtractTopicSlugs(topicList)) {
 		logData["providedTopicSlug"] = topicSlug
 		logData["expectedTopicSlug"] = expectedTopicSlug
-		log.Info(ctx, "incorrect topic slug provided, redirecting to correct topic", logData)
-
-		// Reconstruct the request path with the expected topic slug
-		redirectPath := helpers.ReplaceFirstPathSegment(r.URL.Path, expectedTopicSlug)
+		log.Info(ctx, "incorrect topic slug provided, redirecting to canonical topic", logData)
 
-		//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
-		http.Redirect(w, r, redirectPath, http.StatusFound)
+		redirectToCanonicalTopic(w, r, expectedTopicSlug)
 		return
 	}
 
@@ -101,7 +114,11 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 		return
 	}
 
-	version, err := datasetAPIClient.GetVersionV2(ctx, datasetAPIClientHeaders, datasetID, editionID, versionID)
+	var version datasetAPIModels.Version
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		version, err = datasetAPIClient.GetVersionV2(ctx, datasetAPIClientHeaders, datasetID, editionID, versionID)
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch version", err, logData)
 		setStatusCode(ctx, w, err)
@@ -127,18 +144,23 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 		}
 	}
 
-	// enable approval button if user has permissions and environment is publishing
-	var enableApprovalButton bool
+	// offer the state transitions the user has permission to make when the environment is publishing
+	var transitions []workflow.Transition
 	if cfg.IsPublishing {
-		enableApprovalButton, err = permissions.CheckIsAdmin(ctx, userAccessToken, authMiddleware)
+		datasetPermissions := permissionsChecker.ForRequest(userAccessToken).ForDataset(datasetID)
+		transitions, err = workflow.Available(ctx, version.State, datasetPermissions)
 		if err != nil {
-			log.Error(ctx, "error checking user permissions for approval button", err, logData)
+			log.Error(ctx, "error checking user permissions for version state transitions", err, logData)
 			setStatusCode(ctx, w, err)
 			return
 		}
 	}
 
-	fullVersionsList, err := datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000})
+	var fullVersionsList datasetAPISDK.VersionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		fullVersionsList, err = datasetAPIClient.GetVersions(ctx, datasetAPIClientHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000})
+		return err
+	})
 	if err != nil {
 		log.Error(ctx, "failed to fetch versions list", err, logData)
 		setStatusCode(ctx, w, err)
@@ -146,15 +168,44 @@ func staticLanding(r *http.Request, w http.ResponseWriter, datasetAPIClient clie
 	}
 
 	// Fetch homepage content
-	homepageContent, err := zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		logData["homepageContentError"] = err
-		log.Warn(ctx, "failed to get homepage content", logData)
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zebedeeClient.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
 	// Build and render the page
 	basePage := renderClient.NewBasePageModel()
 	mapper.UpdateBasePage(&basePage, dataset, homepageContent, isValidationError, lang, r)
-	pageModel := mapper.CreateStaticOverviewPage(ctx, basePage, dataset, version, fullVersionsList.Items, cfg.EnableMultivariate, topicList, cfg.IsPublishing, enableApprovalButton)
+	pageModel := mapper.CreateStaticOverviewPage(ctx, basePage, dataset, version, fullVersionsList.Items, cfg.EnableMultivariate, topicList, topicSlug, cfg.IsPublishing, collectionID, transitions)
+
+	// show publishers the gaps in the version's metadata before it is published
+	if cfg.IsPublishing {
+		publishingPanels := mapper.MapLintPanels(linter.Check(dataset, version), lang)
+
+		// and how it differs from the version already published
+		if showVersionDiff(cfg, collectionID, version) {
+			var publishedVersion int
+			var changes []diff.Change
+			if budget.optional(ctx, partVersionDiff, func(ctx context.Context) (err error) {
+				publishedVersion, changes, err = publishedVersionDiff(ctx, datasetAPIClient, userAccessToken, datasetID, editionID, dataset, version)
+				return err
+			}) {
+				publishingPanels = append(publishingPanels, mapper.MapVersionDiffPanel(publishedVersion, changes, lang))
+			}
+		}
+
+		pageModel.DatasetLandingPage.Panels = append(publishingPanels, pageModel.DatasetLandingPage.Panels...)
+	}
+
+	// show the result of a state transition which redirected back to this page
+	if message, ok := flash.Pop(w, r); ok {
+		if panel, ok := mapper.MapFlashPanel(message, lang); ok {
+			pageModel.DatasetLandingPage.Panels = append([]static.Panel{panel}, pageModel.DatasetLandingPage.Panels...)
+		}
+	}
+
+	budget.setHeader(w)
+	pageModel.CSPNonce = security.Nonce(ctx)
 	renderClient.BuildPage(w, pageModel, templateNameStatic)
 }
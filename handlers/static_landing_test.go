@@ -3,17 +3,23 @@ package handlers
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ONSdigital/dis-design-system-go/helper"
 	core "github.com/ONSdigital/dis-design-system-go/model"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
-	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
 	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
-	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions/permissionstest"
 	topicAPIModels "github.com/ONSdigital/dp-topic-api/models"
 	topicAPISDK "github.com/ONSdigital/dp-topic-api/sdk"
 	topicAPISDKErrors "github.com/ONSdigital/dp-topic-api/sdk/errors"
@@ -24,8 +30,8 @@ import (
 )
 
 var (
-	testUserAccessToken  = "user-token"
-	testAdminAccessToken = "admin-token"
+	testUserAccessToken  = mustNewTestToken("publisher", "role-publisher")
+	testAdminAccessToken = mustNewTestToken("admin", "role-admin")
 
 	testAdminDatasetSDKHeaders = datasetAPISDK.Headers{AccessToken: testAdminAccessToken}
 	testUserDatasetSDKHeaders  = datasetAPISDK.Headers{AccessToken: testUserAccessToken}
@@ -46,18 +52,10 @@ func TestStaticLanding(t *testing.T) {
 	mockRenderClient := clients.NewMockRenderClient(ctrl)
 	mockZebedeeClient := clients.NewMockZebedeeClient(ctrl)
 	mockTopicAPIClient := clients.NewMockTopicAPIClient(ctrl)
-	mockAuthMiddleware := &authMock.MiddlewareMock{
-		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
-			switch token {
-			case testAdminAccessToken:
-				return &permissionsAPISDK.EntityData{
-					Groups: []string{"role-admin"},
-				}, nil
-			case testUserAccessToken:
-				return &permissionsAPISDK.EntityData{}, nil
-			}
-			return nil, errors.New("failed to parse token")
-		},
+	permissionsChecker := newTestPermissionsChecker()
+	linter, err := lint.New(nil)
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	datasetID := "static-dataset"
@@ -116,7 +114,7 @@ func TestStaticLanding(t *testing.T) {
 				"editionID": editionID,
 				"versionID": versionID,
 			})
-			staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testAdminAccessToken, lang, collectionID)
+			staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testAdminAccessToken, lang, collectionID)
 
 			Convey("Then the response status code should be 200 OK", func() {
 				So(w.Code, ShouldEqual, http.StatusOK)
@@ -124,6 +122,185 @@ func TestStaticLanding(t *testing.T) {
 		})
 	})
 
+	Convey("Given a request to the static landing page previewing a collection", t, func() {
+		helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+		previewCollectionID := "collection-1"
+		previewHeaders := datasetAPISDK.Headers{AccessToken: testAdminAccessToken, CollectionID: previewCollectionID}
+		previewVersion := datasetAPIModels.Version{State: datasetAPIModels.AssociatedState}
+
+		mockDatasetClient.EXPECT().GetDataset(ctx, previewHeaders, datasetID).
+			Return(dataset, nil)
+
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic1").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic1}, nil)
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic2").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic2}, nil)
+
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, previewHeaders, datasetID, editionID, versionID).
+			Return(previewVersion, nil)
+		mockDatasetClient.EXPECT().GetVersions(ctx, previewHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000}).
+			Return(versionList, nil)
+
+		publishedVersions := datasetAPISDK.VersionsList{Items: []datasetAPIModels.Version{
+			{Version: 1, State: datasetAPIModels.PublishedState, ReleaseDate: "2025-01-15T07:00:00.000Z"},
+			{Version: 2, State: datasetAPIModels.PublishedState, ReleaseDate: "2025-02-15T07:00:00.000Z"},
+		}}
+		mockDatasetClient.EXPECT().GetVersions(ctx, testAdminDatasetSDKHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{State: datasetAPIModels.PublishedState, Limit: 1000}).
+			Return(publishedVersions, nil)
+		mockDatasetClient.EXPECT().GetDatasetCurrentAndNext(ctx, testAdminDatasetSDKHeaders, datasetID).
+			Return(datasetAPIModels.DatasetUpdate{ID: datasetID, Current: &dataset, Next: &dataset}, nil)
+
+		mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testAdminAccessToken, previewCollectionID, lang, homepagePath).
+			Return(zebedee.HomepageContent{}, nil)
+
+		var page static.Page
+		mockRenderClient.EXPECT().NewBasePageModel().
+			Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRenderClient.EXPECT().BuildPage(ctx, gomock.Any(), templateNameStatic).Do(func(_ io.Writer, m interface{}, _ string) {
+			page = m.(static.Page)
+		})
+
+		Convey("When the StaticLanding handler is called", func() {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", "topic1-slug", datasetID, editionID, versionID), http.NoBody)
+			r = mux.SetURLVars(r, map[string]string{
+				"topic":     "topic1-slug",
+				"datasetID": datasetID,
+				"editionID": editionID,
+				"versionID": versionID,
+			})
+			staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testAdminAccessToken, lang, previewCollectionID)
+
+			Convey("Then the version is fetched from the collection and shown in a preview banner", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(page.ShowWorkflow, ShouldBeTrue)
+				So(page.Workflow.CollectionID, ShouldEqual, previewCollectionID)
+				So(page.Workflow.State, ShouldEqual, datasetAPIModels.AssociatedState)
+				So(page.Workflow.IsUnpublished, ShouldBeTrue)
+			})
+
+			Convey("Then the gaps in the version's metadata are shown to the publisher", func() {
+				So(page.DatasetLandingPage.Panels, ShouldHaveLength, 3)
+				So(page.DatasetLandingPage.Panels[0].Type, ShouldEqual, static.Error)
+				So(page.DatasetLandingPage.Panels[1].Type, ShouldEqual, static.Pending)
+			})
+
+			Convey("Then the differences from the latest published version are shown to the publisher", func() {
+				So(page.DatasetLandingPage.Panels[2].Type, ShouldEqual, static.Info)
+				So(page.DatasetLandingPage.Panels[2].Body[0], ShouldEqual, "<p>Compared with published version 2, this version changes:</p>")
+				So(page.DatasetLandingPage.Panels[2].Body[2], ShouldContainSubstring, "<del>Removed: 2025-02-15T07:00:00.000Z</del>")
+			})
+		})
+	})
+
+	Convey("Given a request to the static landing page after an approval", t, func() {
+		helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+		mockDatasetClient.EXPECT().GetDataset(ctx, testAdminDatasetSDKHeaders, datasetID).
+			Return(dataset, nil)
+
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic1").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic1}, nil)
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic2").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic2}, nil)
+
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, testAdminDatasetSDKHeaders, datasetID, editionID, versionID).
+			Return(version, nil)
+		mockDatasetClient.EXPECT().GetVersions(ctx, testAdminDatasetSDKHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000}).
+			Return(versionList, nil)
+
+		mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testAdminAccessToken, collectionID, lang, homepagePath).
+			Return(zebedee.HomepageContent{}, nil)
+
+		var pageModel static.Page
+		mockRenderClient.EXPECT().NewBasePageModel().
+			Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRenderClient.EXPECT().BuildPage(ctx, gomock.Any(), templateNameStatic).Do(func(w io.Writer, m interface{}, templateName string) {
+			pageModel = m.(static.Page)
+		})
+
+		Convey("When the StaticLanding handler is called with the approval flash message", func() {
+			approved := httptest.NewRecorder()
+			flash.Set(approved, httptest.NewRequest(http.MethodPost, "/", http.NoBody), flash.ApprovalSucceeded)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", "topic1-slug", datasetID, editionID, versionID), http.NoBody)
+			r.AddCookie(approved.Result().Cookies()[0])
+			r = mux.SetURLVars(r, map[string]string{
+				"topic":     "topic1-slug",
+				"datasetID": datasetID,
+				"editionID": editionID,
+				"versionID": versionID,
+			})
+			staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testAdminAccessToken, lang, collectionID)
+
+			Convey("Then the result is shown as a success panel", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(pageModel.DatasetLandingPage.Panels, ShouldNotBeEmpty)
+				So(pageModel.DatasetLandingPage.Panels[0].Type, ShouldEqual, static.Success)
+				So(pageModel.DatasetLandingPage.Panels[0].Body, ShouldResemble, []string{"This version has been approved."})
+			})
+
+			Convey("And the flash message is cleared", func() {
+				cookies := w.Result().Cookies()
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].MaxAge, ShouldBeLessThan, 0)
+			})
+		})
+	})
+
+	Convey("Given a version awaiting approval viewed by an admin", t, func() {
+		awaitingApproval := version
+		awaitingApproval.State = "associated"
+
+		mockDatasetClient.EXPECT().GetDataset(ctx, testAdminDatasetSDKHeaders, datasetID).
+			Return(dataset, nil)
+
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic1").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic1}, nil)
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testAdminAccessToken}, "topic2").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic2}, nil)
+
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, testAdminDatasetSDKHeaders, datasetID, editionID, versionID).
+			Return(awaitingApproval, nil)
+		mockDatasetClient.EXPECT().GetVersions(ctx, testAdminDatasetSDKHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000}).
+			Return(versionList, nil)
+
+		mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testAdminAccessToken, collectionID, lang, homepagePath).
+			Return(zebedee.HomepageContent{}, nil)
+
+		var pageModel static.Page
+		mockRenderClient.EXPECT().NewBasePageModel().
+			Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRenderClient.EXPECT().BuildPage(ctx, gomock.Any(), templateNameStatic).Do(func(w io.Writer, m interface{}, templateName string) {
+			pageModel = m.(static.Page)
+		})
+
+		Convey("When the StaticLanding handler is called", func() {
+			versionPath := fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", "topic1-slug", datasetID, editionID, versionID)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, versionPath, http.NoBody)
+			r = mux.SetURLVars(r, map[string]string{
+				"topic":     "topic1-slug",
+				"datasetID": datasetID,
+				"editionID": editionID,
+				"versionID": versionID,
+			})
+			staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testAdminAccessToken, lang, collectionID)
+
+			Convey("Then the state is shown with links to each transition the admin can make", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(pageModel.ShowWorkflow, ShouldBeTrue)
+				So(pageModel.Workflow.State, ShouldEqual, "associated")
+				So(pageModel.Workflow.Transitions, ShouldHaveLength, 3)
+				So(pageModel.Workflow.Transitions[0].URL, ShouldEqual, versionPath+"/approve")
+				So(pageModel.Workflow.Transitions[1].URL, ShouldEqual, versionPath+"/reject")
+				So(pageModel.Workflow.Transitions[2].URL, ShouldEqual, versionPath+"/return-to-draft")
+			})
+		})
+	})
+
 	Convey("When GetDataset fails", t, func() {
 		mockDatasetClient.EXPECT().GetDataset(ctx, testUserDatasetSDKHeaders, datasetID).
 			Return(datasetAPIModels.Dataset{}, errors.New("GetDataset failed"))
@@ -137,7 +314,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -157,7 +334,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 404 Not Found", func() {
 			So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -180,7 +357,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -205,14 +382,59 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
 		})
 	})
 
-	Convey("When the first topic in the dataset does not match the topic in the URL", t, func() {
+	Convey("When a secondary topic in the dataset matches the topic in the URL", t, func() {
+		mockDatasetClient.EXPECT().GetDataset(ctx, testUserDatasetSDKHeaders, datasetID).
+			Return(dataset, nil)
+
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testUserAccessToken}, "topic1").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic1}, nil)
+		mockTopicAPIClient.EXPECT().GetTopicPrivate(ctx, topicAPISDK.Headers{UserAuthToken: testUserAccessToken}, "topic2").
+			Return(&topicAPIModels.TopicResponse{Current: &testTopic2}, nil)
+
+		mockDatasetClient.EXPECT().GetVersionV2(ctx, testUserDatasetSDKHeaders, datasetID, editionID, versionID).
+			Return(version, nil)
+		mockDatasetClient.EXPECT().GetVersions(ctx, testUserDatasetSDKHeaders, datasetID, editionID, &datasetAPISDK.QueryParams{Limit: 1000}).
+			Return(versionList, nil)
+
+		mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testUserAccessToken, collectionID, lang, homepagePath).
+			Return(zebedee.HomepageContent{}, nil)
+
+		var actualPageModel static.Page
+		mockRenderClient.EXPECT().NewBasePageModel().
+			Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRenderClient.EXPECT().BuildPage(ctx, gomock.Any(), templateNameStatic).Do(func(w io.Writer, pageModel interface{}, templateName string) {
+			actualPageModel = pageModel.(static.Page)
+		})
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", testTopic2.Slug, datasetID, editionID, versionID), http.NoBody)
+		r = mux.SetURLVars(r, map[string]string{
+			"topic":     testTopic2.Slug,
+			"datasetID": datasetID,
+			"editionID": editionID,
+			"versionID": versionID,
+		})
+
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
+
+		Convey("Then the page is rendered without redirecting", func() {
+			So(w.Code, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("Then the breadcrumbs follow the secondary topic and the canonical URL uses the primary topic", func() {
+			So(actualPageModel.Breadcrumb[2].URI, ShouldEqual, "/"+testTopic1.Slug+"/"+testTopic2.Slug)
+			So(actualPageModel.Canonical.URL, ShouldEndWith, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", testTopic1.Slug, datasetID, editionID, versionID))
+		})
+	})
+
+	Convey("When none of the topics in the dataset match the topic in the URL", t, func() {
 		mockDatasetClient.EXPECT().GetDataset(ctx, testUserDatasetSDKHeaders, datasetID).
 			Return(dataset, nil)
 
@@ -230,10 +452,10 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
-		Convey("Then the response status code should be 302 Found and redirect to correct topic", func() {
-			So(w.Code, ShouldEqual, http.StatusFound)
+		Convey("Then the response status code should be 301 Moved Permanently and redirect to the canonical topic", func() {
+			So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 			So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", testTopic1.Slug, datasetID, editionID, versionID))
 		})
 	})
@@ -265,7 +487,7 @@ func TestStaticLanding(t *testing.T) {
 			"editionID": editionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -290,7 +512,7 @@ func TestStaticLanding(t *testing.T) {
 			"editionID": editionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response should be a redirect to the latest version", func() {
 			So(w.Code, ShouldEqual, http.StatusFound)
@@ -317,7 +539,7 @@ func TestStaticLanding(t *testing.T) {
 			"editionID": editionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response should be a redirect to the latest version", func() {
 			So(w.Code, ShouldEqual, http.StatusFound)
@@ -348,7 +570,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -387,7 +609,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 200 OK", func() {
 			So(w.Code, ShouldEqual, http.StatusOK)
@@ -415,7 +637,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response should be a redirect to the download URL", func() {
 			So(w.Code, ShouldEqual, http.StatusFound)
@@ -423,7 +645,10 @@ func TestStaticLanding(t *testing.T) {
 		})
 	})
 
-	Convey("When CheckIsAdmin fails due to auth middleware error", t, func() {
+	Convey("When the user's token cannot be verified to check their permissions", t, func() {
+		awaitingApproval := version
+		awaitingApproval.State = "associated"
+
 		mockDatasetClient.EXPECT().GetDataset(ctx, testUserDatasetSDKHeaders, datasetID).
 			Return(dataset, nil)
 
@@ -433,13 +658,10 @@ func TestStaticLanding(t *testing.T) {
 			Return(&topicAPIModels.TopicResponse{Current: &testTopic2}, nil)
 
 		mockDatasetClient.EXPECT().GetVersionV2(ctx, testUserDatasetSDKHeaders, datasetID, editionID, versionID).
-			Return(version, nil)
+			Return(awaitingApproval, nil)
 
-		failingAuthMiddleware := &authMock.MiddlewareMock{
-			ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
-				return nil, errors.New("failed to parse token")
-			},
-		}
+		// tokens signed by an unknown key fail verification
+		untrustedChecker := permissions.NewChecker(mustNewTestIssuer(), permissionstest.NewPermissionsChecker(nil))
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", "topic1-slug", datasetID, editionID, versionID), http.NoBody)
@@ -450,7 +672,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, failingAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, untrustedChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -481,7 +703,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 500 Internal Server Error", func() {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -520,7 +742,7 @@ func TestStaticLanding(t *testing.T) {
 			"versionID": versionID,
 		})
 
-		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, mockAuthMiddleware, testUserAccessToken, lang, collectionID)
+		staticLanding(r, w, mockDatasetClient, mockRenderClient, mockZebedeeClient, mockTopicAPIClient, cfg, permissionsChecker, linter, testUserAccessToken, lang, collectionID)
 
 		Convey("Then the response status code should be 200 OK", func() {
 			So(w.Code, ShouldEqual, http.StatusOK)
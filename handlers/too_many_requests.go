@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	locales "github.com/ONSdigital/dp-frontend-dataset-controller/locale"
+)
+
+// TooManyRequests renders the page served to a client which has created too many filters in a short time, saying how
+// long to wait from the Retry-After header set by the rate limiter
+func TooManyRequests(rend clients.RenderClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		lang := locales.FromRequest(req)
+
+		m := rend.NewBasePageModel()
+		m.Language = lang
+		m.Error.Title = helper.Localise("TooManyRequestsTitle", lang, 1)
+		m.Error.Description = helper.Localise("TooManyRequestsDescription", lang, 1, w.Header().Get("Retry-After"))
+		rend.BuildErrorPage(w, m, http.StatusTooManyRequests)
+	}
+}
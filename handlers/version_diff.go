@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/diff"
+)
+
+// showVersionDiff reports whether publishers are shown how a version differs from the published version of its
+// edition, which is when they are reviewing an unpublished version in a collection
+func showVersionDiff(cfg config.Config, collectionID string, version dpDatasetApiModels.Version) bool {
+	return cfg.IsPublishing && collectionID != "" && version.State != dpDatasetApiModels.PublishedState
+}
+
+// publishedVersionDiff compares a version under review with the latest published version of its edition, returning
+// the published version number and the changes. The published version is fetched without the collection header, and the
+// published dataset read from its current document, so the changes in the collection are not included. A version number
+// of 0 means no version of the edition has been published.
+func publishedVersionDiff(ctx context.Context, dc clients.DatasetAPISdkClient, userAccessToken, datasetID, editionID string, dataset dpDatasetApiModels.Dataset, version dpDatasetApiModels.Version) (int, []diff.Change, error) {
+	headers := dpDatasetApiSdk.Headers{AccessToken: userAccessToken}
+
+	publishedVersions, err := dc.GetVersions(ctx, headers, datasetID, editionID, &dpDatasetApiSdk.QueryParams{State: dpDatasetApiModels.PublishedState, Limit: 1000})
+	if err != nil {
+		// an edition which has never been published is not found outside of its collection
+		if isNotFound(err) {
+			return 0, nil, nil
+		}
+		return 0, nil, err
+	}
+	if len(publishedVersions.Items) == 0 {
+		return 0, nil, nil
+	}
+
+	published := publishedVersions.Items[0]
+	for _, v := range publishedVersions.Items[1:] {
+		if v.Version > published.Version {
+			published = v
+		}
+	}
+
+	// with the user's token the dataset is the draft in the collection, so the published document is read from current
+	publishedDataset, err := dc.GetDatasetCurrentAndNext(ctx, headers, datasetID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if publishedDataset.Current == nil {
+		return 0, nil, nil
+	}
+
+	return published.Version, diff.Compare(*publishedDataset.Current, published, dataset, version), nil
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestShowVersionDiff(t *testing.T) {
+	Convey("Given the publishing environment", t, func() {
+		cfg := initialiseMockConfig()
+		cfg.IsPublishing = true
+		unpublished := datasetAPIModels.Version{State: datasetAPIModels.AssociatedState}
+
+		Convey("Then the differences are shown for an unpublished version in a collection", func() {
+			So(showVersionDiff(cfg, "collection-1", unpublished), ShouldBeTrue)
+		})
+
+		Convey("Then the differences are not shown outside of a collection", func() {
+			So(showVersionDiff(cfg, "", unpublished), ShouldBeFalse)
+		})
+
+		Convey("Then the differences are not shown for a published version", func() {
+			So(showVersionDiff(cfg, "collection-1", datasetAPIModels.Version{State: datasetAPIModels.PublishedState}), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given the web environment", t, func() {
+		cfg := initialiseMockConfig()
+		cfg.IsPublishing = false
+
+		Convey("Then the differences are never shown", func() {
+			So(showVersionDiff(cfg, "collection-1", datasetAPIModels.Version{State: datasetAPIModels.AssociatedState}), ShouldBeFalse)
+		})
+	})
+}
+
+func TestPublishedVersionDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockDatasetClient := clients.NewMockDatasetAPISdkClient(ctrl)
+	headers := datasetAPISDK.Headers{AccessToken: testUserAccessToken}
+	publishedQuery := &datasetAPISDK.QueryParams{State: datasetAPIModels.PublishedState, Limit: 1000}
+
+	datasetID := "cpih01"
+	editionID := "time-series"
+	dataset := datasetAPIModels.Dataset{ID: datasetID, Title: "CPIH"}
+	version := datasetAPIModels.Version{Version: 3, State: datasetAPIModels.AssociatedState}
+
+	Convey("Given an edition with published versions", t, func() {
+		mockDatasetClient.EXPECT().GetVersions(ctx, headers, datasetID, editionID, publishedQuery).
+			Return(datasetAPISDK.VersionsList{Items: []datasetAPIModels.Version{{Version: 2}, {Version: 1}}}, nil)
+		mockDatasetClient.EXPECT().GetDatasetCurrentAndNext(ctx, headers, datasetID).
+			Return(datasetAPIModels.DatasetUpdate{
+				ID:      datasetID,
+				Current: &datasetAPIModels.Dataset{ID: datasetID, Title: "CPIH index"},
+				Next:    &datasetAPIModels.Dataset{ID: datasetID, Title: "CPIH"},
+			}, nil)
+
+		Convey("Then the version is compared with the latest published version and the published dataset, not the draft", func() {
+			publishedVersion, changes, err := publishedVersionDiff(ctx, mockDatasetClient, testUserAccessToken, datasetID, editionID, dataset, version)
+			So(err, ShouldBeNil)
+			So(publishedVersion, ShouldEqual, 2)
+			So(changes, ShouldHaveLength, 1)
+			So(changes[0].Removed, ShouldResemble, []string{"CPIH index"})
+			So(changes[0].Added, ShouldResemble, []string{"CPIH"})
+		})
+	})
+
+	Convey("Given a dataset with published versions but no published document", t, func() {
+		mockDatasetClient.EXPECT().GetVersions(ctx, headers, datasetID, editionID, publishedQuery).
+			Return(datasetAPISDK.VersionsList{Items: []datasetAPIModels.Version{{Version: 1}}}, nil)
+		mockDatasetClient.EXPECT().GetDatasetCurrentAndNext(ctx, headers, datasetID).
+			Return(datasetAPIModels.DatasetUpdate{ID: datasetID, Next: &datasetAPIModels.Dataset{ID: datasetID}}, nil)
+
+		Convey("Then there is no published version to compare with", func() {
+			publishedVersion, changes, err := publishedVersionDiff(ctx, mockDatasetClient, testUserAccessToken, datasetID, editionID, dataset, version)
+			So(err, ShouldBeNil)
+			So(publishedVersion, ShouldEqual, 0)
+			So(changes, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given an edition which has not been published", t, func() {
+		mockDatasetClient.EXPECT().GetVersions(ctx, headers, datasetID, editionID, publishedQuery).
+			Return(datasetAPISDK.VersionsList{}, datasetAPIModels.Error{Code: "404", Description: "edition not found"})
+
+		Convey("Then there is no published version to compare with", func() {
+			publishedVersion, changes, err := publishedVersionDiff(ctx, mockDatasetClient, testUserAccessToken, datasetID, editionID, dataset, version)
+			So(err, ShouldBeNil)
+			So(publishedVersion, ShouldEqual, 0)
+			So(changes, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the dataset API fails", t, func() {
+		mockDatasetClient.EXPECT().GetVersions(ctx, headers, datasetID, editionID, publishedQuery).
+			Return(datasetAPISDK.VersionsList{}, errors.New("dataset API failed"))
+
+		Convey("Then the error is returned", func() {
+			_, _, err := publishedVersionDiff(ctx, mockDatasetClient, testUserAccessToken, datasetID, editionID, dataset, version)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -1,35 +1,44 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
-	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // VersionsList will load a list of versions for a filterable dataset
-func VersionsList(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient) http.HandlerFunc {
-	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
-		versionsList(w, req, dc, zc, rend, collectionID, userAccessToken, lang)
+func VersionsList(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		versionsList(w, req, dc, zc, rend, cfg, collectionID, userAccessToken, lang)
 	})
 }
 
-func versionsList(responseWriter http.ResponseWriter, request *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, collectionID, userAccessToken, lang string) {
+func versionsList(responseWriter http.ResponseWriter, request *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, collectionID, userAccessToken, lang string) {
 	vars := mux.Vars(request)
 	datasetID := vars["datasetID"]
 	editionID := vars["editionID"]
-	ctx := request.Context()
+	budget, ctx, cancel := newDependencyBudget(request.Context(), cfg)
+	defer cancel()
 
 	headers := dpDatasetApiSdk.Headers{
 		CollectionID: collectionID,
 		AccessToken:  userAccessToken,
 	}
 
-	datasetDetails, err := dc.GetDataset(ctx, headers, datasetID)
+	var datasetDetails dpDatasetApiModels.Dataset
+	err := budget.critical(ctx, func(ctx context.Context) (err error) {
+		datasetDetails, err = dc.GetDataset(ctx, headers, datasetID)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -43,18 +52,27 @@ func versionsList(responseWriter http.ResponseWriter, request *http.Request, dc
 
 	getVersionsQueryParams := dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}
 
-	versionsList, err := dc.GetVersions(ctx, headers, datasetID, editionID, &getVersionsQueryParams)
+	var versionsList dpDatasetApiSdk.VersionsList
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		versionsList, err = dc.GetVersions(ctx, headers, datasetID, editionID, &getVersionsQueryParams)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
 	}
 
-	homepageContent, err := zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
-	if err != nil {
-		log.Warn(ctx, "unable to get homepage content", log.FormatErrors([]error{err}), log.Data{"homepage_content": err})
-	}
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
 
-	editionDetails, err := dc.GetEdition(ctx, headers, datasetID, editionID)
+	var editionDetails dpDatasetApiModels.Edition
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		editionDetails, err = dc.GetEdition(ctx, headers, datasetID, editionID)
+		return err
+	})
 	if err != nil {
 		setStatusCode(ctx, responseWriter, err)
 		return
@@ -62,5 +80,8 @@ func versionsList(responseWriter http.ResponseWriter, request *http.Request, dc
 
 	basePage := rend.NewBasePageModel()
 	m := mapper.CreateVersionsList(basePage, request, datasetDetails, editionDetails, versionsList.Items, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	m.Preview = mapper.MapPreview(cfg.IsPublishing, collectionID, "")
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(responseWriter)
 	rend.BuildPage(responseWriter, m, "version-list")
 }
@@ -2,14 +2,17 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/version"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
@@ -43,13 +46,67 @@ func TestVersionList(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2017/versions", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, mockZebedeeClient, mockRend))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, mockZebedeeClient, mockRend, cfg))
 
 			router.ServeHTTP(w, req)
 
 			So(w.Code, ShouldEqual, http.StatusOK)
 		})
 
+		Convey("test versions list is rendered with the homepage content degraded when zebedee fails", func() {
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/").Return(zebedee.HomepageContent{}, errors.New("zebedee error"))
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			mockClient.EXPECT().GetVersions(ctx, headers, "12345", "2017", &dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}).Return(dpDatasetApiSdk.VersionsList{Items: []dpDatasetApiModels.Version{}}, nil)
+			mockClient.EXPECT().GetEdition(ctx, headers, "12345", "2017").Return(dpDatasetApiModels.Edition{}, nil)
+
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "version-list")
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2017/versions", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, mockZebedeeClient, mockRend, cfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get(degradedPartsHeader), ShouldEqual, partHomepageContent)
+		})
+
+		Convey("test versions list shows the preview banner in publishing", func() {
+			publishingCfg := initialiseMockConfig()
+			publishingCfg.IsPublishing = true
+			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+			mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, userAuthToken, collectionID, locale, "/")
+			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{}, nil)
+			mockClient.EXPECT().GetVersions(ctx, headers, "12345", "2017", &dpDatasetApiSdk.QueryParams{Offset: 0, Limit: 1000}).Return(dpDatasetApiSdk.VersionsList{Items: []dpDatasetApiModels.Version{}}, nil)
+			mockClient.EXPECT().GetEdition(ctx, headers, "12345", "2017").Return(dpDatasetApiModels.Edition{}, nil)
+
+			var page version.Page
+			mockRend := clients.NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "version-list").Do(func(_ io.Writer, m interface{}, _ string) {
+				page = m.(version.Page)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/datasets/12345/editions/2017/versions", http.NoBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, mockZebedeeClient, mockRend, publishingCfg))
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(page.ShowWorkflow, ShouldBeTrue)
+			So(page.Workflow.CollectionID, ShouldEqual, collectionID)
+		})
+
 		Convey("test versions list returns status 404 when dataset type is static", func() {
 			mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
 			mockClient.EXPECT().GetDataset(ctx, headers, "12345").Return(dpDatasetApiModels.Dataset{
@@ -60,7 +117,7 @@ func TestVersionList(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2017/versions", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, nil, nil))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, nil, nil, cfg))
 
 			router.ServeHTTP(w, req)
 
@@ -75,7 +132,7 @@ func TestVersionList(t *testing.T) {
 			req := httptest.NewRequest("GET", "/datasets/12345/editions/2017/versions", http.NoBody)
 
 			router := mux.NewRouter()
-			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, nil, nil))
+			router.HandleFunc("/datasets/{datasetID}/editions/{editionID}/versions", VersionsList(mockClient, nil, nil, cfg))
 
 			router.ServeHTTP(w, req)
 
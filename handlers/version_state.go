@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/audit"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
+	dpHandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// RouteTransitionVersionState names the route changing the state of a version, so that attempts rejected before
+// reaching its handler can be audited
+const RouteTransitionVersionState = "transition-version-state"
+
+// versionStateReasonRequired is the locale key of the error shown when a transition is submitted without a reason
+const versionStateReasonRequired = "VersionStateReasonRequired"
+
+type transitionResult struct {
+	succeeded flash.Message
+	failed    flash.Message
+}
+
+// transitionResults are the flash messages shown on the version page once a transition has been attempted
+var transitionResults = map[workflow.Transition]transitionResult{
+	workflow.Approve:       {succeeded: flash.ApprovalSucceeded, failed: flash.ApprovalFailed},
+	workflow.Reject:        {succeeded: flash.RejectionSucceeded, failed: flash.RejectionFailed},
+	workflow.ReturnToDraft: {succeeded: flash.ReturnToDraftSucceeded, failed: flash.ReturnToDraftFailed},
+}
+
+// ConfirmVersionStateTransition asks the publisher to confirm a change to the state of a static dataset version
+func ConfirmVersionStateTransition(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, permissionsChecker *permissions.Checker) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		confirmVersionStateTransition(w, req, dc, zc, rend, cfg, permissionsChecker, lang, collectionID, userAccessToken)
+	})
+}
+
+func confirmVersionStateTransition(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient,
+	cfg config.Config, permissionsChecker *permissions.Checker, lang, collectionID, userAccessToken string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+
+	vars := mux.Vars(req)
+	datasetID := vars["datasetID"]
+	editionID := vars["editionID"]
+	versionID := vars["versionID"]
+	logData := log.Data{
+		"datasetID":  datasetID,
+		"editionID":  editionID,
+		"versionID":  versionID,
+		"transition": vars["transition"],
+	}
+
+	transition, err := workflow.Parse(vars["transition"])
+	if logError(ctx, w, err, "unknown version state transition", logData) {
+		return
+	}
+
+	headers := datasetAPISDK.Headers{
+		AccessToken:  userAccessToken,
+		CollectionID: collectionID,
+	}
+
+	var dataset datasetAPIModels.Dataset
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		dataset, err = dc.GetDataset(ctx, headers, datasetID)
+		return err
+	})
+	if logError(ctx, w, err, "failed to fetch dataset", logData) {
+		return
+	}
+
+	var version datasetAPIModels.Version
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		version, err = dc.GetVersionV2(ctx, headers, datasetID, editionID, versionID)
+		return err
+	})
+	if logError(ctx, w, err, "failed to fetch version", logData) {
+		return
+	}
+	logData["state"] = version.State
+
+	datasetPermissions := permissionsChecker.ForRequest(userAccessToken).ForDataset(datasetID)
+	err = workflow.Check(ctx, version.State, transition, datasetPermissions)
+	// the version may have moved on since the page linking here was loaded, leaving nothing to confirm
+	if errors.Is(err, workflow.ErrTransitionNotAllowed) {
+		log.Info(ctx, "version state transition no longer allowed", logData)
+		http.Redirect(w, req, versionPath(req), http.StatusSeeOther)
+		return
+	}
+	if logError(ctx, w, err, "version state transition not permitted", logData) {
+		return
+	}
+
+	renderVersionStateTransition(ctx, budget, w, req, zc, rend, dataset, version, transition, "", http.StatusOK, lang, collectionID, userAccessToken, logData)
+}
+
+// renderVersionStateTransition renders the page confirming a transition. When errorKey is set the page shows the
+// localised error so that the publisher can correct the form and submit it again.
+func renderVersionStateTransition(ctx context.Context, budget *dependencyBudget, w http.ResponseWriter, req *http.Request, zc clients.ZebedeeClient,
+	rend clients.RenderClient, dataset datasetAPIModels.Dataset, version datasetAPIModels.Version, transition workflow.Transition, errorKey string, status int,
+	lang, collectionID, userAccessToken string, logData log.Data) {
+	token, err := csrf.Token(w, req)
+	if logError(ctx, w, err, "failed to create csrf token", logData) {
+		return
+	}
+
+	var homepageContent zebedee.HomepageContent
+	budget.optional(ctx, partHomepageContent, func(ctx context.Context) (err error) {
+		homepageContent, err = zc.GetHomepageContent(ctx, userAccessToken, collectionID, lang, homepagePath)
+		return err
+	})
+
+	basePage := rend.NewBasePageModel()
+	m := mapper.CreateVersionTransitionPage(basePage, req, lang, dataset, version, transition, errorKey, token, homepageContent.ServiceMessage, homepageContent.EmergencyBanner)
+	m.CSPNonce = security.Nonce(ctx)
+	budget.setHeader(w)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	rend.BuildPage(w, m, "version-state")
+}
+
+// TransitionVersionState changes the state of a static dataset version once the publisher has confirmed it,
+// redirecting back to the version page with a flash message giving the result. A transition submitted without the
+// reason it requires shows the confirmation form again with an error. Every attempt is recorded to the audit sink,
+// along with the reason given for it.
+func TransitionVersionState(dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient, cfg config.Config, permissionsChecker *permissions.Checker,
+	auditor audit.Sink) http.HandlerFunc {
+	return controllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, userAccessToken string) {
+		transitionVersionState(w, req, dc, zc, rend, cfg, permissionsChecker, auditor, lang, collectionID, userAccessToken)
+	})
+}
+
+func transitionVersionState(w http.ResponseWriter, req *http.Request, dc clients.DatasetAPISdkClient, zc clients.ZebedeeClient, rend clients.RenderClient,
+	cfg config.Config, permissionsChecker *permissions.Checker, auditor audit.Sink, lang, collectionID, userAccessToken string) {
+	budget, ctx, cancel := newDependencyBudget(req.Context(), cfg)
+	defer cancel()
+
+	vars := mux.Vars(req)
+	topicSlug := vars["topic"]
+	datasetID := vars["datasetID"]
+	editionID := vars["editionID"]
+	versionID := vars["versionID"]
+
+	headers := datasetAPISDK.Headers{
+		AccessToken:  userAccessToken,
+		CollectionID: collectionID,
+	}
+
+	logData := log.Data{
+		"topicSlug":  topicSlug,
+		"datasetID":  datasetID,
+		"editionID":  editionID,
+		"versionID":  versionID,
+		"transition": vars["transition"],
+	}
+
+	userPermissions := permissionsChecker.ForRequest(userAccessToken)
+	event := audit.Event{
+		Action:    vars["transition"],
+		DatasetID: datasetID,
+		EditionID: editionID,
+		VersionID: versionID,
+	}
+
+	transition, err := workflow.Parse(vars["transition"])
+	if err != nil {
+		event.Cause = audit.CauseUnknownTransition
+		auditTransition(ctx, auditor, userPermissions, event, audit.ResultDenied)
+	}
+	if logError(ctx, w, err, "unknown version state transition", logData) {
+		return
+	}
+
+	reason := strings.TrimSpace(req.PostFormValue(workflow.ReasonField))
+	if reason != "" {
+		logData["reason"] = reason
+	}
+	event.Reason = reason
+
+	result := transitionResults[transition]
+
+	var version datasetAPIModels.Version
+	err = budget.critical(ctx, func(ctx context.Context) (err error) {
+		version, err = dc.GetVersionV2(ctx, headers, datasetID, editionID, versionID)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "failed to fetch version", err, logData)
+		auditTransition(ctx, auditor, userPermissions, event, audit.ResultFailure)
+		redirectWithFlash(w, req, result.failed)
+		return
+	}
+	logData["state"] = version.State
+	event.PreviousState = version.State
+
+	nextState, err := workflow.Next(ctx, version.State, transition, userPermissions.ForDataset(datasetID), reason)
+	if err != nil {
+		auditResult := audit.ResultFailure
+		if errors.Is(err, workflow.ErrTransitionNotAllowed) || errors.Is(err, workflow.ErrPermissionDenied) {
+			auditResult = audit.ResultDenied
+		}
+		auditTransition(ctx, auditor, userPermissions, event, auditResult)
+	}
+	if errors.Is(err, workflow.ErrTransitionNotAllowed) {
+		log.Error(ctx, "version state transition no longer allowed", err, logData)
+		redirectWithFlash(w, req, result.failed)
+		return
+	}
+	if errors.Is(err, workflow.ErrReasonRequired) {
+		log.Info(ctx, "version state transition submitted without a reason", logData)
+		var dataset datasetAPIModels.Dataset
+		err = budget.critical(ctx, func(ctx context.Context) (err error) {
+			dataset, err = dc.GetDataset(ctx, headers, datasetID)
+			return err
+		})
+		if logError(ctx, w, err, "failed to fetch dataset", logData) {
+			return
+		}
+		renderVersionStateTransition(ctx, budget, w, req, zc, rend, dataset, version, transition, versionStateReasonRequired, http.StatusBadRequest,
+			lang, collectionID, userAccessToken, logData)
+		return
+	}
+	if logError(ctx, w, err, "version state transition not permitted", logData) {
+		return
+	}
+	logData["nextState"] = nextState
+	event.NewState = nextState
+
+	err = budget.critical(ctx, func(ctx context.Context) error {
+		return dc.PutVersionState(ctx, headers, datasetID, editionID, versionID, nextState)
+	})
+	if err != nil {
+		log.Error(ctx, "version state transition failed", err, logData)
+		auditTransition(ctx, auditor, userPermissions, event, audit.ResultFailure)
+		redirectWithFlash(w, req, result.failed)
+		return
+	}
+
+	log.Info(ctx, "version state transition successful", logData)
+	auditTransition(ctx, auditor, userPermissions, event, audit.ResultSuccess)
+	redirectWithFlash(w, req, result.succeeded)
+}
+
+// AuditRejectedTransition returns a function recording attempts to change the state of a version which were rejected
+// before reaching the handler for being submitted without a valid csrf token. Other rejected requests are ignored.
+func AuditRejectedTransition(router *mux.Router, permissionsChecker *permissions.Checker, auditor audit.Sink) func(req *http.Request) {
+	return func(req *http.Request) {
+		var match mux.RouteMatch
+		if !router.Match(req, &match) || match.Route == nil || match.Route.GetName() != RouteTransitionVersionState {
+			return
+		}
+
+		ctx := req.Context()
+		userAccessToken, err := dpHandlers.GetFlorenceToken(ctx, req)
+		if err != nil {
+			log.Warn(ctx, "unable to get access token for audit event", log.FormatErrors([]error{err}))
+		}
+
+		event := audit.Event{
+			Action:    match.Vars["transition"],
+			DatasetID: match.Vars["datasetID"],
+			EditionID: match.Vars["editionID"],
+			VersionID: match.Vars["versionID"],
+			Cause:     audit.CauseInvalidCSRFToken,
+		}
+		auditTransition(ctx, auditor, permissionsChecker.ForRequest(userAccessToken), event, audit.ResultDenied)
+	}
+}
+
+// auditTransition records the result of an attempt to change the state of a version, identifying the user from their
+// token. A failure to record the event is logged rather than failing the request, as the state may already have changed.
+func auditTransition(ctx context.Context, auditor audit.Sink, userPermissions *permissions.Request, event audit.Event, result audit.Result) {
+	event.Result = result
+	event.RequestID = request.GetRequestId(ctx)
+
+	userID, err := userPermissions.UserID()
+	if err != nil {
+		log.Warn(ctx, "unable to identify user for audit event", log.FormatErrors([]error{err}))
+	}
+	event.UserID = userID
+
+	if err := auditor.Emit(ctx, event); err != nil {
+		log.Error(ctx, "failed to record audit event", err, log.Data{"event": event})
+	}
+}
+
+// redirectWithFlash returns the publisher to the version page a transition was made from, showing the result
+func redirectWithFlash(w http.ResponseWriter, req *http.Request, m flash.Message) {
+	flash.Set(w, req, m)
+	//nolint:gosec // false positive as this is a relative URL which can only redirect to the same host
+	http.Redirect(w, req, versionPath(req), http.StatusSeeOther)
+}
+
+// versionPath returns the path of the static dataset version page that a transition was made from
+func versionPath(req *http.Request) string {
+	vars := mux.Vars(req)
+	return fmt.Sprintf("/%s/datasets/%s/editions/%s/versions/%s", vars["topic"], vars["datasetID"], vars["editionID"], vars["versionID"])
+}
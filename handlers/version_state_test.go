@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/audit"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/versionstate"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	approvePath       = "/topicSlug/datasets/12345/editions/2017/versions/1/approve"
+	rejectPath        = "/topicSlug/datasets/12345/editions/2017/versions/1/reject"
+	transitionRoute   = "/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/{transition}"
+	transitionVersion = "/topicSlug/datasets/12345/editions/2017/versions/1"
+)
+
+// withToken sets the user's access token on a request, as Florence does
+func withToken(req *http.Request, token string) *http.Request {
+	req.Header.Set(request.FlorenceHeaderKey, token)
+	return req
+}
+
+func TestConfirmVersionStateTransition(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+	cfg := initialiseMockConfig()
+	adminHeaders := datasetAPISDK.Headers{AccessToken: testAdminAccessToken}
+	publisherHeaders := datasetAPISDK.Headers{AccessToken: testUserAccessToken}
+
+	Convey("test ConfirmVersionStateTransition", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+		mockRend := clients.NewMockRenderClient(mockCtrl)
+
+		router := mux.NewRouter()
+		router.HandleFunc(transitionRoute, ConfirmVersionStateTransition(mockClient, mockZebedeeClient, mockRend, cfg, newTestPermissionsChecker()))
+
+		Convey("renders a confirmation page with a csrf token for a version awaiting approval", func() {
+			mockClient.EXPECT().GetDataset(ctx, adminHeaders, "12345").Return(datasetAPIModels.Dataset{ID: "12345", Title: "Weekly deaths"}, nil)
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").
+				Return(datasetAPIModels.Version{Edition: "2017", Version: 1, State: "associated"}, nil)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testAdminAccessToken, collectionID, locale, homepagePath).Return(zebedee.HomepageContent{}, nil)
+
+			var page versionstate.Page
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "version-state").Do(func(w io.Writer, m interface{}, templateName string) {
+				page = m.(versionstate.Page)
+			})
+
+			w := httptest.NewRecorder()
+			req := withToken(httptest.NewRequest(http.MethodGet, approvePath, http.NoBody), testAdminAccessToken)
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(page.Data.DatasetTitle, ShouldEqual, "Weekly deaths")
+			So(page.Data.ActionURL, ShouldEqual, approvePath)
+			So(page.Data.VersionURL, ShouldEqual, transitionVersion)
+
+			var csrfCookie *http.Cookie
+			for _, c := range w.Result().Cookies() {
+				if c.Name == csrf.FieldName {
+					csrfCookie = c
+				}
+			}
+			So(csrfCookie, ShouldNotBeNil)
+			So(page.Data.CSRFToken, ShouldEqual, csrfCookie.Value)
+		})
+
+		Convey("redirects to the version page when the version is already approved", func() {
+			mockClient.EXPECT().GetDataset(ctx, adminHeaders, "12345").Return(datasetAPIModels.Dataset{ID: "12345"}, nil)
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").
+				Return(datasetAPIModels.Version{State: "approved"}, nil)
+
+			w := httptest.NewRecorder()
+			req := withToken(httptest.NewRequest(http.MethodGet, approvePath, http.NoBody), testAdminAccessToken)
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(w.Header().Get("Location"), ShouldEqual, transitionVersion)
+		})
+
+		Convey("forbids a publisher without permission to approve the dataset from rejecting a version", func() {
+			mockClient.EXPECT().GetDataset(ctx, publisherHeaders, "12345").Return(datasetAPIModels.Dataset{ID: "12345"}, nil)
+			mockClient.EXPECT().GetVersionV2(ctx, publisherHeaders, "12345", "2017", "1").
+				Return(datasetAPIModels.Version{State: "associated"}, nil)
+
+			w := httptest.NewRecorder()
+			req := withToken(httptest.NewRequest(http.MethodGet, rejectPath, http.NoBody), testUserAccessToken)
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("returns not found for an unknown transition", func() {
+			w := httptest.NewRecorder()
+			req := withToken(httptest.NewRequest(http.MethodGet, "/topicSlug/datasets/12345/editions/2017/versions/1/publish", http.NoBody), testAdminAccessToken)
+
+			router.ServeHTTP(w, req)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestTransitionVersionState(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	newTransitionRequest := func(path, accessToken, cookieToken, formToken, reason string) *http.Request {
+		form := url.Values{csrf.FieldName: {formToken}}
+		if reason != "" {
+			form.Set(workflow.ReasonField, reason)
+		}
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: csrf.FieldName, Value: cookieToken})
+		req = req.WithContext(request.WithRequestId(req.Context(), "request-1"))
+		return withToken(req, accessToken)
+	}
+
+	flashCookie := func(w *httptest.ResponseRecorder) string {
+		for _, c := range w.Result().Cookies() {
+			if c.Name == "flash" {
+				return c.Value
+			}
+		}
+		return ""
+	}
+
+	auditEvents := func(buf *bytes.Buffer) []audit.Event {
+		var events []audit.Event
+		decoder := json.NewDecoder(buf)
+		for decoder.More() {
+			var e audit.Event
+			So(decoder.Decode(&e), ShouldBeNil)
+			events = append(events, e)
+		}
+		return events
+	}
+
+	Convey("test TransitionVersionState", t, func() {
+		mockClient := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockZebedeeClient := clients.NewMockZebedeeClient(mockCtrl)
+		mockRend := clients.NewMockRenderClient(mockCtrl)
+		adminHeaders := datasetAPISDK.Headers{AccessToken: testAdminAccessToken}
+		publisherHeaders := datasetAPISDK.Headers{AccessToken: testUserAccessToken}
+
+		router := mux.NewRouter()
+		var auditLog bytes.Buffer
+		auditor := audit.NewJSONLinesSink(&auditLog)
+		permissionsChecker := newTestPermissionsChecker()
+		router.HandleFunc(transitionRoute, TransitionVersionState(mockClient, mockZebedeeClient, mockRend, config.Config{}, permissionsChecker, auditor)).
+			Methods(http.MethodPost).Name(RouteTransitionVersionState)
+		router.Use(csrf.Middleware(nil, AuditRejectedTransition(router, permissionsChecker, auditor), nil))
+
+		Convey("approves version and redirects to version page with a success message", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "associated"}, nil)
+			mockClient.
+				EXPECT().
+				PutVersionState(ctx, adminHeaders, "12345", "2017", "1", "approved").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(approvePath, testAdminAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(w.Header().Get("Location"), ShouldEqual, transitionVersion)
+			So(flashCookie(w), ShouldEqual, string(flash.ApprovalSucceeded))
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Time, ShouldNotBeZeroValue)
+			events[0].Time = time.Time{}
+			So(events[0], ShouldResemble, audit.Event{
+				Name:          audit.EventName,
+				Action:        "approve",
+				UserID:        "admin",
+				DatasetID:     "12345",
+				EditionID:     "2017",
+				VersionID:     "1",
+				PreviousState: "associated",
+				NewState:      "approved",
+				Result:        audit.ResultSuccess,
+				RequestID:     "request-1",
+			})
+		})
+
+		Convey("redirects to version page with an error message when the dataset client fails", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "associated"}, nil)
+			mockClient.
+				EXPECT().
+				PutVersionState(ctx, adminHeaders, "12345", "2017", "1", "approved").
+				Return(errors.New("approval failed"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(approvePath, testAdminAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(w.Header().Get("Location"), ShouldEqual, transitionVersion)
+			So(flashCookie(w), ShouldEqual, string(flash.ApprovalFailed))
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Result, ShouldEqual, audit.ResultFailure)
+			So(events[0].NewState, ShouldEqual, "approved")
+		})
+
+		Convey("rejects version back to draft when a reason is given", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "associated"}, nil)
+			mockClient.
+				EXPECT().
+				PutVersionState(ctx, adminHeaders, "12345", "2017", "1", "edition-confirmed").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(rejectPath, testAdminAccessToken, "token", "token", "Totals do not match the release"))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(flashCookie(w), ShouldEqual, string(flash.RejectionSucceeded))
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Action, ShouldEqual, "reject")
+			So(events[0].Reason, ShouldEqual, "Totals do not match the release")
+			So(events[0].NewState, ShouldEqual, "edition-confirmed")
+		})
+
+		Convey("records the reason for a rejection in the audit event when the dataset client fails", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "associated"}, nil)
+			mockClient.
+				EXPECT().
+				PutVersionState(ctx, adminHeaders, "12345", "2017", "1", "edition-confirmed").
+				Return(errors.New("rejection failed"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(rejectPath, testAdminAccessToken, "token", "token", "  Totals do not match the release  "))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(flashCookie(w), ShouldEqual, string(flash.RejectionFailed))
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Result, ShouldEqual, audit.ResultFailure)
+			So(events[0].Reason, ShouldEqual, "Totals do not match the release")
+		})
+
+		Convey("shows the rejection form again with an error when no reason is given", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").
+				Return(datasetAPIModels.Version{Edition: "2017", Version: 1, State: "associated"}, nil)
+			mockClient.EXPECT().GetDataset(ctx, adminHeaders, "12345").Return(datasetAPIModels.Dataset{ID: "12345", Title: "Weekly deaths"}, nil)
+			mockZebedeeClient.EXPECT().GetHomepageContent(ctx, testAdminAccessToken, "", locale, homepagePath).Return(zebedee.HomepageContent{}, nil)
+
+			var page versionstate.Page
+			mockRend.EXPECT().NewBasePageModel().Return(core.NewPage("", ""))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "version-state").Do(func(w io.Writer, m interface{}, templateName string) {
+				page = m.(versionstate.Page)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(rejectPath, testAdminAccessToken, "token", "token", "   "))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(flashCookie(w), ShouldBeEmpty)
+			So(page.Data.DatasetTitle, ShouldEqual, "Weekly deaths")
+			So(page.Data.RequiresReason, ShouldBeTrue)
+			So(page.Data.CSRFToken, ShouldEqual, "token")
+			So(page.Error.ErrorItems, ShouldHaveLength, 1)
+			So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "VersionStateReasonRequired")
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Result, ShouldEqual, audit.ResultFailure)
+		})
+
+		Convey("forbids a publisher without permission to approve the dataset from approving", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, publisherHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "associated"}, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(approvePath, testUserAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].UserID, ShouldEqual, "publisher")
+			So(events[0].Result, ShouldEqual, audit.ResultDenied)
+			So(events[0].NewState, ShouldBeEmpty)
+		})
+
+		Convey("returns version to draft for a publisher who can edit the dataset", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, publisherHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "approved"}, nil)
+			mockClient.
+				EXPECT().
+				PutVersionState(ctx, publisherHeaders, "12345", "2017", "1", "edition-confirmed").
+				Return(nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(transitionVersion+"/return-to-draft", testUserAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(flashCookie(w), ShouldEqual, string(flash.ReturnToDraftSucceeded))
+		})
+
+		Convey("redirects with an error message when the version has moved to a state the transition is not allowed from", func() {
+			mockClient.EXPECT().GetVersionV2(ctx, adminHeaders, "12345", "2017", "1").Return(datasetAPIModels.Version{State: "published"}, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(approvePath, testAdminAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusSeeOther)
+			So(flashCookie(w), ShouldEqual, string(flash.ApprovalFailed))
+		})
+
+		Convey("rejects the request without changing state when the csrf token does not match", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(approvePath, testAdminAccessToken, "token", "forged", ""))
+
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+			So(flashCookie(w), ShouldBeEmpty)
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			events[0].Time = time.Time{}
+			So(events[0], ShouldResemble, audit.Event{
+				Name:      audit.EventName,
+				Action:    "approve",
+				UserID:    "admin",
+				DatasetID: "12345",
+				EditionID: "2017",
+				VersionID: "1",
+				Result:    audit.ResultDenied,
+				Cause:     audit.CauseInvalidCSRFToken,
+				RequestID: "request-1",
+			})
+		})
+
+		Convey("returns not found and audits the attempt for an unknown transition", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newTransitionRequest(transitionVersion+"/publish", testAdminAccessToken, "token", "token", ""))
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+
+			events := auditEvents(&auditLog)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Action, ShouldEqual, "publish")
+			So(events[0].UserID, ShouldEqual, "admin")
+			So(events[0].Result, ShouldEqual, audit.ResultDenied)
+			So(events[0].Cause, ShouldEqual, audit.CauseUnknownTransition)
+		})
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/contact"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/osrlogo"
 	topicAPIModels "github.com/ONSdigital/dp-topic-api/models"
+	"golang.org/x/text/language"
 )
 
 // ExtractDatasetInfoFromPath gets the datasetID, edition and version from a given path
@@ -57,18 +58,48 @@ func GetAPIRouterVersion(rawurl string) (string, error) {
 	return apiRouterURL.Path, nil
 }
 
-// GetCurrentURL returns a string of the current URL from language, site domain and url path parameters
-func GetCurrentURL(lang, siteDomain, urlPath string) string {
-	var welshPrepend string
-	if lang == "cy" {
-		welshPrepend = "cy."
+// GetCurrentURL returns a string of the current URL from language, site domain and url path parameters.
+// Languages other than the default are served from a subdomain named after the language.
+func GetCurrentURL(lang, defaultLang, siteDomain, urlPath string) string {
+	var langPrepend string
+	if lang != "" && lang != defaultLang {
+		langPrepend = lang + "."
 	}
 
 	if siteDomain == "localhost" || siteDomain == "" {
 		siteDomain = "ons.gov.uk"
 	}
 
-	return welshPrepend + siteDomain + urlPath
+	return langPrepend + siteDomain + urlPath
+}
+
+// openGraphLocales maps the languages of the service to their Open Graph locales
+var openGraphLocales = map[string]string{
+	"en": "en_GB",
+	"cy": "cy_GB",
+}
+
+// defaultOpenGraphLocale is the Open Graph locale of the default language
+const defaultOpenGraphLocale = "en_GB"
+
+// GetOpenGraphLocale returns the Open Graph locale (language_TERRITORY) for the given language. Languages without an
+// entry in the mapping table use the territory they are most likely to be written in, and unparseable languages the
+// default locale.
+func GetOpenGraphLocale(lang string) string {
+	if lang == "" {
+		return defaultOpenGraphLocale
+	}
+	if ogLocale, ok := openGraphLocales[strings.ToLower(lang)]; ok {
+		return ogLocale
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return defaultOpenGraphLocale
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	return base.String() + "_" + region.String()
 }
 
 // GenerateSharingLink returns a sharing link for different types of social media
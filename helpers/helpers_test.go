@@ -72,13 +72,35 @@ func TestGetAPIRouterVersion(t *testing.T) {
 
 func TestGetCurrentUrl(t *testing.T) {
 	Convey("The current URL is correctly constructed from the parameters", t, func() {
-		So(GetCurrentURL("en", "mydomain.com", "/page1/page2"), ShouldResemble, "mydomain.com/page1/page2")
-		So(GetCurrentURL("en", "mydomain.com", ""), ShouldResemble, "mydomain.com")
-		So(GetCurrentURL("cy", "mydomain.com", ""), ShouldResemble, "cy.mydomain.com")
-		So(GetCurrentURL("cy", "mydomain.com", "/page1"), ShouldResemble, "cy.mydomain.com/page1")
-		So(GetCurrentURL("en", "localhost", "/page1"), ShouldResemble, "ons.gov.uk/page1")
-		So(GetCurrentURL("cy", "localhost", "/page1"), ShouldResemble, "cy.ons.gov.uk/page1")
-		So(GetCurrentURL("en", "", "/page1"), ShouldResemble, "ons.gov.uk/page1")
+		So(GetCurrentURL("en", "en", "mydomain.com", "/page1/page2"), ShouldResemble, "mydomain.com/page1/page2")
+		So(GetCurrentURL("en", "en", "mydomain.com", ""), ShouldResemble, "mydomain.com")
+		So(GetCurrentURL("cy", "en", "mydomain.com", ""), ShouldResemble, "cy.mydomain.com")
+		So(GetCurrentURL("cy", "en", "mydomain.com", "/page1"), ShouldResemble, "cy.mydomain.com/page1")
+		So(GetCurrentURL("en", "en", "localhost", "/page1"), ShouldResemble, "ons.gov.uk/page1")
+		So(GetCurrentURL("cy", "en", "localhost", "/page1"), ShouldResemble, "cy.ons.gov.uk/page1")
+		So(GetCurrentURL("en", "en", "", "/page1"), ShouldResemble, "ons.gov.uk/page1")
+		So(GetCurrentURL("", "en", "mydomain.com", "/page1"), ShouldResemble, "mydomain.com/page1")
+		So(GetCurrentURL("ga", "en", "mydomain.com", "/page1"), ShouldResemble, "ga.mydomain.com/page1")
+	})
+
+	Convey("The default language is served without a subdomain when it is not English", t, func() {
+		So(GetCurrentURL("cy", "cy", "mydomain.com", "/page1"), ShouldResemble, "mydomain.com/page1")
+		So(GetCurrentURL("en", "cy", "mydomain.com", "/page1"), ShouldResemble, "en.mydomain.com/page1")
+	})
+}
+
+func TestGetOpenGraphLocale(t *testing.T) {
+	Convey("The Open Graph locale is mapped from the languages of the service", t, func() {
+		So(GetOpenGraphLocale("en"), ShouldEqual, "en_GB")
+		So(GetOpenGraphLocale("cy"), ShouldEqual, "cy_GB")
+		So(GetOpenGraphLocale("CY"), ShouldEqual, "cy_GB")
+		So(GetOpenGraphLocale(""), ShouldEqual, "en_GB")
+	})
+
+	Convey("The Open Graph locale of other languages uses their most likely territory", t, func() {
+		So(GetOpenGraphLocale("ga"), ShouldEqual, "ga_IE")
+		So(GetOpenGraphLocale("pt-BR"), ShouldEqual, "pt_BR")
+		So(GetOpenGraphLocale("not a language"), ShouldEqual, "en_GB")
 	})
 }
 
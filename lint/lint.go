@@ -0,0 +1,163 @@
+// Package lint checks the metadata of static dataset versions for the gaps publishers most often leave before
+// publishing, such as missing contacts or distributions without a file size.
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// Severity is how seriously a failed rule is reported
+type Severity string
+
+// Severities a rule can be configured with
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+// IDs of the rules checked
+const (
+	RuleContacts         = "contacts"
+	RuleQMI              = "qmi"
+	RuleUsageNotes       = "usage-notes"
+	RuleNextRelease      = "next-release"
+	RuleDistributionSize = "distribution-size"
+)
+
+// rule checks one part of the metadata of a dataset version
+type rule struct {
+	id        string
+	localeKey string
+	severity  Severity
+	// check reports whether the dataset version passes, naming the parts which failed when a rule covers several
+	check func(d models.Dataset, v models.Version) (ok bool, subjects []string)
+}
+
+// Failure is a rule a dataset version does not pass
+type Failure struct {
+	Rule      string   `json:"rule"`
+	Severity  Severity `json:"severity"`
+	Subjects  []string `json:"subjects,omitempty"`
+	Message   string   `json:"message"`
+	LocaleKey string   `json:"-"`
+}
+
+// Report is the result of checking a dataset version
+type Report struct {
+	DatasetID string    `json:"dataset_id"`
+	Edition   string    `json:"edition"`
+	Version   int       `json:"version"`
+	State     string    `json:"state"`
+	Passed    bool      `json:"passed"`
+	Failures  []Failure `json:"failures"`
+}
+
+// defaultRules are the rules checked, in the order failures are reported, with their default severities
+var defaultRules = []rule{
+	{id: RuleContacts, localeKey: "LintContacts", severity: SeverityError, check: checkContacts},
+	{id: RuleQMI, localeKey: "LintQMI", severity: SeverityError, check: checkQMI},
+	{id: RuleUsageNotes, localeKey: "LintUsageNotes", severity: SeverityWarning, check: checkUsageNotes},
+	{id: RuleNextRelease, localeKey: "LintNextRelease", severity: SeverityWarning, check: checkNextRelease},
+	{id: RuleDistributionSize, localeKey: "LintDistributionSize", severity: SeverityError, check: checkDistributionSize},
+}
+
+// Linter checks dataset versions against a set of rules
+type Linter struct {
+	rules []rule
+}
+
+// New returns a Linter checking the default rules, with severities overridden by rule ID. An unknown rule or severity
+// is an error, so that mistakes in configuration are found at startup.
+func New(severities map[string]string) (*Linter, error) {
+	known := make(map[string]bool, len(defaultRules))
+	for _, r := range defaultRules {
+		known[r.id] = true
+	}
+	for id, severity := range severities {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+		switch Severity(severity) {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return nil, fmt.Errorf("unknown severity %q for lint rule %q", severity, id)
+		}
+	}
+
+	rules := make([]rule, 0, len(defaultRules))
+	for _, r := range defaultRules {
+		if severity, ok := severities[r.id]; ok {
+			r.severity = Severity(severity)
+		}
+		if r.severity != SeverityOff {
+			rules = append(rules, r)
+		}
+	}
+	return &Linter{rules: rules}, nil
+}
+
+// Check returns the rules the dataset version does not pass
+func (l *Linter) Check(d models.Dataset, v models.Version) []Failure {
+	var failures []Failure
+	for _, r := range l.rules {
+		if ok, subjects := r.check(d, v); !ok {
+			failures = append(failures, Failure{
+				Rule:      r.id,
+				Severity:  r.severity,
+				Subjects:  subjects,
+				LocaleKey: r.localeKey,
+			})
+		}
+	}
+	return failures
+}
+
+func checkContacts(d models.Dataset, _ models.Version) (ok bool, subjects []string) {
+	for _, c := range d.Contacts {
+		if c.Email != "" || c.Telephone != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func checkQMI(d models.Dataset, _ models.Version) (ok bool, subjects []string) {
+	return d.QMI != nil && d.QMI.HRef != "", nil
+}
+
+func checkUsageNotes(_ models.Dataset, v models.Version) (ok bool, subjects []string) {
+	if v.UsageNotes == nil || len(*v.UsageNotes) == 0 {
+		return false, nil
+	}
+	for _, n := range *v.UsageNotes {
+		if strings.TrimSpace(n.Note) == "" {
+			subjects = append(subjects, n.Title)
+		}
+	}
+	return len(subjects) == 0, subjects
+}
+
+func checkNextRelease(d models.Dataset, _ models.Version) (ok bool, subjects []string) {
+	return d.NextRelease != "", nil
+}
+
+func checkDistributionSize(_ models.Dataset, v models.Version) (ok bool, subjects []string) {
+	if v.Distributions == nil {
+		return true, nil
+	}
+	for _, dist := range *v.Distributions {
+		if dist.ByteSize > 0 {
+			continue
+		}
+		name := dist.Title
+		if name == "" {
+			name = strings.ToUpper(dist.Format.String())
+		}
+		subjects = append(subjects, name)
+	}
+	return len(subjects) == 0, subjects
+}
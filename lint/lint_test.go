@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	completeDataset = models.Dataset{
+		Contacts:    []models.ContactDetails{{Name: "Consumer prices team", Email: "cpi@ons.gov.uk"}},
+		QMI:         &models.GeneralDetails{HRef: "/economy/inflationandpriceindices/methodologies/consumerpriceinflationqmi"},
+		NextRelease: "15 April 2026",
+	}
+	completeVersion = models.Version{
+		UsageNotes: &[]models.UsageNote{{Title: "Coverage", Note: "Covers the UK"}},
+		Distributions: &[]models.Distribution{
+			{Format: models.DistributionFormatCSV, ByteSize: 1024},
+		},
+	}
+)
+
+func failedRules(failures []Failure) []string {
+	rules := make([]string, 0, len(failures))
+	for _, f := range failures {
+		rules = append(rules, f.Rule)
+	}
+	return rules
+}
+
+func TestCheck(t *testing.T) {
+	linter, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a dataset version with complete metadata", t, func() {
+		Convey("Then no rules fail", func() {
+			So(linter.Check(completeDataset, completeVersion), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a dataset version with no metadata", t, func() {
+		failures := linter.Check(models.Dataset{}, models.Version{})
+
+		Convey("Then every rule for missing metadata fails with its default severity", func() {
+			So(failures, ShouldResemble, []Failure{
+				{Rule: RuleContacts, Severity: SeverityError, LocaleKey: "LintContacts"},
+				{Rule: RuleQMI, Severity: SeverityError, LocaleKey: "LintQMI"},
+				{Rule: RuleUsageNotes, Severity: SeverityWarning, LocaleKey: "LintUsageNotes"},
+				{Rule: RuleNextRelease, Severity: SeverityWarning, LocaleKey: "LintNextRelease"},
+			})
+		})
+	})
+
+	Convey("Given a dataset whose only contact has no email or telephone", t, func() {
+		d := completeDataset
+		d.Contacts = []models.ContactDetails{{Name: "Consumer prices team"}}
+
+		Convey("Then the contacts rule fails", func() {
+			So(failedRules(linter.Check(d, completeVersion)), ShouldResemble, []string{RuleContacts})
+		})
+	})
+
+	Convey("Given a version with an empty usage note", t, func() {
+		v := completeVersion
+		v.UsageNotes = &[]models.UsageNote{{Title: "Coverage", Note: "Covers the UK"}, {Title: "Revisions", Note: " "}}
+
+		Convey("Then the usage notes rule fails naming the empty note", func() {
+			failures := linter.Check(completeDataset, v)
+			So(failedRules(failures), ShouldResemble, []string{RuleUsageNotes})
+			So(failures[0].Subjects, ShouldResemble, []string{"Revisions"})
+		})
+	})
+
+	Convey("Given a version with distributions which have no size", t, func() {
+		v := completeVersion
+		v.Distributions = &[]models.Distribution{
+			{Format: models.DistributionFormatCSV, ByteSize: 1024},
+			{Format: models.DistributionFormatXLSX},
+			{Title: "Supporting tables", Format: models.DistributionFormatCSV},
+		}
+
+		Convey("Then the distribution size rule fails naming each distribution", func() {
+			failures := linter.Check(completeDataset, v)
+			So(failedRules(failures), ShouldResemble, []string{RuleDistributionSize})
+			So(failures[0].Severity, ShouldEqual, SeverityError)
+			So(failures[0].Subjects, ShouldResemble, []string{"XLSX", "Supporting tables"})
+		})
+	})
+}
+
+func TestNew(t *testing.T) {
+	Convey("Given severities overriding the defaults", t, func() {
+		linter, err := New(map[string]string{RuleNextRelease: "error", RuleQMI: "off"})
+		So(err, ShouldBeNil)
+
+		Convey("Then the overridden severities are reported and rules turned off are not checked", func() {
+			d := completeDataset
+			d.QMI = nil
+			d.NextRelease = ""
+
+			So(linter.Check(d, completeVersion), ShouldResemble, []Failure{
+				{Rule: RuleNextRelease, Severity: SeverityError, LocaleKey: "LintNextRelease"},
+			})
+		})
+	})
+
+	Convey("Given an unknown rule", t, func() {
+		_, err := New(map[string]string{"keywords": "error"})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an unknown severity", t, func() {
+		_, err := New(map[string]string{RuleQMI: "fatal"})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
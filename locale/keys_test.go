@@ -0,0 +1,117 @@
+package locale
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-dataset-controller/assets"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const repoRoot = ".."
+
+var (
+	templateKeyPattern = regexp.MustCompile(`localise\s+"(\w+)"`)
+	// keys built at runtime, such as "TimestampMonth"+month.String(), are not matched
+	sourceKeyPattern = regexp.MustCompile(`(?:helper\.Localise\(\s*|LocaleKey:\s*)"(\w+)"\s*[,)}]`)
+	localeKeyPattern = regexp.MustCompile(`(?m)^\[(\w+)\]`)
+)
+
+// TestLocaleFilesContainUsedKeys checks that every localisation key used by the templates and the Go source
+// is defined for each supported language, either by this service or by the design system
+func TestLocaleFilesContainUsedKeys(t *testing.T) {
+	cfg, err := config.Get()
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+
+	usedKeys := findUsedKeys(t)
+
+	Convey("Given the localisation keys used by the templates and the Go source", t, func() {
+		So(usedKeys, ShouldNotBeEmpty)
+
+		for _, lang := range cfg.SupportedLanguages {
+			definedKeys := loadLocaleKeys(t, lang)
+
+			Convey("Then every key is defined for "+lang, func() {
+				var missing []string
+				for key, usedIn := range usedKeys {
+					if !definedKeys[key] {
+						missing = append(missing, key+" (used in "+usedIn+")")
+					}
+				}
+				sort.Strings(missing)
+				So(missing, ShouldBeEmpty)
+			})
+		}
+	})
+}
+
+// findUsedKeys returns the localisation keys used in the templates and the non-test Go source, along with
+// the first file each key was found in
+func findUsedKeys(t *testing.T) map[string]string {
+	t.Helper()
+
+	usedKeys := map[string]string{}
+	err := filepath.WalkDir(repoRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != repoRoot && (strings.HasPrefix(name, ".") || name == "vendor" || name == "mocks") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var pattern *regexp.Regexp
+		switch {
+		case strings.HasSuffix(path, ".tmpl"):
+			pattern = templateKeyPattern
+		case strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") && filepath.Base(path) != "data.go":
+			pattern = sourceKeyPattern
+		default:
+			return nil
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range pattern.FindAllStringSubmatch(string(b), -1) {
+			if _, ok := usedKeys[match[1]]; !ok {
+				usedKeys[match[1]] = filepath.ToSlash(path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to find localisation keys: %v", err)
+	}
+
+	return usedKeys
+}
+
+// loadLocaleKeys returns the keys defined for a language by the service and design system locale files
+func loadLocaleKeys(t *testing.T, lang string) map[string]bool {
+	t.Helper()
+
+	keys := map[string]bool{}
+	for _, name := range []string{"service", "core"} {
+		b, err := assets.Asset("locales/" + name + "." + lang + ".toml")
+		if err != nil {
+			t.Fatalf("missing %s locale file for %s: %v", name, lang, err)
+		}
+		for _, match := range localeKeyPattern.FindAllStringSubmatch(string(b), -1) {
+			keys[match[1]] = true
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,53 @@
+package locale
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-net/v3/request"
+)
+
+type contextKey struct{}
+
+// Middleware resolves the language of a request using the registry. A language cookie is resolved through
+// its fallback chain (e.g. "cy-GB" becomes "cy"), then a language subdomain is used and, when neither has
+// been provided, the Accept-Language header is negotiated. The resolved language is added to the context of
+// the request, where it is read by FromRequest, as dp-net only recognises English and Welsh.
+func Middleware(registry *Registry) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Add("Vary", "Accept-Language")
+
+			ctx := context.WithValue(req.Context(), contextKey{}, requestedLanguage(registry, req))
+			h.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// FromRequest returns the language resolved for the request by Middleware, falling back to the language
+// dp-net finds in the request when it has not been through the middleware
+func FromRequest(req *http.Request) string {
+	if lang, ok := req.Context().Value(contextKey{}).(string); ok {
+		return lang
+	}
+	return request.GetLocaleCode(req)
+}
+
+// requestedLanguage returns the supported language of the request
+func requestedLanguage(registry *Registry, req *http.Request) string {
+	if cookie, err := req.Cookie(request.LocaleCookieKey); err == nil && cookie.Value != "" {
+		return registry.Resolve(cookie.Value)
+	}
+
+	subdomain := strings.Split(req.Host, ".")[0]
+	if registry.IsSupported(subdomain) {
+		return registry.Resolve(subdomain)
+	}
+
+	acceptLanguage := req.Header.Get("Accept-Language")
+	if acceptLanguage == "" {
+		return registry.Default()
+	}
+	return registry.Negotiate(acceptLanguage)
+}
@@ -0,0 +1,122 @@
+package locale
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-net/v3/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	registry, _ := NewRegistry([]string{"en", "cy"})
+
+	// serve returns the language used to render the page and the response
+	serve := func(req *http.Request) (string, *httptest.ResponseRecorder) {
+		var lang string
+		handler := Middleware(registry)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			lang = FromRequest(r)
+		}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return lang, w
+	}
+
+	Convey("Given a request to the default language domain", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "http://www.ons.gov.uk/datasets/cpih01", http.NoBody)
+
+		Convey("When the Accept-Language header prefers a supported language", func() {
+			req.Header.Set("Accept-Language", "cy-GB,cy;q=0.9,en;q=0.8")
+			lang, w := serve(req)
+
+			Convey("Then the negotiated language is used", func() {
+				So(lang, ShouldEqual, "cy")
+			})
+
+			Convey("And the response varies by Accept-Language", func() {
+				So(w.Header().Get("Vary"), ShouldEqual, "Accept-Language")
+			})
+		})
+
+		Convey("When the Accept-Language header only contains unsupported languages", func() {
+			req.Header.Set("Accept-Language", "fr-FR,de;q=0.5")
+			lang, _ := serve(req)
+
+			Convey("Then the default language is used", func() {
+				So(lang, ShouldEqual, "en")
+			})
+		})
+
+		Convey("When a language cookie has been set", func() {
+			req.Header.Set("Accept-Language", "cy")
+			req.AddCookie(&http.Cookie{Name: request.LocaleCookieKey, Value: "en"})
+			lang, _ := serve(req)
+
+			Convey("Then the cookie takes precedence over the Accept-Language header", func() {
+				So(lang, ShouldEqual, "en")
+			})
+		})
+
+		Convey("When a regional language cookie has been set", func() {
+			req.AddCookie(&http.Cookie{Name: request.LocaleCookieKey, Value: "cy-GB"})
+			lang, _ := serve(req)
+
+			Convey("Then the cookie is resolved through its fallback chain", func() {
+				So(lang, ShouldEqual, "cy")
+			})
+		})
+	})
+
+	Convey("Given a request to a language subdomain", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "http://cy.ons.gov.uk/datasets/cpih01", http.NoBody)
+
+		Convey("When the Accept-Language header prefers a different language", func() {
+			req.Header.Set("Accept-Language", "en-GB")
+			lang, _ := serve(req)
+
+			Convey("Then the language of the subdomain is used", func() {
+				So(lang, ShouldEqual, "cy")
+			})
+		})
+	})
+
+	Convey("Given a third language has been registered", t, func() {
+		registry, _ := NewRegistry([]string{"en", "cy", "ga"})
+		serve := func(req *http.Request) string {
+			var lang string
+			handler := Middleware(registry)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				lang = FromRequest(r)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			return lang
+		}
+
+		Convey("Then it is used when set by a cookie", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://www.ons.gov.uk/datasets/cpih01", http.NoBody)
+			req.AddCookie(&http.Cookie{Name: request.LocaleCookieKey, Value: "ga-IE"})
+			So(serve(req), ShouldEqual, "ga")
+		})
+
+		Convey("Then it is used when requested by a subdomain", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://ga.ons.gov.uk/datasets/cpih01", http.NoBody)
+			So(serve(req), ShouldEqual, "ga")
+		})
+
+		Convey("Then it is used when negotiated from the Accept-Language header", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://www.ons.gov.uk/datasets/cpih01", http.NoBody)
+			req.Header.Set("Accept-Language", "ga-IE,en;q=0.5")
+			So(serve(req), ShouldEqual, "ga")
+		})
+	})
+}
+
+func TestFromRequest(t *testing.T) {
+	Convey("Given a request which has not been through the middleware", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "http://cy.ons.gov.uk/datasets/cpih01", http.NoBody)
+
+		Convey("Then the language is found by dp-net", func() {
+			So(FromRequest(req), ShouldEqual, "cy")
+		})
+	})
+}
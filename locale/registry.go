@@ -0,0 +1,120 @@
+package locale
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ErrNoSupportedLanguages is returned when a registry is created without any supported languages
+var ErrNoSupportedLanguages = errors.New("at least one supported language is required")
+
+// Registry holds the languages supported by the service, in order of preference, and resolves requested
+// languages to a supported one by walking a fallback chain. The first supported language is the default.
+type Registry struct {
+	languages []string
+}
+
+// NewRegistry creates a Registry from the configured supported languages
+func NewRegistry(supportedLanguages []string) (*Registry, error) {
+	languages := make([]string, 0, len(supportedLanguages))
+	for _, lang := range supportedLanguages {
+		lang = normalise(lang)
+		if lang != "" && !contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+
+	if len(languages) == 0 {
+		return nil, ErrNoSupportedLanguages
+	}
+
+	return &Registry{languages: languages}, nil
+}
+
+// Default returns the default language
+func (r *Registry) Default() string {
+	return r.languages[0]
+}
+
+// Languages returns the supported languages in order of preference
+func (r *Registry) Languages() []string {
+	return append([]string(nil), r.languages...)
+}
+
+// IsSupported returns true if lang is one of the supported languages
+func (r *Registry) IsSupported(lang string) bool {
+	return contains(r.languages, normalise(lang))
+}
+
+// FallbackChain returns the languages to try for lang, from the most to the least specific and ending
+// with the default language, e.g. "cy-GB" gives [cy-gb cy en]
+func (r *Registry) FallbackChain(lang string) []string {
+	chain := parentChain(lang)
+	if !contains(chain, r.Default()) {
+		chain = append(chain, r.Default())
+	}
+	return chain
+}
+
+// Resolve returns the first supported language in the fallback chain of lang
+func (r *Registry) Resolve(lang string) string {
+	for _, candidate := range r.FallbackChain(lang) {
+		if r.IsSupported(candidate) {
+			return candidate
+		}
+	}
+	return r.Default()
+}
+
+// Negotiate returns the supported language that best matches the value of an Accept-Language header,
+// falling back to the default language if none of the requested languages are supported
+func (r *Registry) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return r.Default()
+	}
+
+	// tags are sorted by quality, so the first one with a supported language in its chain is the best match
+	for _, tag := range tags {
+		for _, candidate := range parentChain(tag.String()) {
+			if r.IsSupported(candidate) {
+				return candidate
+			}
+		}
+	}
+
+	return r.Default()
+}
+
+// parentChain returns lang followed by each of its less specific parents, e.g. "cy-GB" gives [cy-gb cy]
+func parentChain(lang string) []string {
+	chain := make([]string, 0, 2)
+
+	lang = normalise(lang)
+	for lang != "" {
+		chain = append(chain, lang)
+
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+
+	return chain
+}
+
+func normalise(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
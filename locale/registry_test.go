@@ -0,0 +1,87 @@
+package locale
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewRegistry(t *testing.T) {
+	Convey("Given a list of supported languages", t, func() {
+		Convey("When the registry is created", func() {
+			registry, err := NewRegistry([]string{"en", "CY", " en "})
+
+			Convey("Then the languages are normalised and deduplicated", func() {
+				So(err, ShouldBeNil)
+				So(registry.Languages(), ShouldResemble, []string{"en", "cy"})
+			})
+
+			Convey("And the first language is the default", func() {
+				So(registry.Default(), ShouldEqual, "en")
+			})
+
+			Convey("And only the listed languages are supported", func() {
+				So(registry.IsSupported("cy"), ShouldBeTrue)
+				So(registry.IsSupported("Cy"), ShouldBeTrue)
+				So(registry.IsSupported("fr"), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given no supported languages", t, func() {
+		Convey("When the registry is created", func() {
+			registry, err := NewRegistry([]string{})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, ErrNoSupportedLanguages)
+				So(registry, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestFallbackChain(t *testing.T) {
+	registry, _ := NewRegistry([]string{"en", "cy"})
+
+	Convey("The fallback chain goes from the most to the least specific language and ends with the default", t, func() {
+		So(registry.FallbackChain("cy-GB"), ShouldResemble, []string{"cy-gb", "cy", "en"})
+		So(registry.FallbackChain("cy_GB"), ShouldResemble, []string{"cy-gb", "cy", "en"})
+		So(registry.FallbackChain("cy"), ShouldResemble, []string{"cy", "en"})
+		So(registry.FallbackChain("en-GB"), ShouldResemble, []string{"en-gb", "en"})
+		So(registry.FallbackChain(""), ShouldResemble, []string{"en"})
+	})
+
+	Convey("Languages are resolved to the first supported language in their fallback chain", t, func() {
+		So(registry.Resolve("cy-GB"), ShouldEqual, "cy")
+		So(registry.Resolve("en-US"), ShouldEqual, "en")
+		So(registry.Resolve("fr-FR"), ShouldEqual, "en")
+		So(registry.Resolve(""), ShouldEqual, "en")
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	registry, _ := NewRegistry([]string{"en", "cy"})
+
+	Convey("Given an Accept-Language header", t, func() {
+		Convey("Then the supported language with the highest quality is negotiated", func() {
+			So(registry.Negotiate("cy"), ShouldEqual, "cy")
+			So(registry.Negotiate("cy-GB,cy;q=0.9,en;q=0.8"), ShouldEqual, "cy")
+			So(registry.Negotiate("en-GB,en;q=0.9,cy;q=0.8"), ShouldEqual, "en")
+			So(registry.Negotiate("en;q=0.5,cy;q=0.8"), ShouldEqual, "cy")
+		})
+
+		Convey("Then unsupported languages are skipped", func() {
+			So(registry.Negotiate("fr-FR,fr;q=0.9,cy;q=0.5"), ShouldEqual, "cy")
+		})
+
+		Convey("Then the default language is used when no requested language is supported", func() {
+			So(registry.Negotiate("fr-FR,de;q=0.5"), ShouldEqual, "en")
+			So(registry.Negotiate(""), ShouldEqual, "en")
+			So(registry.Negotiate("*"), ShouldEqual, "en")
+		})
+
+		Convey("Then the default language is used when the header is malformed", func() {
+			So(registry.Negotiate("en;q=not-a-number"), ShouldEqual, "en")
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/smtp"
 	"os"
 	"os/signal"
+	"slices"
 
 	render "github.com/ONSdigital/dis-design-system-go"
 	"github.com/ONSdigital/dis-design-system-go/middleware/renderror"
@@ -18,13 +19,21 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	authPermissions "github.com/ONSdigital/dp-authorisation/v2/permissions"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/assets"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/audit"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
 	cachePublic "github.com/ONSdigital/dp-frontend-dataset-controller/cache/public"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/handlers"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/locale"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/ratelimit"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/security"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	topic "github.com/ONSdigital/dp-topic-api/sdk"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -151,13 +160,75 @@ func run(ctx context.Context) error {
 		return err
 	}
 
+	// Permissions are only checked in publishing, where they decide the version state transitions offered. Without
+	// authorisation tokens carry no identity, so the permissions cache is only started when it is enabled.
+	var permissionsChecker *permissions.Checker
+	var permissionsCache *authPermissions.Checker
+	if cfg.IsPublishing {
+		var datasetPermissions auth.PermissionsChecker
+		if cfg.AuthConfig.Enabled {
+			permissionsCache = authPermissions.NewChecker(
+				ctx,
+				cfg.AuthConfig.PermissionsAPIURL,
+				cfg.AuthConfig.PermissionsCacheUpdateInterval,
+				cfg.AuthConfig.PermissionsMaxCacheTime,
+			)
+			datasetPermissions = permissionsCache
+		}
+		permissionsChecker = permissions.NewChecker(authorisation, datasetPermissions)
+	}
+
+	// Publishers are shown the gaps in a static version's metadata before it is published
+	linter, err := lint.New(cfg.LintRuleSeverities)
+	if err != nil {
+		log.Error(ctx, "invalid lint rule severities", err, log.Data{"severities": cfg.LintRuleSeverities})
+		return err
+	}
+
+	// Clients are limited in how often they can create filters, so that they cannot be created in bulk. Publishers are
+	// limited by who they are once their token is verified, which needs authorisation in publishing.
+	var identify ratelimit.Identify
+	if cfg.IsPublishing && cfg.AuthConfig.Enabled {
+		identify = func(token string) (string, error) {
+			return permissionsChecker.ForRequest(token).UserID()
+		}
+	}
+	limiter, err := ratelimit.New(ratelimit.NewMemoryStore(), cfg.RateLimits, cfg.RateLimitTrustedProxies, identify)
+	if err != nil {
+		log.Error(ctx, "invalid rate limits", err, log.Data{"rate_limits": cfg.RateLimits})
+		return err
+	}
+
+	// State changes made in publishing are audited, to a file when one is configured
+	var auditor audit.Sink = audit.NewStdoutSink()
+	if cfg.IsPublishing && cfg.AuditLogPath != "" {
+		fileSink, aErr := audit.NewFileSink(cfg.AuditLogPath)
+		if aErr != nil {
+			log.Error(ctx, "could not open audit log", aErr, log.Data{"path": cfg.AuditLogPath})
+			return aErr
+		}
+		defer func() {
+			if cErr := fileSink.Close(); cErr != nil {
+				log.Error(ctx, "failed to close audit log", cErr)
+			}
+		}()
+		auditor = fileSink
+	}
+
 	healthcheck := health.New(versionInfo, cfg.HealthCheckCriticalTimeout, cfg.HealthCheckInterval)
 
-	if err = registerCheckers(ctx, &healthcheck, apiRouterCli); err != nil {
+	if err = registerCheckers(ctx, &healthcheck, apiRouterCli, permissionsCache); err != nil {
 		os.Exit(1) // nolint:gocritic // ignoring exitAfterDefer: os.Exit will exit, and `defer func(){...}(...)` will not run
 	}
 
+	localeRegistry, err := locale.NewRegistry(cfg.SupportedLanguages)
+	if err != nil {
+		log.Error(ctx, "failed to create locale registry", err, log.Data{"supported_languages": cfg.SupportedLanguages})
+		return err
+	}
+
 	// Initialise render client, routes and initialise localisations bundles
+	csrf.RegisterTemplateFuncs()
 	rend := render.NewWithDefaultClient(assets.Asset, assets.AssetNames, cfg.PatternLibraryAssetsPath, cfg.SiteDomain)
 
 	// Enable profiling endpoint for authorised users
@@ -173,6 +244,9 @@ func run(ctx context.Context) error {
 		log.Error(ctx, "failed to create navigation cache", err, log.Data{"update_interval": cfg.CacheNavigationUpdateInterval})
 		return err
 	}
+	cacheList.AreaLabels = cache.NewAreaLabelCache(cfg.AreaLabelCacheTTL, cfg.AreaLabelCacheSize)
+	cacheList.BlockedAreaCounts = cache.NewBlockedAreaCountCache(cfg.BlockedAreaCountCacheTTL, cfg.BlockedAreaCountCacheSize, cfg.CriticalDependencyTimeout)
+
 	for _, lang := range cfg.SupportedLanguages {
 		navigationlangKey := cacheList.Navigation.GetCachingKeyForNavigationLanguage(lang)
 		cacheList.Navigation.AddUpdateFunc(navigationlangKey, cachePublic.UpdateNavigationData(ctx, cfg, lang, tc))
@@ -181,41 +255,56 @@ func run(ctx context.Context) error {
 	router.Path("/health").HandlerFunc(healthcheck.Handler)
 
 	if cfg.EnableMultivariate {
+		router.Path("/datasets/create").Methods("GET").Queries("populationType", "{populationType}").HandlerFunc(handlers.CreateCustomDatasetFromLink(pc, zc, rend, *cfg))
 		router.Path("/datasets/create").Methods("GET").HandlerFunc(handlers.CreateCustomDataset(pc, zc, rend, *cfg, apiRouterVersion))
-		router.Path("/datasets/create").Methods("POST").HandlerFunc(handlers.PostCreateCustomDataset(f))
-		router.Path("/datasets/create/filter-outputs/{filterOutputID}").Methods("GET").HandlerFunc(handlers.FilterOutput(zc, f, pc, datasetAPISdkClient, rend, *cfg, apiRouterVersion))
-		router.Path("/datasets/create/filter-outputs/{filterOutputID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexIDFromOutput(f))
+		router.Path("/datasets/create").Methods("POST").HandlerFunc(handlers.PostCreateCustomDataset(f, pc, *cfg)).Name(ratelimit.RouteCreateCustomDataset)
+		router.Path("/datasets/create/filter-outputs/{filterOutputID}").Methods("GET").HandlerFunc(handlers.FilterOutput(zc, f, pc, datasetAPISdkClient, rend, cacheList, *cfg, apiRouterVersion))
+		router.Path("/datasets/create/filter-outputs/{filterOutputID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexIDFromOutput(f)).Name(ratelimit.RouteCreateFilterFromOutput)
+		router.Path("/datasets/create/filter-outputs/{filterOutputID}/events").Methods("GET").HandlerFunc(handlers.FilterOutputEvents(f, pc, datasetAPISdkClient, cacheList, *cfg)).Name(handlers.RouteFilterOutputEvents)
+		router.Path("/datasets/create/filter-outputs/{filterOutputID}/sdc").Methods("GET").HandlerFunc(handlers.FilterOutputSDC(zc, f, pc, datasetAPISdkClient, rend, cacheList, *cfg))
+		router.Path("/datasets/create/filter-outputs/{filterOutputID}/spec.json").Methods("GET").HandlerFunc(handlers.FilterOutputSpec(f))
+		router.Path("/datasets/create/import").Methods("GET").HandlerFunc(handlers.ImportFilterSpecForm(zc, rend, *cfg))
+		router.Path("/datasets/create/import").Methods("POST").HandlerFunc(handlers.ImportFilterSpec(f, pc, zc, rend, *cfg)).Name(ratelimit.RouteImportFilterSpec)
 	}
 
-	router.Path("/datasets/{datasetID}").Methods("GET").HandlerFunc(handlers.EditionsList(datasetAPISdkClient, zc, rend, apiRouterVersion))
-	router.Path("/datasets/{datasetID}/editions").Methods("GET").HandlerFunc(handlers.EditionsList(datasetAPISdkClient, zc, rend, apiRouterVersion))
+	router.Path("/datasets/{datasetID}").Methods("GET").HandlerFunc(handlers.EditionsList(datasetAPISdkClient, zc, rend, *cfg, apiRouterVersion))
+	router.Path("/datasets/{datasetID}/editions").Methods("GET").HandlerFunc(handlers.EditionsList(datasetAPISdkClient, zc, rend, *cfg, apiRouterVersion))
 	router.Path("/datasets/{datasetID}/editions/{editionID}").Methods("GET").HandlerFunc(handlers.FilterableLanding(datasetAPISdkClient, pc, rend, zc, *cfg, apiRouterVersion))
-	router.Path("/datasets/{datasetID}/editions/{editionID}/versions").Methods("GET").HandlerFunc(handlers.VersionsList(datasetAPISdkClient, zc, rend))
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions").Methods("GET").HandlerFunc(handlers.VersionsList(datasetAPISdkClient, zc, rend, *cfg))
 	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}").Methods("GET").HandlerFunc(handlers.FilterableLanding(datasetAPISdkClient, pc, rend, zc, *cfg, apiRouterVersion))
-	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexID(f, apiClientsGoDatasetClient))
-	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter").Methods("POST").HandlerFunc(handlers.CreateFilterID(f, apiClientsGoDatasetClient))
-	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}").Methods("GET").HandlerFunc(handlers.FilterOutput(zc, f, pc, datasetAPISdkClient, rend, *cfg, apiRouterVersion))
-	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexIDFromOutput(f))
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexID(f, apiClientsGoDatasetClient)).Name(ratelimit.RouteCreateFlexFilter)
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter").Methods("POST").HandlerFunc(handlers.CreateFilterID(f, apiClientsGoDatasetClient)).Name(ratelimit.RouteCreateFilter)
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}").Methods("GET").HandlerFunc(handlers.FilterOutput(zc, f, pc, datasetAPISdkClient, rend, cacheList, *cfg, apiRouterVersion))
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}").Methods("POST").HandlerFunc(handlers.CreateFilterFlexIDFromOutput(f)).Name(ratelimit.RouteCreateFilterFromOutput)
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/events").Methods("GET").HandlerFunc(handlers.FilterOutputEvents(f, pc, datasetAPISdkClient, cacheList, *cfg)).Name(handlers.RouteFilterOutputEvents)
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/sdc").Methods("GET").HandlerFunc(handlers.FilterOutputSDC(zc, f, pc, datasetAPISdkClient, rend, cacheList, *cfg))
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/spec.json").Methods("GET").HandlerFunc(handlers.FilterOutputSpec(f))
+
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/dimensions/{dimensionName}/options").Methods("GET").HandlerFunc(handlers.DimensionOptions(datasetAPISdkClient, zc, rend, *cfg))
+	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/dimensions/{dimensionName}/options.csv").Methods("GET").HandlerFunc(handlers.DimensionOptionsCSV(datasetAPISdkClient))
 
 	router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/metadata.txt").Methods("GET").HandlerFunc(handlers.MetadataText(datasetAPISdkClient, *cfg))
 
 	// "/data" endpoints for static datasets
-	router.Path("/{topic}/datasets/{datasetID}/data").Methods("GET").HandlerFunc(handlers.DatasetData(datasetAPISdkClient, tc, cfg.IsPublishing))
-	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/data").Methods("GET").HandlerFunc(handlers.EditionData(datasetAPISdkClient, tc, cfg.IsPublishing))
-	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/data").Methods("GET").HandlerFunc(handlers.VersionData(datasetAPISdkClient, tc, cfg.IsPublishing))
+	router.Path("/{topic}/datasets/{datasetID}/data").Methods("GET").HandlerFunc(handlers.DatasetData(datasetAPISdkClient, tc, *cfg))
+	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/data").Methods("GET").HandlerFunc(handlers.EditionData(datasetAPISdkClient, tc, *cfg))
+	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/data").Methods("GET").HandlerFunc(handlers.VersionData(datasetAPISdkClient, tc, *cfg))
 
 	// Static landing page routes
 	router.Path("/{topic}/datasets/{datasetID}").Methods("GET").HandlerFunc(handlers.StaticEditionsList(datasetAPISdkClient, rend, zc, tc, *cfg, apiRouterVersion))
 	router.Path("/{topic}/datasets/{datasetID}/editions").Methods("GET").HandlerFunc(handlers.StaticEditionsList(datasetAPISdkClient, rend, zc, tc, *cfg, apiRouterVersion))
-	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, authorisation))
-	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, authorisation))
-	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, authorisation))
+	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, permissionsChecker, linter))
+	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, permissionsChecker, linter))
+	router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}").Methods("GET").HandlerFunc(handlers.StaticLanding(datasetAPISdkClient, rend, zc, tc, *cfg, permissionsChecker, linter))
 
 	if cfg.IsPublishing {
-		router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/approve").Methods("GET").HandlerFunc(handlers.ApproveDatasetVersion(datasetAPISdkClient, *cfg))
+		router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/lint").Methods("GET").HandlerFunc(handlers.VersionLint(datasetAPISdkClient, linter))
+		router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/{transition:approve|reject|return-to-draft}").Methods("GET").HandlerFunc(handlers.ConfirmVersionStateTransition(datasetAPISdkClient, zc, rend, *cfg, permissionsChecker))
+		// Any transition is routed to the handler so that attempts to make an unknown transition are audited
+		router.Path("/{topic}/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/{transition}").Methods("POST").HandlerFunc(handlers.TransitionVersionState(datasetAPISdkClient, zc, rend, *cfg, permissionsChecker, auditor)).Name(handlers.RouteTransitionVersionState)
 	}
 
-	router.PathPrefix("/dataset/").Methods("GET").Handler(http.StripPrefix("/dataset/", handlers.DatasetPage(zc, rend, fc, cacheList)))
+	router.PathPrefix("/dataset/").Methods("GET").Handler(http.StripPrefix("/dataset/", handlers.DatasetPage(zc, rend, fc, cacheList, *cfg)))
 	router.HandleFunc("/{uri:.*}", handlers.LegacyLanding(zc, apiClientsGoDatasetClient, fc, rend, cacheList, *cfg))
 
 	log.Info(ctx, "Starting server", log.Data{"config": cfg})
@@ -226,18 +315,7 @@ func run(ctx context.Context) error {
 	// Start caching
 	go cacheList.Navigation.StartUpdates(ctx, make(chan error))
 
-	collectionIDMiddleware := dpnethandlers.CheckCookie(dpnethandlers.CollectionID)
-	accessTokenMiddleware := dpnethandlers.CheckCookie(dpnethandlers.UserAccess)
-	localeMiddleware := dpnethandlers.CheckHeader(dpnethandlers.Locale)
-	renderrorMiddleware := renderror.Handler(rend)
-
-	var middlewareChain http.Handler
-	if cfg.OtelEnabled {
-		otelMiddleware := otelhttp.NewMiddleware(cfg.OTServiceName)
-		middlewareChain = alice.New(collectionIDMiddleware, accessTokenMiddleware, localeMiddleware, renderrorMiddleware, otelMiddleware).Then(router)
-	} else {
-		middlewareChain = alice.New(collectionIDMiddleware, accessTokenMiddleware, localeMiddleware, renderrorMiddleware).Then(router)
-	}
+	middlewareChain := newMiddlewareChain(cfg, router, rend, localeRegistry, limiter, permissionsChecker, auditor)
 
 	s := dpnethttp.NewServer(cfg.BindAddr, middlewareChain)
 	s.HandleOSSignals = false
@@ -272,6 +350,12 @@ func run(ctx context.Context) error {
 
 		// Stop caching
 		cacheList.Navigation.Close()
+		if permissionsCache != nil {
+			if err := permissionsCache.Close(ctx); err != nil {
+				log.Error(ctx, "failed to close permissions cache", err)
+				hasShutdownErrs = true
+			}
+		}
 
 		if err := s.Shutdown(ctx); err != nil {
 			log.Error(ctx, "failed to gracefully shutdown http server", err)
@@ -301,7 +385,7 @@ func run(ctx context.Context) error {
 	return nil
 }
 
-func registerCheckers(ctx context.Context, h *health.HealthCheck, apiRouterCli *apihealthcheck.Client) (err error) {
+func registerCheckers(ctx context.Context, h *health.HealthCheck, apiRouterCli *apihealthcheck.Client, permissionsCache *authPermissions.Checker) (err error) {
 	hasErrors := false
 
 	if err = h.AddCheck("API router", apiRouterCli.Checker); err != nil {
@@ -309,6 +393,13 @@ func registerCheckers(ctx context.Context, h *health.HealthCheck, apiRouterCli *
 		log.Error(ctx, "failed to add API router health checker", err)
 	}
 
+	if permissionsCache != nil {
+		if err = h.AddCheck("permissions cache", permissionsCache.HealthCheck); err != nil {
+			hasErrors = true
+			log.Error(ctx, "failed to add permissions cache health checker", err)
+		}
+	}
+
 	if hasErrors {
 		return errors.New("Error(s) registering checkers for healthcheck")
 	}
@@ -316,6 +407,41 @@ func registerCheckers(ctx context.Context, h *health.HealthCheck, apiRouterCli *
 	return nil
 }
 
+// newMiddlewareChain wraps the router in the middleware every request passes through
+func newMiddlewareChain(cfg *config.Config, router *mux.Router, rend *render.Render, localeRegistry *locale.Registry, limiter *ratelimit.Limiter, permissionsChecker *permissions.Checker, auditor audit.Sink) http.Handler {
+	collectionIDMiddleware := dpnethandlers.CheckCookie(dpnethandlers.CollectionID)
+	accessTokenMiddleware := dpnethandlers.CheckCookie(dpnethandlers.UserAccess)
+	localeMiddleware := dpnethandlers.CheckHeader(dpnethandlers.Locale)
+	negotiateLocaleMiddleware := locale.Middleware(localeRegistry)
+	// the error page middleware neither flushes nor extends the write deadline, so event streams bypass it
+	renderrorMiddleware := exceptRoutes(router, renderror.Handler(rend), handlers.RouteFilterOutputEvents)
+	securityMiddleware := security.Middleware(cfg.CSPReportOnly, cfg.HSTSMaxAge)
+	rateLimitMiddleware := limiter.Middleware(router, handlers.TooManyRequests(rend))
+	csrfMiddleware := csrf.Middleware(cfg.CSRFAllowedOrigins, handlers.AuditRejectedTransition(router, permissionsChecker, auditor), handlers.Forbidden(rend))
+
+	// the rate limiter and csrf protection render their own error pages, which the error page middleware would discard
+	if cfg.OtelEnabled {
+		otelMiddleware := otelhttp.NewMiddleware(cfg.OTServiceName)
+		return alice.New(collectionIDMiddleware, accessTokenMiddleware, negotiateLocaleMiddleware, localeMiddleware, securityMiddleware, rateLimitMiddleware, csrfMiddleware, renderrorMiddleware, otelMiddleware).Then(router)
+	}
+	return alice.New(collectionIDMiddleware, accessTokenMiddleware, negotiateLocaleMiddleware, localeMiddleware, securityMiddleware, rateLimitMiddleware, csrfMiddleware, renderrorMiddleware).Then(router)
+}
+
+// exceptRoutes applies a middleware to every request other than those matching the named routes of the router
+func exceptRoutes(router *mux.Router, middleware func(http.Handler) http.Handler, names ...string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		wrapped := middleware(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var match mux.RouteMatch
+			if router.Match(req, &match) && match.Route != nil && slices.Contains(names, match.Route.GetName()) {
+				h.ServeHTTP(w, req)
+				return
+			}
+			wrapped.ServeHTTP(w, req)
+		})
+	}
+}
+
 // profileMiddleware to validate auth token before accessing endpoint
 func profileMiddleware(token string) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	render "github.com/ONSdigital/dis-design-system-go"
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/assets"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/audit"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/cache"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/clients"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/csrf"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/handlers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/locale"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddlewareChainFilterOutputEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cfg, err := config.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.FilterOutputEventsInterval = 20 * time.Millisecond
+	cfg.FilterOutputEventsTimeout = 5 * time.Second
+
+	localeRegistry, err := locale.NewRegistry(cfg.SupportedLanguages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter, err := ratelimit.New(ratelimit.NewMemoryStore(), cfg.RateLimits, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrf.RegisterTemplateFuncs()
+	rend := render.NewWithDefaultClient(assets.Asset, assets.AssetNames, cfg.PatternLibraryAssetsPath, cfg.SiteDomain)
+
+	pending := filter.Model{
+		Downloads: map[string]filter.Download{
+			"CSV": {URL: "https://download.ons.gov.uk/output.csv", Size: "1234"},
+		},
+	}
+	ready := filter.Model{
+		Downloads: map[string]filter.Download{
+			"CSV":  {URL: "https://download.ons.gov.uk/output.csv", Size: "1234"},
+			"CSVW": {URL: "https://download.ons.gov.uk/output.csvw", Size: "56"},
+			"TXT":  {URL: "https://download.ons.gov.uk/output.txt", Size: "78"},
+		},
+	}
+
+	Convey("Given the middleware chain of the service in front of the filter output events", t, func() {
+		mockFc := clients.NewMockFilterClient(mockCtrl)
+		gomock.InOrder(
+			mockFc.EXPECT().GetOutput(gomock.Any(), "", "", "", "", "67890").Return(pending, nil).Times(5),
+			mockFc.EXPECT().GetOutput(gomock.Any(), "", "", "", "", "67890").Return(ready, nil),
+		)
+		mockDc := clients.NewMockDatasetAPISdkClient(mockCtrl)
+		mockDc.EXPECT().GetDataset(gomock.Any(), gomock.Any(), "12345").Return(dpDatasetApiModels.Dataset{Type: "cantabular_flexible_table"}, nil)
+
+		router := mux.NewRouter()
+		router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter-outputs/{filterOutputID}/events").Methods("GET").
+			HandlerFunc(handlers.FilterOutputEvents(mockFc, clients.NewMockPopulationClient(mockCtrl), mockDc, &cache.List{}, *cfg)).Name(handlers.RouteFilterOutputEvents)
+
+		// the downloads become ready only after the server's write timeout has passed
+		server := httptest.NewUnstartedServer(newMiddlewareChain(cfg, router, rend, localeRegistry, limiter, nil, audit.NewJSONLinesSink(io.Discard)))
+		server.Config.WriteTimeout = 50 * time.Millisecond
+		server.Start()
+		defer server.Close()
+
+		Convey("When the events are requested", func() {
+			resp, err := http.Get(server.URL + "/datasets/12345/editions/2021/versions/1/filter-outputs/67890/events")
+			So(err, ShouldBeNil)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+
+			Convey("Then every download is streamed, followed by a complete event", func() {
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Header.Get("Content-Type"), ShouldEqual, "text/event-stream")
+				So(string(body), ShouldEqual, strings.Join([]string{
+					"event: download\ndata: {\"format\":\"csv\",\"url\":\"https://download.ons.gov.uk/output.csv\",\"size\":\"1234\",\"size_label\":\"1.2 KB\"}\n\n",
+					"event: download\ndata: {\"format\":\"csvw\",\"url\":\"https://download.ons.gov.uk/output.csvw\",\"size\":\"56\",\"size_label\":\"56 B\"}\n\n",
+					"event: download\ndata: {\"format\":\"txt\",\"url\":\"https://download.ons.gov.uk/output.txt\",\"size\":\"78\",\"size_label\":\"78 B\"}\n\n",
+					"event: complete\ndata: {}\n\n",
+				}, ""))
+			})
+		})
+	})
+}
+
+func TestMiddlewareChainCSRF(t *testing.T) {
+	cfg, err := config.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localeRegistry, err := locale.NewRegistry(cfg.SupportedLanguages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter, err := ratelimit.New(ratelimit.NewMemoryStore(), cfg.RateLimits, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrf.RegisterTemplateFuncs()
+	helper.InitialiseLocalisationsHelper(assets.Asset)
+	rend := render.NewWithDefaultClient(assets.Asset, assets.AssetNames, cfg.PatternLibraryAssetsPath, cfg.SiteDomain)
+
+	Convey("Given the middleware chain of the service in front of a form", t, func() {
+		router := mux.NewRouter()
+		router.Path("/datasets/create").Methods("POST").HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusSeeOther)
+		})
+		chain := newMiddlewareChain(cfg, router, rend, localeRegistry, limiter, nil, audit.NewJSONLinesSink(io.Discard))
+
+		Convey("When the form is submitted from a page on the same host by a browser which does not send Sec-Fetch-Site", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/datasets/create", strings.NewReader("populationType=UR"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Origin", "http://example.com")
+			chain.ServeHTTP(w, req)
+
+			Convey("Then it is accepted without a token", func() {
+				So(w.Code, ShouldEqual, http.StatusSeeOther)
+			})
+		})
+
+		Convey("When the form is submitted from a page on another site", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/datasets/create", strings.NewReader("populationType=UR"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Origin", "https://attacker.example")
+			chain.ServeHTTP(w, req)
+
+			Convey("Then it is rejected with an error page", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, "The form could not be sent")
+			})
+		})
+	})
+}
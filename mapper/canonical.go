@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+)
+
+const (
+	canonicalURLScheme = "https://"
+	hrefLangDefault    = "x-default"
+)
+
+// buildCanonical returns the canonical URL of the page at urlPath in the given language, along with an
+// hreflang alternate for each supported language. The first supported language is used as the default.
+func buildCanonical(lang, siteDomain, urlPath string) sharedModel.Canonical {
+	canonical := sharedModel.Canonical{
+		URL: canonicalURLScheme + helpers.GetCurrentURL(lang, defaultLanguage(), siteDomain, urlPath),
+	}
+
+	for _, supportedLang := range cfg.SupportedLanguages {
+		canonical.Alternates = append(canonical.Alternates, sharedModel.Alternate{
+			HrefLang: supportedLang,
+			URL:      canonicalURLScheme + helpers.GetCurrentURL(supportedLang, defaultLanguage(), siteDomain, urlPath),
+		})
+	}
+
+	if len(canonical.Alternates) > 0 {
+		canonical.Alternates = append(canonical.Alternates, sharedModel.Alternate{
+			HrefLang: hrefLangDefault,
+			URL:      canonical.Alternates[0].URL,
+		})
+	}
+
+	return canonical
+}
@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"testing"
+
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildCanonical(t *testing.T) {
+	expectedAlternates := []sharedModel.Alternate{
+		{HrefLang: "en", URL: "https://ons.gov.uk/economy/datasets/cpih"},
+		{HrefLang: "cy", URL: "https://cy.ons.gov.uk/economy/datasets/cpih"},
+		{HrefLang: "x-default", URL: "https://ons.gov.uk/economy/datasets/cpih"},
+	}
+
+	Convey("Given an English page", t, func() {
+		canonical := buildCanonical("en", "ons.gov.uk", "/economy/datasets/cpih")
+
+		Convey("Then the canonical URL is on the English site", func() {
+			So(canonical.URL, ShouldEqual, "https://ons.gov.uk/economy/datasets/cpih")
+		})
+
+		Convey("Then an alternate is included for each supported language and the default", func() {
+			So(canonical.Alternates, ShouldResemble, expectedAlternates)
+		})
+	})
+
+	Convey("Given a Welsh page", t, func() {
+		canonical := buildCanonical("cy", "ons.gov.uk", "/economy/datasets/cpih")
+
+		Convey("Then the canonical URL is on the Welsh site", func() {
+			So(canonical.URL, ShouldEqual, "https://cy.ons.gov.uk/economy/datasets/cpih")
+		})
+
+		Convey("Then the alternates are the same as for the English page", func() {
+			So(canonical.Alternates, ShouldResemble, expectedAlternates)
+		})
+	})
+
+	Convey("Given no supported languages are configured", t, func() {
+		originalLanguages := cfg.SupportedLanguages
+		cfg.SupportedLanguages = nil
+		defer func() { cfg.SupportedLanguages = originalLanguages }()
+
+		canonical := buildCanonical("en", "ons.gov.uk", "/datasets/cpih")
+
+		Convey("Then no alternates are included", func() {
+			So(canonical.URL, ShouldEqual, "https://ons.gov.uk/datasets/cpih")
+			So(canonical.Alternates, ShouldBeEmpty)
+		})
+	})
+}
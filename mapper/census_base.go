@@ -141,9 +141,11 @@ func CreateCensusBasePage(basePage dpRendererModel.Page, datasetDetails dpDatase
 	}
 
 	// SHARING LINKS
-	currentURL := helpers.GetCurrentURL(censusPage.Language, censusPage.SiteDomain, censusPage.URI)
+	currentURL := helpers.GetCurrentURL(censusPage.Language, defaultLanguage(), censusPage.SiteDomain, censusPage.URI)
 	censusPage.DatasetLandingPage.DatasetURL = currentURL
 	censusPage.DatasetLandingPage.ShareDetails = buildSharingDetails(datasetDetails, censusPage.Language, currentURL)
+	censusPage.Canonical = buildCanonical(censusPage.Language, censusPage.SiteDomain, censusPage.URI)
+	censusPage.SocialMetadata = buildSocialMetadata(censusPage.Language, censusPage.Canonical.URL, datasetDetails.Title, datasetDetails.Description, "census")
 
 	// RELATED CONTENT
 	censusPage.DatasetLandingPage.RelatedContentItems = []model.RelatedContentItem{}
@@ -57,10 +57,15 @@ func CreateCensusFilterOutputsPage(req *http.Request, basePage core.Page, d dpDa
 	}
 	p.Version.Downloads = orderDownloads(p.Version.Downloads)
 
+	// the form is shown once every download is ready, whether on this page or as the download events arrive
+	p.DatasetLandingPage.ShowXLSXInfo = true
 	if len(filterOutput.Downloads) >= 3 {
 		p.DatasetLandingPage.HasDownloads = true
-		p.DatasetLandingPage.ShowXLSXInfo = true
+	} else {
+		p.DatasetLandingPage.DownloadsEventsURL = req.URL.Path + "/events"
+		p.Version.Downloads = withPendingDownloads(p.Version.Downloads)
 	}
+	p.DatasetLandingPage.FilterSpecURL = req.URL.Path + "/spec.json"
 
 	popDim := sharedModel.Dimension{
 		IsPopulationType: true,
@@ -113,14 +118,11 @@ func CreateCensusFilterOutputsPage(req *http.Request, basePage core.Page, d dpDa
 
 	// SDC
 	if p.DatasetLandingPage.IsMultivariate {
-		switch {
-		case sdc.Blocked > 0: // areas blocked
-			p.DatasetLandingPage.HasSDC = true
-			p.DatasetLandingPage.SDC = mapBlockedAreasPanel(&sdc, census.Pending, lang)
+		p.DatasetLandingPage.SDC = MapFilterOutputSDCPanel(sdc, lang)
+		p.DatasetLandingPage.HasSDC = len(p.DatasetLandingPage.SDC) > 0
+		if sdc.Blocked > 0 {
 			p.DatasetLandingPage.ImproveResults = mapImproveResultsCollapsible(p.DatasetLandingPage.Dimensions, lang)
-		case sdc.Passed == sdc.Total && sdc.Total > 0: // all areas passing
-			p.DatasetLandingPage.HasSDC = true
-			p.DatasetLandingPage.SDC = mapBlockedAreasPanel(&sdc, census.Success, lang)
+			p.DatasetLandingPage.SDCDetailURL = req.URL.Path + "/sdc"
 		}
 	}
 
@@ -152,17 +154,19 @@ func mapFilterOutputDims(dims []sharedModel.FilterDimension, queryStrValues []st
 		pDim.TotalItems = dims[i].OptionsCount
 		midFloor, midCeiling := getTruncationMidRange(pDim.TotalItems)
 
-		var displayedOptions []string
-		if pDim.TotalItems > 9 && !helpers.HasStringInSlice(pDim.ID, queryStrValues) && !pDim.IsAreaType {
-			displayedOptions = dims[i].Options[:3]
-			displayedOptions = append(displayedOptions, dims[i].Options[midFloor:midCeiling]...)
-			displayedOptions = append(displayedOptions, dims[i].Options[len(dims[i].Options)-3:]...)
-			pDim.IsTruncated = true
-		} else {
-			displayedOptions = dims[i].Options
-		}
+		if !summariseTimeDimension(lang, pDim.Name, &pDim, dims[i].Options) {
+			var displayedOptions []string
+			if pDim.TotalItems > 9 && !helpers.HasStringInSlice(pDim.ID, queryStrValues) && !pDim.IsAreaType {
+				displayedOptions = dims[i].Options[:3]
+				displayedOptions = append(displayedOptions, dims[i].Options[midFloor:midCeiling]...)
+				displayedOptions = append(displayedOptions, dims[i].Options[len(dims[i].Options)-3:]...)
+				pDim.IsTruncated = true
+			} else {
+				displayedOptions = dims[i].Options
+			}
 
-		pDim.Values = append(pDim.Values, displayedOptions...)
+			pDim.Values = append(pDim.Values, displayedOptions...)
+		}
 
 		q := url.Values{}
 		if pDim.IsTruncated {
@@ -214,6 +218,37 @@ func getFilterAnalytics(filterDimensions []sharedModel.FilterDimension, defaultC
 	return analytics
 }
 
+// MapFilterOutputSDCPanel maps the statistical disclosure control result of a filter output to a panel, pending when
+// any areas are blocked and successful when every area passes. No panel is mapped when there is no result.
+func MapFilterOutputSDCPanel(sdc cantabular.GetBlockedAreaCountResult, lang string) []census.Panel {
+	switch {
+	case sdc.Blocked > 0: // areas blocked
+		return mapBlockedAreasPanel(&sdc, census.Pending, lang)
+	case sdc.Passed == sdc.Total && sdc.Total > 0: // all areas passing
+		return mapBlockedAreasPanel(&sdc, census.Success, lang)
+	}
+	return nil
+}
+
+// withPendingDownloads adds a download without a size for each filter output format which is not ready yet, so the
+// page can show each format as its download event arrives
+func withPendingDownloads(downloads []sharedModel.Download) []sharedModel.Download {
+	ready := make(map[string]bool, len(downloads))
+	for _, download := range downloads {
+		ready[download.Extension] = true
+	}
+	// the spreadsheet is shown as xlsx whichever extension it has
+	if ready["xls"] {
+		ready["xlsx"] = true
+	}
+	for _, ext := range []string{"xlsx", "csv", "txt", "csvw"} {
+		if !ready[ext] {
+			downloads = append(downloads, sharedModel.Download{Extension: ext})
+		}
+	}
+	return orderDownloads(downloads)
+}
+
 // mapBlockedAreasPanel is a helper function that maps the blocked areas panel by panel type
 func mapBlockedAreasPanel(sdc *cantabular.GetBlockedAreaCountResult, panelType census.PanelType, lang string) (p []census.Panel) {
 	switch panelType {
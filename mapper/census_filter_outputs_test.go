@@ -94,6 +94,25 @@ func TestCreateCensusFilterOutputsPage(t *testing.T) {
 				So(page.Collapsible.CollapsibleItems[3].Subheading, ShouldEqual, dimDesc.Dimensions[1].Label)
 				So(page.Collapsible.CollapsibleItems[3].Content, ShouldResemble, []string{dimDesc.Dimensions[1].Description})
 			})
+
+			Convey("and the downloads are streamed from the events endpoint as they are not all ready", func() {
+				So(page.DatasetLandingPage.HasDownloads, ShouldBeFalse)
+				So(page.DatasetLandingPage.DownloadsEventsURL, ShouldEqual, req.URL.Path+"/events")
+			})
+
+			Convey("and the filter specification can be downloaded", func() {
+				So(page.DatasetLandingPage.FilterSpecURL, ShouldEqual, req.URL.Path+"/spec.json")
+			})
+		})
+
+		Convey("when we build a filter outputs page whose downloads are ready", func() {
+			filterOutputs.Downloads = getTestFilterDownloads([]string{"csv", "csvw", "txt"})
+			page := CreateCensusFilterOutputsPage(req, pageModel, datasetModel, version, false, []dpDatasetApiModels.Version{version}, 1, "/a/version/1", "", []string{}, false, true, filterOutputs, filterDims, serviceMessage, emergencyBanner, true, dimDesc, cantabular.GetBlockedAreaCountResult{}, pop)
+
+			Convey("then the downloads are shown without streaming events", func() {
+				So(page.DatasetLandingPage.HasDownloads, ShouldBeTrue)
+				So(page.DatasetLandingPage.DownloadsEventsURL, ShouldBeEmpty)
+			})
 		})
 	})
 
@@ -161,6 +180,9 @@ func TestSDCOnFilterOutputsPage(t *testing.T) {
 			Convey("then the panel type is 'pending'", func() {
 				So(page.DatasetLandingPage.SDC[0].Type, ShouldEqual, census.Pending)
 			})
+			Convey("then the sdc detail page is linked to", func() {
+				So(page.DatasetLandingPage.SDCDetailURL, ShouldEqual, req.URL.Path+"/sdc")
+			})
 		})
 
 		Convey("when all areas are passing", func() {
@@ -177,6 +199,9 @@ func TestSDCOnFilterOutputsPage(t *testing.T) {
 			Convey("then the panel type is 'pending'", func() {
 				So(page.DatasetLandingPage.SDC[0].Type, ShouldEqual, census.Success)
 			})
+			Convey("then the sdc detail page is not linked to", func() {
+				So(page.DatasetLandingPage.SDCDetailURL, ShouldBeEmpty)
+			})
 		})
 	})
 }
@@ -357,6 +382,31 @@ func TestCreateCensusFilterOutputsDownloads(t *testing.T) {
 			Convey("then HasDownloads set to false", func() {
 				So(page.DatasetLandingPage.HasDownloads, ShouldBeFalse)
 			})
+
+			Convey("and every format is listed without a size until its download is ready", func() {
+				So(page.Version.Downloads, ShouldResemble, []sharedModel.Download{
+					{Extension: "xlsx"}, {Extension: "csv"}, {Extension: "txt"}, {Extension: "csvw"},
+				})
+			})
+		})
+	})
+
+	Convey("given only some downloads are ready", t, func() {
+		filterOutputs := filter.Model{
+			Downloads: getTestFilterDownloads([]string{"xls", "csv"}),
+		}
+
+		Convey("when we build a census landing page", func() {
+			page := CreateCensusFilterOutputsPage(req, pageModel, datasetModel, version, false, []dpDatasetApiModels.Version{version}, 1, "/a/version/1", "", []string{}, false, true, filterOutputs, filterDims, serviceMessage, emergencyBanner, true, population.GetDimensionsResponse{}, cantabular.GetBlockedAreaCountResult{}, population.GetPopulationTypeResponse{})
+
+			Convey("then the formats which are not ready are added after the spreadsheet and csv", func() {
+				So(page.Version.Downloads, ShouldHaveLength, 4)
+				So(page.Version.Downloads[0].Extension, ShouldEqual, "xls")
+				So(page.Version.Downloads[0].Size, ShouldNotBeEmpty)
+				So(page.Version.Downloads[1].Extension, ShouldEqual, "csv")
+				So(page.Version.Downloads[1].Size, ShouldNotBeEmpty)
+				So(page.Version.Downloads[2:], ShouldResemble, []sharedModel.Download{{Extension: "txt"}, {Extension: "csvw"}})
+			})
 		})
 	})
 }
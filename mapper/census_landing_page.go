@@ -104,18 +104,25 @@ func mapCensusOptionsToDimensions(dims []dpDatasetApiModels.Dimension, opts []dp
 		pDim.TotalItems = totalItems
 		midFloor, midCeiling := getTruncationMidRange(totalItems)
 
-		var displayedOptions []dpDatasetApiModels.PublicDimensionOption
-		if pDim.TotalItems > 9 && !helpers.HasStringInSlice(pDim.ID, queryStrValues) {
-			displayedOptions = opt.Items[:3]
-			displayedOptions = append(displayedOptions, opt.Items[midFloor:midCeiling]...)
-			displayedOptions = append(displayedOptions, opt.Items[len(opt.Items)-3:]...)
-			pDim.IsTruncated = true
-		} else {
-			displayedOptions = opt.Items
+		labels := make([]string, 0, totalItems)
+		for i := range opt.Items {
+			labels = append(labels, opt.Items[i].Label)
 		}
 
-		for i := range displayedOptions {
-			pDim.Values = append(pDim.Values, displayedOptions[i].Label)
+		if !summariseTimeDimension(lang, pDim.Name, &pDim, labels) {
+			var displayedOptions []dpDatasetApiModels.PublicDimensionOption
+			if pDim.TotalItems > 9 && !helpers.HasStringInSlice(pDim.ID, queryStrValues) {
+				displayedOptions = opt.Items[:3]
+				displayedOptions = append(displayedOptions, opt.Items[midFloor:midCeiling]...)
+				displayedOptions = append(displayedOptions, opt.Items[len(opt.Items)-3:]...)
+				pDim.IsTruncated = true
+			} else {
+				displayedOptions = opt.Items
+			}
+
+			for i := range displayedOptions {
+				pDim.Values = append(pDim.Values, displayedOptions[i].Label)
+			}
 		}
 
 		q := url.Values{}
@@ -380,3 +380,68 @@ func TestCreateCensusLandingAnalytics(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateCensusLandingPageTimeDimensions(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	pageModel := core.Page{
+		URI: "/",
+	}
+	contacts := getTestContacts()
+	relatedContent := getTestRelatedContent()
+	datasetModel := getTestDatasetDetails(contacts, relatedContent)
+	version := getTestVersionOneDetails()
+	version.Dimensions = append(version.Dimensions, getTestDimension(DimensionTime, false))
+
+	// yearOptions returns the options of the named dimension for a run of years with 2020 missing
+	yearOptions := func(name string) dpDatasetApiSdk.VersionDimensionOptionsList {
+		years := getTestOptions(name, 0)
+		for _, year := range []string{"2011", "2012", "2013", "2014", "2015", "2016", "2017", "2018", "2019", "2021"} {
+			years.Items = append(years.Items, dpDatasetApiModels.PublicDimensionOption{Name: name, Label: year, Option: year})
+		}
+		return years
+	}
+
+	Convey("Given a time dimension whose options are years", t, func() {
+		datasetOptions := []dpDatasetApiSdk.VersionDimensionOptionsList{
+			getTestOptions("1", 21),
+			yearOptions(DimensionTime),
+		}
+
+		Convey("When we build a census landing page", func() {
+			page := CreateCensusLandingPage(pageModel, datasetModel, version, datasetOptions, map[string]int{},
+				[]dpDatasetApiModels.Version{version}, []string{}, true, population.GetPopulationTypeResponse{})
+
+			Convey("Then the years are summarised instead of truncated", func() {
+				dim := page.DatasetLandingPage.Dimensions[3]
+				So(dim.TotalItems, ShouldEqual, 10)
+				So(dim.IsTimeSummary, ShouldBeTrue)
+				So(dim.IsTruncated, ShouldBeFalse)
+				So(dim.Values, ShouldResemble, []string{"All years between 2011 and 2019", "This year contains data for 2021"})
+				So(dim.Gaps, ShouldResemble, []string{"No data is available for 2020"})
+			})
+
+			Convey("And the area type is not summarised", func() {
+				So(page.DatasetLandingPage.Dimensions[1].IsTimeSummary, ShouldBeFalse)
+				So(page.DatasetLandingPage.Dimensions[1].IsTruncated, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a dimension which is not of time whose options are years", t, func() {
+		datasetOptions := []dpDatasetApiSdk.VersionDimensionOptionsList{
+			getTestOptions("1", 21),
+			yearOptions("2"),
+		}
+
+		Convey("When we build a census landing page", func() {
+			page := CreateCensusLandingPage(pageModel, datasetModel, version, datasetOptions, map[string]int{},
+				[]dpDatasetApiModels.Version{version}, []string{}, true, population.GetPopulationTypeResponse{})
+
+			Convey("Then the options are truncated rather than summarised", func() {
+				dim := page.DatasetLandingPage.Dimensions[3]
+				So(dim.IsTimeSummary, ShouldBeFalse)
+				So(dim.IsTruncated, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/ONSdigital/dis-design-system-go/helper"
 	core "github.com/ONSdigital/dis-design-system-go/model"
@@ -20,6 +21,7 @@ func CreateCustomDatasetPage(req *http.Request, basePage core.Page, populationTy
 	p.Metadata.Title = helper.Localise("CreateCustomDatasetTitle", lang, 1)
 	p.Language = lang
 	p.URI = req.URL.Path
+	p.Canonical = buildCanonical(lang, p.SiteDomain, p.URI)
 	p.Metadata.Description = p.Metadata.Title
 
 	// BANNERS
@@ -69,6 +71,58 @@ func CreateCustomDatasetPage(req *http.Request, basePage core.Page, populationTy
 	return p
 }
 
+// CreateConfirmCustomDatasetPage maps the page confirming the population type, area type and dimensions of a custom
+// dataset link, which are submitted to create the custom dataset
+func CreateConfirmCustomDatasetPage(req *http.Request, basePage core.Page, populationType population.PopulationType, areaType population.Dimension, dimensions []population.Dimension, lang, serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) custom.ConfirmPage {
+	p := custom.ConfirmPage{
+		Page: basePage,
+	}
+	MapCookiePreferences(req, &p.CookiesPreferencesSet, &p.CookiesPolicy)
+
+	// PAGE BASICS
+	p.Metadata.Title = helper.Localise("ConfirmCustomDatasetTitle", lang, 1)
+	p.Language = lang
+	p.URI = req.URL.Path
+	p.Metadata.Description = p.Metadata.Title
+
+	// BANNERS
+	p.BetaBannerEnabled = true
+	p.ServiceMessage = serviceMessage
+	p.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+
+	// CENSUS BRANDING
+	p.ShowCensusBranding = true
+
+	// FEEDBACK API
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	// BREADCRUMBS
+	p.Breadcrumb = []core.TaxonomyNode{
+		{
+			Title: "Home",
+			URI:   "/",
+		},
+		{
+			Title: "Census",
+			URI:   "/census",
+		},
+	}
+
+	// PAGE CONTENT
+	p.ConfirmCustomDatasetPage.PopulationType = mapPopulationTypes([]population.PopulationType{populationType})[0]
+	if areaType.ID != "" {
+		p.ConfirmCustomDatasetPage.AreaType = custom.Selection{ID: areaType.ID, Label: areaType.Label}
+	}
+	ids := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		p.ConfirmCustomDatasetPage.Dimensions = append(p.ConfirmCustomDatasetPage.Dimensions, custom.Selection{ID: dimension.ID, Label: dimension.Label})
+		ids = append(ids, dimension.ID)
+	}
+	p.ConfirmCustomDatasetPage.DimensionIDs = strings.Join(ids, ",")
+
+	return p
+}
+
 // mapPopulationTypes maps population.PopulationType to createCensusDatasetPage.PopulationType
 func mapPopulationTypes(populationTypes []population.PopulationType) []custom.PopulationType {
 	mapped := make([]custom.PopulationType, 0, len(populationTypes))
@@ -9,6 +9,7 @@ import (
 	core "github.com/ONSdigital/dis-design-system-go/model"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/custom"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -52,3 +53,43 @@ func TestCreateCustomDataset(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateConfirmCustomDatasetPage(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/datasets/create?populationType=UR", http.NoBody)
+	serviceMessage := getTestServiceMessage()
+	emergencyBanner := getTestEmergencyBanner()
+	populationType := population.PopulationType{Name: "UR", Label: "All usual residents", Description: "Description"}
+
+	Convey("Given the selections of a custom dataset link", t, func() {
+		areaType := population.Dimension{ID: "ltla", Label: "Lower tier local authorities"}
+		dimensions := []population.Dimension{{ID: "sex", Label: "Sex"}, {ID: "age_6a", Label: "Age"}}
+
+		Convey("When the confirmation page is mapped", func() {
+			page := CreateConfirmCustomDatasetPage(req, core.Page{}, populationType, areaType, dimensions, "en", serviceMessage, emergencyBanner)
+
+			Convey("Then the selections are mapped to be shown and submitted", func() {
+				So(page.Metadata.Title, ShouldEqual, "Create a custom dataset with these selections")
+				So(page.URI, ShouldEqual, "/datasets/create")
+				So(page.ConfirmCustomDatasetPage.PopulationType.Name, ShouldEqual, "UR")
+				So(page.ConfirmCustomDatasetPage.PopulationType.Label, ShouldEqual, "All usual residents")
+				So(page.ConfirmCustomDatasetPage.AreaType, ShouldResemble, custom.Selection{ID: "ltla", Label: "Lower tier local authorities"})
+				So(page.ConfirmCustomDatasetPage.Dimensions, ShouldResemble, []custom.Selection{{ID: "sex", Label: "Sex"}, {ID: "age_6a", Label: "Age"}})
+				So(page.ConfirmCustomDatasetPage.DimensionIDs, ShouldEqual, "sex,age_6a")
+			})
+		})
+	})
+
+	Convey("Given a custom dataset link with only a population type", t, func() {
+		Convey("When the confirmation page is mapped", func() {
+			page := CreateConfirmCustomDatasetPage(req, core.Page{}, populationType, population.Dimension{}, nil, "en", serviceMessage, emergencyBanner)
+
+			Convey("Then no area type or dimensions are mapped", func() {
+				So(page.ConfirmCustomDatasetPage.PopulationType.Name, ShouldEqual, "UR")
+				So(page.ConfirmCustomDatasetPage.AreaType.ID, ShouldBeEmpty)
+				So(page.ConfirmCustomDatasetPage.Dimensions, ShouldBeEmpty)
+				So(page.ConfirmCustomDatasetPage.DimensionIDs, ShouldBeEmpty)
+			})
+		})
+	})
+}
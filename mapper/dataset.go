@@ -37,6 +37,9 @@ func CreateDatasetPage(basePage core.Page, req *http.Request, d zebedee.Dataset,
 
 	dp.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
 
+	dp.Canonical = buildCanonical(lang, dp.SiteDomain, dp.URI)
+	dp.SocialMetadata = buildSocialMetadata(lang, dp.Canonical.URL, dlp.Description.Title, dlp.Description.Summary, "")
+
 	for _, breadcrumb := range bc {
 		dp.Breadcrumb = append(dp.Breadcrumb, core.TaxonomyNode{
 			Title: breadcrumb.Description.Title,
@@ -0,0 +1,109 @@
+package mapper
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	dpRendererModel "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/dimensionoptions"
+	"github.com/gorilla/mux"
+)
+
+// paginationWindow is the number of pages either side of the current page that are linked to
+const paginationWindow = 2
+
+// CreateDimensionOptionsPage maps a page of the options of a dimension, which have already been searched and sorted
+func CreateDimensionOptionsPage(basePage dpRendererModel.Page, req *http.Request, datasetDetails dpDatasetApiModels.Dataset, dimension dpDatasetApiModels.Dimension,
+	options []dpDatasetApiModels.PublicDimensionOption, totalOptions int, query, sortBy string, currentPage, totalPages, pageSize int,
+	serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) dimensionoptions.Page {
+	p := dimensionoptions.Page{
+		Page: basePage,
+	}
+	MapCookiePreferences(req, &p.CookiesPreferencesSet, &p.CookiesPolicy)
+
+	vars := mux.Vars(req)
+	versionURL := helpers.DatasetVersionURL(vars["datasetID"], vars["editionID"], vars["versionID"])
+
+	p.Data.DimensionName = dimension.Name
+	p.Data.DimensionTitle = dimension.Label
+	if p.Data.DimensionTitle == "" {
+		p.Data.DimensionTitle = dimension.Name
+	}
+	p.Data.Description = dimension.Description
+	p.Data.DatasetTitle = datasetDetails.Title
+	p.Data.Query = query
+	p.Data.SortBy = sortBy
+	p.Data.TotalOptions = totalOptions
+	p.Data.MatchingOptions = len(options)
+	p.Data.VersionURL = versionURL
+
+	q := url.Values{}
+	if query != "" {
+		q.Set("q", query)
+	}
+	q.Set("sort", sortBy)
+	p.Data.CSVURL = req.URL.Path + ".csv?" + q.Encode()
+
+	start := min((currentPage-1)*pageSize, len(options))
+	end := min(start+pageSize, len(options))
+	for i := range options[start:end] {
+		option := &options[start+i]
+		p.Data.Options = append(p.Data.Options, dimensionoptions.Option{
+			Code:  option.Option,
+			Label: option.Label,
+		})
+	}
+
+	p.Metadata.Title = p.Data.DimensionTitle + " - " + datasetDetails.Title
+	p.DatasetId = datasetDetails.ID
+	p.DatasetTitle = datasetDetails.Title
+	p.URI = req.URL.Path
+	p.Count = len(options)
+	p.Pagination = mapPagination(req.URL.Path, q, currentPage, totalPages, pageSize)
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, p.URI)
+	p.BetaBannerEnabled = true
+	p.ServiceMessage = serviceMessage
+	p.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	return p
+}
+
+// mapPagination returns the pagination for a list, linking to the pages either side of the current page as well
+// as the first and last pages
+func mapPagination(path string, q url.Values, currentPage, totalPages, limit int) dpRendererModel.Pagination {
+	pagination := dpRendererModel.Pagination{
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+		Limit:       limit,
+	}
+	if totalPages == 0 {
+		return pagination
+	}
+
+	pageURL := func(page int) string {
+		pageQuery := url.Values{}
+		for key, values := range q {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("page", strconv.Itoa(page))
+		return path + "?" + pageQuery.Encode()
+	}
+
+	for page := max(1, currentPage-paginationWindow); page <= min(totalPages, currentPage+paginationWindow); page++ {
+		pagination.PagesToDisplay = append(pagination.PagesToDisplay, dpRendererModel.PageToDisplay{
+			PageNumber: page,
+			URL:        pageURL(page),
+		})
+	}
+	pagination.FirstAndLastPages = []dpRendererModel.PageToDisplay{
+		{PageNumber: 1, URL: pageURL(1)},
+		{PageNumber: totalPages, URL: pageURL(totalPages)},
+	}
+
+	return pagination
+}
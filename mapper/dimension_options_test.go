@@ -0,0 +1,100 @@
+package mapper
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateDimensionOptionsPage(t *testing.T) {
+	Convey("Given the searched and sorted options of a dimension", t, func() {
+		options := []dpDatasetApiModels.PublicDimensionOption{}
+		for i := 1; i <= 25; i++ {
+			options = append(options, dpDatasetApiModels.PublicDimensionOption{Option: fmt.Sprint(i), Label: fmt.Sprintf("Area %d", i)})
+		}
+		req := httptest.NewRequest("GET", "/datasets/cpih01/editions/time-series/versions/1/dimensions/geography/options?q=area&page=3", nil)
+		req = mux.SetURLVars(req, map[string]string{"datasetID": "cpih01", "editionID": "time-series", "versionID": "1"})
+		dataset := dpDatasetApiModels.Dataset{ID: "cpih01", Title: "CPIH"}
+		dimension := dpDatasetApiModels.Dimension{Name: "geography", Label: "Geography", Description: "Where"}
+
+		Convey("When the third page of ten is mapped", func() {
+			page := CreateDimensionOptionsPage(core.Page{Language: "en", SiteDomain: "ons.gov.uk"}, req, dataset, dimension, options, 40,
+				"area", "label", 3, 3, 10, "", zebedee.EmergencyBanner{})
+
+			Convey("Then the dimension details are mapped", func() {
+				So(page.Data.DimensionTitle, ShouldEqual, "Geography")
+				So(page.Data.Description, ShouldEqual, "Where")
+				So(page.Data.DatasetTitle, ShouldEqual, "CPIH")
+				So(page.Data.TotalOptions, ShouldEqual, 40)
+				So(page.Data.MatchingOptions, ShouldEqual, 25)
+				So(page.Data.VersionURL, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1")
+				So(page.Data.CSVURL, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1/dimensions/geography/options.csv?q=area&sort=label")
+				So(page.Metadata.Title, ShouldEqual, "Geography - CPIH")
+			})
+
+			Convey("Then only the options on the current page are mapped", func() {
+				So(page.Data.Options, ShouldHaveLength, 5)
+				So(page.Data.Options[0].Code, ShouldEqual, "21")
+				So(page.Data.Options[4].Label, ShouldEqual, "Area 25")
+			})
+
+			Convey("Then the pagination keeps the search and sort order", func() {
+				So(page.Pagination.CurrentPage, ShouldEqual, 3)
+				So(page.Pagination.TotalPages, ShouldEqual, 3)
+				So(page.Pagination.PagesToDisplay, ShouldHaveLength, 3)
+				So(page.Pagination.PagesToDisplay[0].URL, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1/dimensions/geography/options?page=1&q=area&sort=label")
+			})
+		})
+
+		Convey("When the dimension has no label", func() {
+			dimension.Label = ""
+			page := CreateDimensionOptionsPage(core.Page{}, req, dataset, dimension, options, 25, "", "code", 1, 3, 10, "", zebedee.EmergencyBanner{})
+
+			Convey("Then the dimension name is used as the title", func() {
+				So(page.Data.DimensionTitle, ShouldEqual, "geography")
+				So(page.Data.CSVURL, ShouldEqual, "/datasets/cpih01/editions/time-series/versions/1/dimensions/geography/options.csv?sort=code")
+			})
+		})
+	})
+}
+
+func TestMapPagination(t *testing.T) {
+	Convey("Given a list with many pages", t, func() {
+		q := url.Values{"sort": []string{"code"}}
+
+		Convey("When a page in the middle is current", func() {
+			pagination := mapPagination("/options", q, 10, 20, 100)
+
+			Convey("Then the pages either side of it are displayed along with the first and last pages", func() {
+				pageNumbers := []int{}
+				for _, page := range pagination.PagesToDisplay {
+					pageNumbers = append(pageNumbers, page.PageNumber)
+				}
+				So(pageNumbers, ShouldResemble, []int{8, 9, 10, 11, 12})
+				So(pagination.FirstAndLastPages[0].URL, ShouldEqual, "/options?page=1&sort=code")
+				So(pagination.FirstAndLastPages[1].URL, ShouldEqual, "/options?page=20&sort=code")
+				So(pagination.Limit, ShouldEqual, 100)
+			})
+
+			Convey("Then the query values are not modified", func() {
+				So(q, ShouldResemble, url.Values{"sort": []string{"code"}})
+			})
+		})
+
+		Convey("When there are no pages", func() {
+			pagination := mapPagination("/options", q, 1, 0, 100)
+
+			Convey("Then no pages are displayed", func() {
+				So(pagination.PagesToDisplay, ShouldBeEmpty)
+				So(pagination.FirstAndLastPages, ShouldBeEmpty)
+			})
+		})
+	})
+}
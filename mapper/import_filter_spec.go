@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/importspec"
+)
+
+// CreateImportFilterSpecPage maps the page importing the selections of a previously downloaded filter output. When
+// errorKey is set the page shows the localised error with the submitted specification so that it can be corrected.
+func CreateImportFilterSpecPage(basePage core.Page, req *http.Request, lang, spec, specField, errorKey, csrfToken, serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) importspec.Page {
+	p := importspec.Page{
+		Page: basePage,
+	}
+	MapCookiePreferences(req, &p.CookiesPreferencesSet, &p.CookiesPolicy)
+
+	p.Language = lang
+	p.URI = req.URL.Path
+	p.Metadata.Title = helper.Localise("ImportFilterSpecTitle", lang, 1)
+	p.Metadata.Description = p.Metadata.Title
+	p.BetaBannerEnabled = true
+	p.ServiceMessage = serviceMessage
+	p.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	p.Breadcrumb = []core.TaxonomyNode{
+		{
+			Title: "Home",
+			URI:   "/",
+		},
+		{
+			Title: "Census",
+			URI:   "/census",
+		},
+	}
+
+	p.Data.Spec = spec
+	p.Data.SpecField = specField
+	p.Data.CSRFToken = csrfToken
+
+	if errorKey != "" {
+		p.Error = core.Error{
+			Title: helper.Localise(errorKey, lang, 1),
+			ErrorItems: []core.ErrorItem{
+				{
+					Description: core.Localisation{
+						LocaleKey: errorKey,
+						Plural:    1,
+					},
+					URL: "#" + specField,
+				},
+			},
+			Language: lang,
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateImportFilterSpecPage(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/datasets/create/import", http.NoBody)
+	serviceMessage := getTestServiceMessage()
+	emergencyBanner := getTestEmergencyBanner()
+
+	Convey("Given the import form is requested", t, func() {
+		Convey("When the page is mapped", func() {
+			page := CreateImportFilterSpecPage(core.Page{}, req, "en", "", "spec", "", "token", serviceMessage, emergencyBanner)
+
+			Convey("Then an empty form is mapped without an error", func() {
+				So(page.Metadata.Title, ShouldEqual, "Import dataset selections")
+				So(page.URI, ShouldEqual, "/datasets/create/import")
+				So(page.ServiceMessage, ShouldEqual, serviceMessage)
+				So(page.Data.Spec, ShouldBeEmpty)
+				So(page.Data.SpecField, ShouldEqual, "spec")
+				So(page.Data.CSRFToken, ShouldEqual, "token")
+				So(page.Error.Title, ShouldBeEmpty)
+				So(page.Error.ErrorItems, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a specification which could not be imported", t, func() {
+		Convey("When the page is mapped", func() {
+			page := CreateImportFilterSpecPage(core.Page{}, req, "en", `{"spec_version": `, "spec", "ImportFilterSpecErrorUnreadable", "token", serviceMessage, emergencyBanner)
+
+			Convey("Then the localised error links to the field holding the submitted specification", func() {
+				So(page.Data.Spec, ShouldEqual, `{"spec_version": `)
+				So(page.Error.Title, ShouldEqual, "Enter the contents of a selections file")
+				So(page.Error.ErrorItems, ShouldHaveLength, 1)
+				So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "ImportFilterSpecErrorUnreadable")
+				So(page.Error.ErrorItems[0].URL, ShouldEqual, "#spec")
+			})
+		})
+	})
+}
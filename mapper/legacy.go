@@ -52,6 +52,9 @@ func CreateLegacyDatasetLanding(ctx context.Context, basePage core.Page, req *ht
 
 	sdlp.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
 
+	sdlp.Canonical = buildCanonical(localeCode, sdlp.SiteDomain, sdlp.URI)
+	sdlp.SocialMetadata = buildSocialMetadata(localeCode, sdlp.Canonical.URL, dlp.Description.Title, dlp.Description.Summary, "")
+
 	if navigationContent != nil {
 		sdlp.NavigationContent = MapNavigationContent(*navigationContent)
 	}
@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"html"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+)
+
+// lintPanels are the panels failures of each severity are shown in, with the locale key of their introduction
+var lintPanels = []struct {
+	severity  lint.Severity
+	panelType static.PanelType
+	localeKey string
+}{
+	{severity: lint.SeverityError, panelType: static.Error, localeKey: "LintErrors"},
+	{severity: lint.SeverityWarning, panelType: static.Pending, localeKey: "LintWarnings"},
+}
+
+// MapLintPanels maps the metadata lint failures of a static dataset version to the panels shown to publishers at the
+// top of the version page, one for errors and one for warnings
+func MapLintPanels(failures []lint.Failure, lang string) []static.Panel {
+	failures = localiseLintFailures(failures, lang)
+
+	var panels []static.Panel
+	for _, lp := range lintPanels {
+		var items strings.Builder
+		for _, f := range failures {
+			if f.Severity == lp.severity {
+				items.WriteString("<li class=\"ons-list__item\">" + html.EscapeString(f.Message) + "</li>")
+			}
+		}
+		if items.Len() == 0 {
+			continue
+		}
+
+		panels = append(panels, static.Panel{
+			Type: lp.panelType,
+			Body: []string{
+				"<p>" + helper.Localise(lp.localeKey, lang, 1) + "</p>",
+				"<ul class=\"ons-list\">" + items.String() + "</ul>",
+			},
+			CSSClasses: []string{"ons-u-mt-m", "ons-u-mb-l"},
+			Language:   lang,
+		})
+	}
+	return panels
+}
+
+// MapLintReport maps the metadata lint failures of a static dataset version to the report returned for automated QA
+func MapLintReport(datasetID string, version dpDatasetApiModels.Version, failures []lint.Failure, lang string) lint.Report {
+	failures = localiseLintFailures(failures, lang)
+	if failures == nil {
+		failures = []lint.Failure{}
+	}
+
+	return lint.Report{
+		DatasetID: datasetID,
+		Edition:   version.Edition,
+		Version:   version.Version,
+		State:     version.State,
+		Passed:    len(failures) == 0,
+		Failures:  failures,
+	}
+}
+
+// localiseLintFailures returns a copy of the failures with their messages, naming any parts of the metadata which failed
+func localiseLintFailures(failures []lint.Failure, lang string) []lint.Failure {
+	if failures == nil {
+		return nil
+	}
+
+	localised := make([]lint.Failure, 0, len(failures))
+	for _, f := range failures {
+		f.Message = helper.Localise(f.LocaleKey, lang, 1)
+		if len(f.Subjects) > 0 {
+			f.Message += ": " + strings.Join(f.Subjects, ", ")
+		}
+		localised = append(localised, f)
+	}
+	return localised
+}
@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/lint"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMapLintPanels(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given errors and warnings from checking a version's metadata", t, func() {
+		failures := []lint.Failure{
+			{Rule: lint.RuleQMI, Severity: lint.SeverityError, LocaleKey: "LintQMI"},
+			{Rule: lint.RuleUsageNotes, Severity: lint.SeverityWarning, Subjects: []string{"<b>Revisions</b>"}, LocaleKey: "LintUsageNotes"},
+		}
+
+		Convey("When they are mapped to panels", func() {
+			panels := MapLintPanels(failures, "en")
+
+			Convey("Then errors are shown in an error panel and warnings in a pending panel", func() {
+				So(panels, ShouldHaveLength, 2)
+				So(panels[0].Type, ShouldEqual, static.Error)
+				So(panels[0].Body, ShouldResemble, []string{
+					"<p>Fix these problems before this version is published:</p>",
+					"<ul class=\"ons-list\"><li class=\"ons-list__item\">There is no link to a Quality and Methodology Information (QMI) report</li></ul>",
+				})
+				So(panels[1].Type, ShouldEqual, static.Pending)
+			})
+
+			Convey("Then the parts of the metadata which failed are named and escaped", func() {
+				So(panels[1].Body[1], ShouldContainSubstring, "Usage notes are missing or empty: &lt;b&gt;Revisions&lt;/b&gt;")
+			})
+		})
+	})
+
+	Convey("Given only warnings", t, func() {
+		panels := MapLintPanels([]lint.Failure{{Rule: lint.RuleNextRelease, Severity: lint.SeverityWarning, LocaleKey: "LintNextRelease"}}, "cy")
+
+		Convey("Then only a localised pending panel is shown", func() {
+			So(panels, ShouldHaveLength, 1)
+			So(panels[0].Type, ShouldEqual, static.Pending)
+			So(panels[0].Body[0], ShouldEqual, "<p>Gwiriwch y rhain cyn i'r fersiwn hon gael ei chyhoeddi:</p>")
+		})
+	})
+
+	Convey("Given no failures", t, func() {
+		Convey("Then no panels are shown", func() {
+			So(MapLintPanels(nil, "en"), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestMapLintReport(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	version := dpDatasetApiModels.Version{Edition: "time-series", Version: 3, State: "associated"}
+
+	Convey("Given a version with distributions which have no size", t, func() {
+		failures := []lint.Failure{{Rule: lint.RuleDistributionSize, Severity: lint.SeverityError, Subjects: []string{"CSV", "XLSX"}, LocaleKey: "LintDistributionSize"}}
+
+		Convey("Then the report fails with a message naming the distributions", func() {
+			report := MapLintReport("cpih01", version, failures, "en")
+			So(report.Passed, ShouldBeFalse)
+			So(report.DatasetID, ShouldEqual, "cpih01")
+			So(report.Edition, ShouldEqual, "time-series")
+			So(report.Version, ShouldEqual, 3)
+			So(report.Failures[0].Message, ShouldEqual, "Downloads have no file size: CSV, XLSX")
+		})
+	})
+
+	Convey("Given a version with complete metadata", t, func() {
+		Convey("Then the report passes with an empty list of failures", func() {
+			report := MapLintReport("cpih01", version, nil, "en")
+			So(report.Passed, ShouldBeTrue)
+			So(report.Failures, ShouldNotBeNil)
+			So(report.Failures, ShouldBeEmpty)
+		})
+	})
+}
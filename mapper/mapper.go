@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/ONSdigital/dp-frontend-dataset-controller/config"
 	datasetMdl "github.com/ONSdigital/dp-frontend-dataset-controller/model/dataset"
@@ -25,6 +24,7 @@ import (
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
 	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-net/v3/request"
 
 	dpRendererModel "github.com/ONSdigital/dis-design-system-go/model"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/version"
@@ -32,9 +32,6 @@ import (
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
-// TimeSlice allows sorting of a list of time.Time
-type TimeSlice []time.Time
-
 // Constants names
 const (
 	DimensionTime      = "time"
@@ -47,16 +44,12 @@ var (
 	cfg, _ = config.Get()
 )
 
-func (p TimeSlice) Len() int {
-	return len(p)
-}
-
-func (p TimeSlice) Less(i, j int) bool {
-	return p[i].Before(p[j])
-}
-
-func (p TimeSlice) Swap(i, j int) {
-	p[i], p[j] = p[j], p[i]
+// defaultLanguage returns the default language of the service, which is the first of the supported languages
+func defaultLanguage() string {
+	if len(cfg.SupportedLanguages) == 0 {
+		return request.DefaultLang
+	}
+	return cfg.SupportedLanguages[0]
 }
 
 // Trim API version path prefix from breadcrumb URI, if present.
@@ -254,9 +247,12 @@ func CreateFilterableLandingPage(ctx context.Context, basePage dpRendererModel.P
 	p.DatasetLandingPage.Version = v
 
 	if len(opts) > 0 {
-		p.DatasetLandingPage.Dimensions = mapOptionsToDimensions(ctx, d.Type, dims, opts, latestVersionURL, maxNumOpts)
+		p.DatasetLandingPage.Dimensions = mapOptionsToDimensions(ctx, p.Language, d.Type, dims, opts, latestVersionURL, maxNumOpts)
 	}
 
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, p.URI)
+	p.SocialMetadata = buildSocialMetadata(p.Language, p.Canonical.URL, d.Title, d.Description, "")
+
 	return p
 }
 
@@ -281,6 +277,7 @@ func CreateVersionsList(basePage dpRendererModel.Page, req *http.Request, datase
 	}
 	p.DatasetId = datasetDetails.ID
 	p.URI = req.URL.Path
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, p.URI)
 	p.FeatureFlags.SixteensVersion = SixteensVersion
 
 	p.ServiceMessage = serviceMessage
@@ -385,11 +382,14 @@ func CreateEditionsList(ctx context.Context, basePage dpRendererModel.Page, req
 	// Prepares table of components object for use in dis-design-system-go
 	p.TableOfContents = buildEditionsListTableOfContents(d)
 
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, p.URI)
+	p.SocialMetadata = buildSocialMetadata(p.Language, p.Canonical.URL, d.Title, d.Description, "")
+
 	return p
 }
 
 // CreateEditionsListForStaticDatasetType creates an editions list page when dataset type is static, based on api model responses
-func CreateEditionsListForStaticDatasetType(ctx context.Context, basePage dpRendererModel.Page, req *http.Request, d dpDatasetApiModels.Dataset, editions dpDatasetApiSdk.EditionsList, datasetID, apiRouterVersion string, topicObjectList []*dpTopicApiModels.Topic) edition.Page {
+func CreateEditionsListForStaticDatasetType(ctx context.Context, basePage dpRendererModel.Page, req *http.Request, d dpDatasetApiModels.Dataset, editions dpDatasetApiSdk.EditionsList, datasetID, apiRouterVersion string, topicObjectList []*dpTopicApiModels.Topic, entryTopicSlug string) edition.Page {
 	p := edition.Page{
 		Page: basePage,
 	}
@@ -409,8 +409,12 @@ func CreateEditionsListForStaticDatasetType(ctx context.Context, basePage dpRend
 	p.NextRelease = d.NextRelease
 	p.DatasetID = datasetID
 
+	// Links stay within the topic the user came in through, whereas the canonical URL always uses the primary topic
+	primaryTopicSlug := topicObjectList[0].Slug
+	topicSlug := getEntryTopicSlug(topicObjectList, entryTopicSlug)
+
 	// BREADCRUMBS
-	p.Breadcrumb = CreateBreadcrumbsFromTopicList(topicObjectList)
+	p.Breadcrumb = CreateBreadcrumbsForEntryTopic(topicObjectList, topicSlug)
 
 	// Get editions list
 	editionItems := editions.Items
@@ -418,7 +422,7 @@ func CreateEditionsListForStaticDatasetType(ctx context.Context, basePage dpRend
 		for i := range editionItems {
 			var el edition.List
 			el.Title = editionItems[i].Edition
-			el.LatestVersionURL = helpers.DatasetVersionURLWithTopic(topicObjectList[0].Slug, datasetID, editionItems[i].Edition, editionItems[i].Links.LatestVersion.ID)
+			el.LatestVersionURL = helpers.DatasetVersionURLWithTopic(topicSlug, datasetID, editionItems[i].Edition, editionItems[i].Links.LatestVersion.ID)
 			p.Editions = append(p.Editions, el)
 		}
 	}
@@ -426,6 +430,9 @@ func CreateEditionsListForStaticDatasetType(ctx context.Context, basePage dpRend
 	// Prepares table of components object for use in dis-design-system-go
 	p.TableOfContents = buildEditionsListTableOfContents(d)
 
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, getCanonicalTopicPath(p.URI, topicSlug, primaryTopicSlug))
+	p.SocialMetadata = buildSocialMetadata(p.Language, p.Canonical.URL, d.Title, d.Description, primaryTopicSlug)
+
 	// ANALYTICS
 	p.PreGTMJavaScript = append(
 		p.PreGTMJavaScript,
@@ -467,7 +474,7 @@ func mapCorrectionAlert(ver *dpDatasetApiModels.Version, model *sharedModel.Vers
 }
 
 //nolint:all // legacy code with poor test coverage
-func mapOptionsToDimensions(ctx context.Context, datasetType string, dims dpDatasetApiSdk.VersionDimensionsList, opts []dpDatasetApiSdk.VersionDimensionOptionsList, latestVersionURL string, maxNumberOfOptions int) []sharedModel.Dimension {
+func mapOptionsToDimensions(ctx context.Context, lang, datasetType string, dims dpDatasetApiSdk.VersionDimensionsList, opts []dpDatasetApiSdk.VersionDimensionOptionsList, latestVersionURL string, maxNumberOfOptions int) []sharedModel.Dimension {
 	dimensions := []sharedModel.Dimension{}
 	for _, opt := range opts {
 		var pDim sharedModel.Dimension
@@ -497,76 +504,12 @@ func mapOptionsToDimensions(ctx context.Context, datasetType string, dims dpData
 			pDim.OptionsURL = fmt.Sprintf("%s/dimensions/%s/options", versionURL.Path, opt.Items[0].Name)
 			pDim.TotalItems = totalCount
 
-			if _, err = time.Parse("Jan-06", opt.Items[0].Label); err == nil {
-				var ts TimeSlice
-				for _, val := range opt.Items {
-					t, err := convertMMMYYToTime(val.Label)
-					if err != nil {
-						log.Warn(ctx, "unable to convert date (MMYY) to time", log.FormatErrors([]error{err}), log.Data{"label": val.Label})
-					}
-					ts = append(ts, t)
-				}
-				sort.Sort(ts)
-
-				if len(ts) > 0 {
-					startDate := ts[0]
-
-					for i, t := range ts {
-						if i != len(ts)-1 {
-							if ((ts[i+1].Month() - t.Month()) == 1) || (t.Month() == 12 && ts[i+1].Month() == 1) {
-								continue
-							}
-							if startDate.Year() == t.Year() && startDate.Month().String() == t.Month().String() {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("This year %d contains data for the month %s", startDate.Year(), startDate.Month().String()))
-							} else {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("All months between %s %d and %s %d", startDate.Month().String(), startDate.Year(), t.Month().String(), t.Year()))
-							}
-							startDate = ts[i+1]
-						} else {
-							if startDate.Year() == t.Year() && startDate.Month().String() == t.Month().String() {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("This year %d contains data for the month %s", startDate.Year(), startDate.Month().String()))
-							} else {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("All months between %s %d and %s %d", startDate.Month().String(), startDate.Year(), t.Month().String(), t.Year()))
-							}
-						}
-					}
-				}
-			} else if _, err = time.Parse("2006", opt.Items[0].Label); err == nil {
-				var ts TimeSlice
-				for _, val := range opt.Items {
-					t, err := convertYYYYToTime(val.Label)
-					if err != nil {
-						log.Warn(ctx, "unable to convert date (YYYY) to time", log.FormatErrors([]error{err}), log.Data{"label": val.Label})
-					}
-					ts = append(ts, t)
-				}
-				sort.Sort(ts)
-
-				if len(ts) > 0 {
-					startDate := ts[0]
-
-					for i, t := range ts {
-						if i != len(ts)-1 {
-							if (ts[i+1].Year() - t.Year()) == 1 {
-								continue
-							}
-							if startDate.Year() == t.Year() {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("This year contains data for %d", startDate.Year()))
-							} else {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("All years between %d and %d", startDate.Year(), t.Year()))
-							}
-							startDate = ts[i+1]
-						} else {
-
-							if startDate.Year() == t.Year() {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("This year contains data for %d", startDate.Year()))
-							} else {
-								pDim.Values = append(pDim.Values, fmt.Sprintf("All years between %d and %d", startDate.Year(), t.Year()))
-							}
-						}
-					}
-				}
-			} else {
+			labels := make([]string, 0, totalCount)
+			for _, val := range opt.Items {
+				labels = append(labels, val.Label)
+			}
+
+			if !summariseTimeDimension(lang, opt.Items[0].Name, &pDim, labels) {
 				for i, val := range opt.Items {
 					if totalCount > maxNumberOfOptions {
 						if i > 9 {
@@ -602,14 +545,6 @@ func mapOptionsToDimensions(ctx context.Context, datasetType string, dims dpData
 	return dimensions
 }
 
-func convertMMMYYToTime(input string) (t time.Time, err error) {
-	return time.Parse("Jan-06", input)
-}
-
-func convertYYYYToTime(input string) (t time.Time, err error) {
-	return time.Parse("2006", input)
-}
-
 // MapCookiePreferences reads cookie policy and preferences cookies and then maps the values to the page model
 func MapCookiePreferences(req *http.Request, preferencesIsSet *bool, policy *dpRendererModel.CookiesPolicy) {
 	preferencesCookie := cookies.GetONSCookiePreferences(req)
@@ -759,3 +694,33 @@ func CreateBreadcrumbsFromTopicList(topicObjectList []*dpTopicApiModels.Topic) [
 	}
 	return breadcrumbsObject
 }
+
+// CreateBreadcrumbsForEntryTopic builds the breadcrumbs for a static dataset page reached through the topic with the
+// given slug. Pages reached through a secondary topic show the topic list up to and including that topic, with the
+// same nested URIs as the full topic list, which is shown otherwise.
+func CreateBreadcrumbsForEntryTopic(topicObjectList []*dpTopicApiModels.Topic, entryTopicSlug string) []dpRendererModel.TaxonomyNode {
+	for i := 1; i < len(topicObjectList); i++ {
+		if topicObjectList[i].Slug == entryTopicSlug {
+			return CreateBreadcrumbsFromTopicList(topicObjectList[:i+1])
+		}
+	}
+	return CreateBreadcrumbsFromTopicList(topicObjectList)
+}
+
+// getEntryTopicSlug returns entryTopicSlug if it belongs to one of the dataset's topics, otherwise the primary topic slug
+func getEntryTopicSlug(topicObjectList []*dpTopicApiModels.Topic, entryTopicSlug string) string {
+	for _, topicObject := range topicObjectList {
+		if topicObject.Slug == entryTopicSlug {
+			return entryTopicSlug
+		}
+	}
+	return topicObjectList[0].Slug
+}
+
+// getCanonicalTopicPath returns the path of a page reached through a secondary topic under the primary topic instead
+func getCanonicalTopicPath(urlPath, entryTopicSlug, primaryTopicSlug string) string {
+	if entryTopicSlug == primaryTopicSlug {
+		return urlPath
+	}
+	return helpers.ReplaceFirstPathSegment(urlPath, primaryTopicSlug)
+}
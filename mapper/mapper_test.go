@@ -9,16 +9,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-design-system-go/helper"
 	dpRendererModel "github.com/ONSdigital/dis-design-system-go/model"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	dpDatasetApiSdk "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
 	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 	dpTopicApiModels "github.com/ONSdigital/dp-topic-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
 func TestUnitMapper(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
 	ctx := context.Background()
 	mdl := dpRendererModel.Page{}
 
@@ -393,6 +396,8 @@ func TestUnitMapper(t *testing.T) {
 		So(p.DatasetLandingPage.Dimensions[0].Title, ShouldEqual, "Time")
 		So(p.DatasetLandingPage.Dimensions[0].Values[0], ShouldEqual, "This year 2005 contains data for the month January")
 		So(p.DatasetLandingPage.Dimensions[0].Values[1], ShouldEqual, "All months between May 2007 and June 2007")
+		So(p.DatasetLandingPage.Dimensions[0].Gaps, ShouldResemble, []string{"No data is available between February 2005 and April 2007"})
+		So(p.DatasetLandingPage.Dimensions[0].IsTimeSummary, ShouldBeTrue)
 	})
 
 	Convey("test time dimensions for CreateFilterableLandingPage ", t, func() {
@@ -430,6 +435,40 @@ func TestUnitMapper(t *testing.T) {
 		So(p.DatasetLandingPage.Dimensions[0].Title, ShouldEqual, "Time")
 		So(p.DatasetLandingPage.Dimensions[0].Values[0], ShouldEqual, "This year contains data for 2016")
 		So(p.DatasetLandingPage.Dimensions[0].Values[1], ShouldEqual, "All years between 2018 and 2020")
+		So(p.DatasetLandingPage.Dimensions[0].Gaps, ShouldResemble, []string{"No data is available for 2017"})
+	})
+
+	Convey("test time dimensions are localised for CreateFilterableLandingPage in Welsh", t, func() {
+		welshMdl := dpRendererModel.Page{Language: "cy"}
+		options := []dpDatasetApiSdk.VersionDimensionOptionsList{
+			{
+				Items: []dpDatasetApiModels.PublicDimensionOption{
+					{Name: "time", Label: "Jan-05", Option: "Jan-05"},
+					{Name: "time", Label: "May-07", Option: "May-07"},
+					{Name: "time", Label: "Jun-07", Option: "Jun-07"},
+				},
+			},
+			{
+				Items: []dpDatasetApiModels.PublicDimensionOption{
+					{Name: "time", Label: "2016", Option: "2016"},
+					{Name: "time", Label: "2018", Option: "2018"},
+					{Name: "time", Label: "2019", Option: "2019"},
+					{Name: "time", Label: "2020", Option: "2020"},
+				},
+			},
+		}
+		p := CreateFilterableLandingPage(ctx, welshMdl, d, v[0], datasetID, options, dpDatasetApiSdk.VersionDimensionsList{}, false, []zebedee.Breadcrumb{},
+			1, "/datasets/83jd98fkflg/editions/124/versions/1", "/v1", 50)
+
+		So(p.DatasetLandingPage.Dimensions, ShouldHaveLength, 2)
+		So(p.DatasetLandingPage.Dimensions[0].Values, ShouldResemble, []string{
+			"Mae'r flwyddyn 2005 yn cynnwys data ar gyfer mis Ionawr",
+			"Pob mis rhwng Mai 2007 a Mehefin 2007",
+		})
+		So(p.DatasetLandingPage.Dimensions[1].Values, ShouldResemble, []string{
+			"Mae'r flwyddyn hon yn cynnwys data ar gyfer 2016",
+			"Pob blwyddyn rhwng 2018 a 2020",
+		})
 	})
 }
 
@@ -684,6 +723,46 @@ func TestCreateBreadcrumbsFromTopicList(t *testing.T) {
 	})
 }
 
+func TestCreateBreadcrumbsForEntryTopic(t *testing.T) {
+	Convey("Given a topicObjectList with a primary and secondary topics", t, func() {
+		topicObjectList := []*dpTopicApiModels.Topic{
+			{Title: "Topic One", Slug: "slug1"},
+			{Title: "Topic Two", Slug: "slug2"},
+			{Title: "Topic Three", Slug: "slug3"},
+		}
+
+		Convey("When the page is reached through the primary topic", func() {
+			breadcrumbObject := CreateBreadcrumbsForEntryTopic(topicObjectList, "slug1")
+
+			Convey("Then the breadcrumbs contain the full topic list", func() {
+				So(breadcrumbObject, ShouldResemble, CreateBreadcrumbsFromTopicList(topicObjectList))
+			})
+		})
+
+		Convey("When the page is reached through the secondary topic", func() {
+			breadcrumbObject := CreateBreadcrumbsForEntryTopic(topicObjectList, "slug2")
+
+			Convey("Then the breadcrumbs follow the topic list as far as the secondary topic", func() {
+				So(breadcrumbObject, ShouldHaveLength, 3)
+				So(breadcrumbObject[0].Title, ShouldEqual, "Home")
+				So(breadcrumbObject[0].URI, ShouldEqual, "/")
+				So(breadcrumbObject[1].Title, ShouldEqual, "Topic One")
+				So(breadcrumbObject[1].URI, ShouldEqual, "/slug1")
+				So(breadcrumbObject[2].Title, ShouldEqual, "Topic Two")
+				So(breadcrumbObject[2].URI, ShouldEqual, "/slug1/slug2")
+			})
+		})
+
+		Convey("When the page is reached through an unknown topic", func() {
+			breadcrumbObject := CreateBreadcrumbsForEntryTopic(topicObjectList, "unknown")
+
+			Convey("Then the breadcrumbs contain the full topic list", func() {
+				So(breadcrumbObject, ShouldResemble, CreateBreadcrumbsFromTopicList(topicObjectList))
+			})
+		})
+	})
+}
+
 func TestSetGTMDataLayerValuesForStaticEditionList(t *testing.T) {
 	Convey("Given a static dataset with a topic", t, func() {
 		dataset := dpDatasetApiModels.Dataset{
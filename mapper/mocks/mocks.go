@@ -23,10 +23,168 @@ var cyLocale = []string{
 	"[SDCAllAreasAvailable]",
 	"one = \"1 area available\"",
 	"other = \"All 10 areas available\"",
+	"[SDCDetailTitle]",
+	"one = \"Restricted areas in this dataset\"",
+	"[ApproveConfirmTitle]",
+	"one = \"Cymeradwyo'r fersiwn hon\"",
+	"[RejectConfirmTitle]",
+	"one = \"Gwrthod y fersiwn hon\"",
+	"[ApproveConfirmBody]",
+	"one = \"Rydych ar fin cymeradwyo {{.arg0}}, rhifyn {{.arg1}}, fersiwn {{.arg2}}.\"",
+	"[ApproveConfirmWarning]",
+	"one = \"Ar ôl ei chymeradwyo, caiff y fersiwn ei chyhoeddi gyda'i chasgliad.\"",
+	"[ApproveConfirmButton]",
+	"one = \"Cymeradwyo\"",
+	"[RejectConfirmBody]",
+	"one = \"Rydych ar fin gwrthod {{.arg0}}, rhifyn {{.arg1}}, fersiwn {{.arg2}}.\"",
+	"[RejectConfirmWarning]",
+	"one = \"Bydd y fersiwn yn mynd yn ôl i fod yn ddrafft er mwyn gallu ei chywiro a'i chyflwyno i'w chymeradwyo eto.\"",
+	"[RejectConfirmButton]",
+	"one = \"Gwrthod\"",
+	"[VersionStateReasonRequired]",
+	"one = \"Rhowch reswm\"",
+	"[RejectionSucceeded]",
+	"one = \"Mae'r fersiwn hon wedi'i gwrthod a'i dychwelyd i fod yn ddrafft.\"",
+	"[LintErrors]",
+	"one = \"Cywirwch y problemau hyn cyn i'r fersiwn hon gael ei chyhoeddi:\"",
+	"[LintWarnings]",
+	"one = \"Gwiriwch y rhain cyn i'r fersiwn hon gael ei chyhoeddi:\"",
+	"[LintContacts]",
+	"one = \"Nid oes gan unrhyw gyswllt gyfeiriad e-bost na rhif ffôn\"",
+	"[LintQMI]",
+	"one = \"Nid oes dolen i adroddiad Gwybodaeth am Ansawdd a Methodoleg (QMI)\"",
+	"[LintUsageNotes]",
+	"one = \"Mae nodiadau defnydd ar goll neu'n wag\"",
+	"[LintNextRelease]",
+	"one = \"Nid oes dyddiad rhyddhau nesaf\"",
+	"[LintDistributionSize]",
+	"one = \"Nid oes maint ffeil gan y lawrlwythiadau\"",
+	"[VersionDiffIntro]",
+	"one = \"O'i chymharu â fersiwn gyhoeddedig {{.arg0}}, mae'r fersiwn hon yn newid:\"",
+	"[VersionDiffNone]",
+	"one = \"Nid yw'r fersiwn hon yn newid teitl, disgrifiad, lawrlwythiadau, rhybuddion, nodiadau defnydd na dyddiad rhyddhau fersiwn gyhoeddedig {{.arg0}}.\"",
+	"[VersionDiffNoPublished]",
+	"one = \"Nid oes fersiwn o'r rhifyn hwn wedi'i chyhoeddi eto, felly nid oes dim i gymharu'r fersiwn hon ag ef.\"",
+	"[VersionDiffShowFull]",
+	"one = \"Dangos pob gwahaniaeth\"",
+	"[VersionDiffChanged]",
+	"one = \"{{.arg0}} wedi newid\"",
+	"[VersionDiffCounts]",
+	"one = \"{{.arg0}}: {{.arg1}} wedi'u hychwanegu, {{.arg2}} wedi'u dileu\"",
+	"[VersionDiffRemoved]",
+	"one = \"Wedi'i ddileu\"",
+	"[VersionDiffAdded]",
+	"one = \"Wedi'i ychwanegu\"",
+	"[VersionDiffTitle]",
+	"one = \"Teitl\"",
+	"[VersionDiffDescription]",
+	"one = \"Disgrifiad\"",
+	"[VersionDiffDistributions]",
+	"one = \"Lawrlwythiadau\"",
+	"[VersionDiffAlerts]",
+	"one = \"Rhybuddion\"",
+	"[VersionDiffUsageNotes]",
+	"one = \"Nodiadau defnydd\"",
+	"[VersionDiffReleaseDate]",
+	"one = \"Dyddiad rhyddhau\"",
+	"[TooManyRequestsTitle]",
+	"one = \"Gormod o geisiadau\"",
+	"[TooManyRequestsDescription]",
+	"one = \"Rydych wedi gwneud gormod o geisiadau i greu set ddata mewn cyfnod byr. Arhoswch {{.arg0}} eiliad a rhowch gynnig arall arni.\"",
+	"[ForbiddenTitle]",
+	"one = \"Nid oedd modd anfon y ffurflen\"",
+	"[ForbiddenDescription]",
+	"one = \"Nid oedd modd anfon eich ffurflen yn ddiogel. Ewch yn ôl i'r dudalen, adnewyddwch hi a rhowch gynnig arall arni.\"",
+	"[ApprovalSucceeded]",
+	"one = \"Mae'r fersiwn hon wedi'i chymeradwyo.\"",
+	"[ApprovalFailed]",
+	"one = \"Nid oedd modd cymeradwyo'r fersiwn hon. Rhowch gynnig arall arni yn nes ymlaen.\"",
 	"[CreateCustomDatasetTitle]",
 	"one = \"Create a custom dataset\"",
 	"[CustomDatasetSummary]",
 	"one = \"This is a custom dataset\"",
+	"[TimeCoverageMonths]",
+	"one = \"Mae'r flwyddyn {{.arg1}} yn cynnwys data ar gyfer mis {{.arg0}}\"",
+	"two = \"Pob mis rhwng {{.arg0}} {{.arg1}} a {{.arg2}} {{.arg3}}\"",
+	"few = \"Pob mis rhwng {{.arg0}} {{.arg1}} a {{.arg2}} {{.arg3}}\"",
+	"many = \"Pob mis rhwng {{.arg0}} {{.arg1}} a {{.arg2}} {{.arg3}}\"",
+	"other = \"Pob mis rhwng {{.arg0}} {{.arg1}} a {{.arg2}} {{.arg3}}\"",
+	"[TimeCoverageYears]",
+	"one = \"Mae'r flwyddyn hon yn cynnwys data ar gyfer {{.arg0}}\"",
+	"two = \"Pob blwyddyn rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Pob blwyddyn rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Pob blwyddyn rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Pob blwyddyn rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimestampMonthJanuary]",
+	"one = \"Ionawr\"",
+	"[TimestampMonthFebruary]",
+	"one = \"Chwefror\"",
+	"[TimestampMonthMarch]",
+	"one = \"Mawrth\"",
+	"[TimestampMonthApril]",
+	"one = \"Ebrill\"",
+	"[TimestampMonthMay]",
+	"one = \"Mai\"",
+	"[TimestampMonthJune]",
+	"one = \"Mehefin\"",
+	"[TimestampMonthJuly]",
+	"one = \"Gorffennaf\"",
+	"[TimestampMonthAugust]",
+	"one = \"Awst\"",
+	"[TimestampMonthSeptember]",
+	"one = \"Medi\"",
+	"[TimestampMonthOctober]",
+	"one = \"Hydref\"",
+	"[TimestampMonthNovember]",
+	"one = \"Tachwedd\"",
+	"[TimestampMonthDecember]",
+	"one = \"Rhagfyr\"",
+	"[TimeCoverageQuarters]",
+	"one = \"Mae'r set ddata hon yn cynnwys data ar gyfer {{.arg0}}\"",
+	"two = \"Pob chwarter rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Pob chwarter rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Pob chwarter rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Pob chwarter rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimeCoverageFinancialYears]",
+	"one = \"Mae'r set ddata hon yn cynnwys data ar gyfer y flwyddyn ariannol {{.arg0}}\"",
+	"two = \"Pob blwyddyn ariannol rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Pob blwyddyn ariannol rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Pob blwyddyn ariannol rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Pob blwyddyn ariannol rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimeCoverageWeeks]",
+	"one = \"Mae'r set ddata hon yn cynnwys data ar gyfer {{.arg0}}\"",
+	"two = \"Pob wythnos rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Pob wythnos rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Pob wythnos rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Pob wythnos rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimeCoverageDays]",
+	"one = \"Mae'r set ddata hon yn cynnwys data ar gyfer {{.arg0}}\"",
+	"two = \"Pob diwrnod rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Pob diwrnod rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Pob diwrnod rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Pob diwrnod rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimeCoverageGap]",
+	"one = \"Nid oes data ar gael ar gyfer {{.arg0}}\"",
+	"two = \"Nid oes data ar gael rhwng {{.arg0}} a {{.arg1}}\"",
+	"few = \"Nid oes data ar gael rhwng {{.arg0}} a {{.arg1}}\"",
+	"many = \"Nid oes data ar gael rhwng {{.arg0}} a {{.arg1}}\"",
+	"other = \"Nid oes data ar gael rhwng {{.arg0}} a {{.arg1}}\"",
+	"[TimeLabelQuarter]",
+	"one = \"Ch{{.arg0}} {{.arg1}}\"",
+	"[TimeLabelWeek]",
+	"one = \"wythnos {{.arg0}} {{.arg1}}\"",
+	"[ConfirmCustomDatasetTitle]",
+	"one = \"Creu set ddata wedi'i haddasu gyda'r dewisiadau hyn\"",
+	"[ImportFilterSpecTitle]",
+	"one = \"Mewnforio dewisiadau set ddata\"",
+	"[ImportFilterSpecErrorUnreadable]",
+	"one = \"Enter the contents of a selections file\"",
+	"[ImportFilterSpecErrorInvalid]",
+	"one = \"The selections are not valid\"",
+	"[ImportFilterSpecErrorTooLarge]",
+	"one = \"The selections must be smaller than 1MB\"",
+	"[ImportFilterSpecErrorUnavailable]",
+	"one = \"The selections are not available\"",
 }
 
 var enLocale = []string{
@@ -50,10 +208,147 @@ var enLocale = []string{
 	"[SDCAllAreasAvailable]",
 	"one = \"1 area available\"",
 	"other = \"All 10 areas available\"",
+	"[SDCDetailTitle]",
+	"one = \"Restricted areas in this dataset\"",
+	"[ApproveConfirmTitle]",
+	"one = \"Approve this version\"",
+	"[RejectConfirmTitle]",
+	"one = \"Reject this version\"",
+	"[ApproveConfirmBody]",
+	"one = \"You are about to approve {{.arg0}}, edition {{.arg1}}, version {{.arg2}}.\"",
+	"[ApproveConfirmWarning]",
+	"one = \"Once approved, the version will be published with its collection.\"",
+	"[ApproveConfirmButton]",
+	"one = \"Approve\"",
+	"[RejectConfirmBody]",
+	"one = \"You are about to reject {{.arg0}}, edition {{.arg1}}, version {{.arg2}}.\"",
+	"[RejectConfirmWarning]",
+	"one = \"The version will go back to draft so that it can be corrected and submitted for approval again.\"",
+	"[RejectConfirmButton]",
+	"one = \"Reject\"",
+	"[VersionStateReasonRequired]",
+	"one = \"Enter a reason\"",
+	"[RejectionSucceeded]",
+	"one = \"This version has been rejected and returned to draft.\"",
+	"[LintErrors]",
+	"one = \"Fix these problems before this version is published:\"",
+	"[LintWarnings]",
+	"one = \"Check these before this version is published:\"",
+	"[LintContacts]",
+	"one = \"No contact has an email address or telephone number\"",
+	"[LintQMI]",
+	"one = \"There is no link to a Quality and Methodology Information (QMI) report\"",
+	"[LintUsageNotes]",
+	"one = \"Usage notes are missing or empty\"",
+	"[LintNextRelease]",
+	"one = \"There is no next release date\"",
+	"[LintDistributionSize]",
+	"one = \"Downloads have no file size\"",
+	"[VersionDiffIntro]",
+	"one = \"Compared with published version {{.arg0}}, this version changes:\"",
+	"[VersionDiffNone]",
+	"one = \"This version does not change the title, description, downloads, alerts, usage notes or release date of published version {{.arg0}}.\"",
+	"[VersionDiffNoPublished]",
+	"one = \"No version of this edition has been published yet, so there is nothing to compare this version with.\"",
+	"[VersionDiffShowFull]",
+	"one = \"Show all differences\"",
+	"[VersionDiffChanged]",
+	"one = \"{{.arg0}} changed\"",
+	"[VersionDiffCounts]",
+	"one = \"{{.arg0}}: {{.arg1}} added, {{.arg2}} removed\"",
+	"[VersionDiffRemoved]",
+	"one = \"Removed\"",
+	"[VersionDiffAdded]",
+	"one = \"Added\"",
+	"[VersionDiffTitle]",
+	"one = \"Title\"",
+	"[VersionDiffDescription]",
+	"one = \"Description\"",
+	"[VersionDiffDistributions]",
+	"one = \"Downloads\"",
+	"[VersionDiffAlerts]",
+	"one = \"Alerts\"",
+	"[VersionDiffUsageNotes]",
+	"one = \"Usage notes\"",
+	"[VersionDiffReleaseDate]",
+	"one = \"Release date\"",
+	"[TooManyRequestsTitle]",
+	"one = \"Too many requests\"",
+	"[TooManyRequestsDescription]",
+	"one = \"You have made too many requests to create a dataset in a short time. Wait {{.arg0}} seconds and try again.\"",
+	"[ForbiddenTitle]",
+	"one = \"The form could not be sent\"",
+	"[ForbiddenDescription]",
+	"one = \"Your form could not be sent securely. Go back to the page, refresh it and try again.\"",
+	"[ApprovalSucceeded]",
+	"one = \"This version has been approved.\"",
+	"[ApprovalFailed]",
+	"one = \"This version could not be approved. Try again later.\"",
 	"[CreateCustomDatasetTitle]",
 	"one = \"Create a custom dataset\"",
 	"[CustomDatasetSummary]",
 	"one = \"This is a custom dataset\"",
+	"[TimeCoverageMonths]",
+	"one = \"This year {{.arg1}} contains data for the month {{.arg0}}\"",
+	"other = \"All months between {{.arg0}} {{.arg1}} and {{.arg2}} {{.arg3}}\"",
+	"[TimeCoverageYears]",
+	"one = \"This year contains data for {{.arg0}}\"",
+	"other = \"All years between {{.arg0}} and {{.arg1}}\"",
+	"[TimestampMonthJanuary]",
+	"one = \"January\"",
+	"[TimestampMonthFebruary]",
+	"one = \"February\"",
+	"[TimestampMonthMarch]",
+	"one = \"March\"",
+	"[TimestampMonthApril]",
+	"one = \"April\"",
+	"[TimestampMonthMay]",
+	"one = \"May\"",
+	"[TimestampMonthJune]",
+	"one = \"June\"",
+	"[TimestampMonthJuly]",
+	"one = \"July\"",
+	"[TimestampMonthAugust]",
+	"one = \"August\"",
+	"[TimestampMonthSeptember]",
+	"one = \"September\"",
+	"[TimestampMonthOctober]",
+	"one = \"October\"",
+	"[TimestampMonthNovember]",
+	"one = \"November\"",
+	"[TimestampMonthDecember]",
+	"one = \"December\"",
+	"[TimeCoverageQuarters]",
+	"one = \"This dataset contains data for {{.arg0}}\"",
+	"other = \"All quarters between {{.arg0}} and {{.arg1}}\"",
+	"[TimeCoverageFinancialYears]",
+	"one = \"This dataset contains data for the financial year {{.arg0}}\"",
+	"other = \"All financial years between {{.arg0}} and {{.arg1}}\"",
+	"[TimeCoverageWeeks]",
+	"one = \"This dataset contains data for {{.arg0}}\"",
+	"other = \"All weeks between {{.arg0}} and {{.arg1}}\"",
+	"[TimeCoverageDays]",
+	"one = \"This dataset contains data for {{.arg0}}\"",
+	"other = \"All days between {{.arg0}} and {{.arg1}}\"",
+	"[TimeCoverageGap]",
+	"one = \"No data is available for {{.arg0}}\"",
+	"other = \"No data is available between {{.arg0}} and {{.arg1}}\"",
+	"[TimeLabelQuarter]",
+	"one = \"Q{{.arg0}} {{.arg1}}\"",
+	"[TimeLabelWeek]",
+	"one = \"week {{.arg0}} {{.arg1}}\"",
+	"[ConfirmCustomDatasetTitle]",
+	"one = \"Create a custom dataset with these selections\"",
+	"[ImportFilterSpecTitle]",
+	"one = \"Import dataset selections\"",
+	"[ImportFilterSpecErrorUnreadable]",
+	"one = \"Enter the contents of a selections file\"",
+	"[ImportFilterSpecErrorInvalid]",
+	"one = \"The selections are not valid\"",
+	"[ImportFilterSpecErrorTooLarge]",
+	"one = \"The selections must be smaller than 1MB\"",
+	"[ImportFilterSpecErrorUnavailable]",
+	"one = \"The selections are not available\"",
 }
 
 // MockAssetFunction returns mocked toml []bytes
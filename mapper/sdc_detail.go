@@ -0,0 +1,105 @@
+package mapper
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	dpRendererModel "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/sdcdetail"
+)
+
+// CreateSDCDetailPage maps the statistical disclosure control detail page of a filter output. The blocked area counts
+// of each area type are keyed by area type ID, and the counts with each dimension removed and the categorisation counts
+// are keyed by dimension name.
+func CreateSDCDetailPage(basePage dpRendererModel.Page, req *http.Request, lang string, d dpDatasetApiModels.Dataset, filterOutput filter.Model,
+	sdc cantabular.GetBlockedAreaCountResult, areaTypes []population.AreaType, areaTypeSDC map[string]cantabular.GetBlockedAreaCountResult,
+	blockedWithout, categorisationCounts map[string]int, serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) sdcdetail.Page {
+	p := sdcdetail.Page{
+		Page: basePage,
+	}
+	MapCookiePreferences(req, &p.CookiesPreferencesSet, &p.CookiesPolicy)
+
+	p.Language = lang
+	p.Data.DatasetTitle = d.Title
+	p.Data.FilterOutputURL = strings.TrimSuffix(req.URL.Path, "/sdc")
+
+	panelType := census.Pending
+	if sdc.Blocked == 0 {
+		panelType = census.Success
+	}
+	p.Data.Panels = mapBlockedAreasPanel(&sdc, panelType, lang)
+
+	var selectedAreaType string
+	var dims []sharedModel.Dimension
+	for i := range filterOutput.Dimensions {
+		dimension := &filterOutput.Dimensions[i]
+		title := dimension.Label
+		if title == "" {
+			title = dimension.Name
+		}
+		isAreaType := helpers.IsBoolPtr(dimension.IsAreaType)
+		dims = append(dims, sharedModel.Dimension{
+			Title:      title,
+			ID:         dimension.ID,
+			Name:       dimension.Name,
+			IsAreaType: isAreaType,
+		})
+
+		if isAreaType {
+			selectedAreaType = dimension.ID
+			continue
+		}
+		p.Data.Suggestions = append(p.Data.Suggestions, sdcdetail.Suggestion{
+			DimensionName:       dimension.Name,
+			DimensionTitle:      title,
+			BlockedWithout:      blockedWithout[dimension.Name],
+			CategorisationCount: categorisationCounts[dimension.Name],
+		})
+	}
+	// the dimensions whose removal would block the fewest areas are suggested first
+	sort.SliceStable(p.Data.Suggestions, func(i, j int) bool {
+		return p.Data.Suggestions[i].BlockedWithout < p.Data.Suggestions[j].BlockedWithout
+	})
+
+	for i := range areaTypes {
+		areaType := &areaTypes[i]
+		result, ok := areaTypeSDC[areaType.ID]
+		if !ok {
+			continue
+		}
+		p.Data.AreaTypes = append(p.Data.AreaTypes, sdcdetail.AreaTypeCount{
+			ID:         areaType.ID,
+			Label:      areaType.Label,
+			Passed:     result.Passed,
+			Blocked:    result.Blocked,
+			Total:      result.Total,
+			IsSelected: areaType.ID == selectedAreaType,
+		})
+	}
+
+	if sdc.Blocked > 0 {
+		p.Data.ImproveResults = mapImproveResultsCollapsible(dims, lang)
+	}
+
+	p.Metadata.Title = helper.Localise("SDCDetailTitle", lang, 1) + " - " + d.Title
+	p.DatasetId = d.ID
+	p.DatasetTitle = d.Title
+	p.URI = req.URL.Path
+	p.Canonical = buildCanonical(p.Language, p.SiteDomain, p.URI)
+	p.BetaBannerEnabled = true
+	p.ServiceMessage = serviceMessage
+	p.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	return p
+}
@@ -0,0 +1,100 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/sdcdetail"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSDCDetailPage(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	req := httptest.NewRequest("GET", "/datasets/create/filter-outputs/67890/sdc", nil)
+	dataset := dpDatasetApiModels.Dataset{ID: "RM097", Title: "Census"}
+	filterOutput := filter.Model{
+		PopulationType: "UR",
+		Dimensions: []filter.ModelDimension{
+			{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true)},
+			{Name: "sex", ID: "sex", Label: "Sex", IsAreaType: helpers.ToBoolPtr(false)},
+			{Name: "age_86a", ID: "age_86a", IsAreaType: helpers.ToBoolPtr(false)},
+		},
+	}
+	areaTypes := []population.AreaType{
+		{ID: "ctry", Label: "Countries"},
+		{ID: "ltla", Label: "Lower tier local authorities"},
+		{ID: "msoa", Label: "MSOAs"},
+	}
+	areaTypeSDC := map[string]cantabular.GetBlockedAreaCountResult{
+		"ctry": {Passed: 2, Blocked: 0, Total: 2},
+		"ltla": {Passed: 300, Blocked: 31, Total: 331},
+	}
+	blockedWithout := map[string]int{"sex": 12, "age_86a": 0}
+	categorisationCounts := map[string]int{"sex": 1, "age_86a": 7}
+
+	Convey("Given a filter output with blocked areas", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 15, Total: 25}
+
+		Convey("When the page is mapped", func() {
+			page := CreateSDCDetailPage(core.Page{SiteDomain: "ons.gov.uk"}, req, "en", dataset, filterOutput, sdc, areaTypes, areaTypeSDC,
+				blockedWithout, categorisationCounts, "", zebedee.EmergencyBanner{})
+
+			Convey("Then the selected areas are summarised in a pending panel", func() {
+				So(page.Data.Panels, ShouldHaveLength, 1)
+				So(page.Data.Panels[0].Type, ShouldEqual, census.Pending)
+			})
+
+			Convey("Then the counts of each area type with a result are mapped in order, marking the selected area type", func() {
+				So(page.Data.AreaTypes, ShouldResemble, []sdcdetail.AreaTypeCount{
+					{ID: "ctry", Label: "Countries", Passed: 2, Blocked: 0, Total: 2},
+					{ID: "ltla", Label: "Lower tier local authorities", Passed: 300, Blocked: 31, Total: 331, IsSelected: true},
+				})
+			})
+
+			Convey("Then the non area type dimensions are suggested, fewest blocked areas first", func() {
+				So(page.Data.Suggestions, ShouldResemble, []sdcdetail.Suggestion{
+					{DimensionName: "age_86a", DimensionTitle: "age_86a", BlockedWithout: 0, CategorisationCount: 7},
+					{DimensionName: "sex", DimensionTitle: "Sex", BlockedWithout: 12, CategorisationCount: 1},
+				})
+			})
+
+			Convey("Then the ways to improve results are included", func() {
+				So(page.Data.ImproveResults.CollapsibleItems, ShouldHaveLength, 1)
+			})
+
+			Convey("Then the page links back to the filter output", func() {
+				So(page.Data.FilterOutputURL, ShouldEqual, "/datasets/create/filter-outputs/67890")
+				So(page.Data.DatasetTitle, ShouldEqual, "Census")
+				So(page.Metadata.Title, ShouldEqual, "Restricted areas in this dataset - Census")
+				So(page.Language, ShouldEqual, "en")
+			})
+		})
+	})
+
+	Convey("Given a filter output without blocked areas", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 0, Total: 10}
+
+		Convey("When the page is mapped in Welsh", func() {
+			page := CreateSDCDetailPage(core.Page{SiteDomain: "ons.gov.uk"}, req, "cy", dataset, filterOutput, sdc, areaTypes, areaTypeSDC,
+				blockedWithout, categorisationCounts, "", zebedee.EmergencyBanner{})
+
+			Convey("Then the selected areas are summarised in a success panel without ways to improve results", func() {
+				So(page.Language, ShouldEqual, "cy")
+				So(page.Data.Panels, ShouldHaveLength, 1)
+				So(page.Data.Panels[0].Type, ShouldEqual, census.Success)
+				So(page.Data.ImproveResults.CollapsibleItems, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"strings"
+
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/helpers"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+)
+
+// Open Graph and Twitter Card values shared by all dataset pages
+const (
+	socialType            = "website"
+	socialSiteNameKey     = "OfficeForNationalStatistics"
+	twitterCardSummary    = "summary"
+	twitterCardLargeImage = "summary_large_image"
+)
+
+// buildSocialMetadata returns the Open Graph and Twitter Card details for a page. The image is taken from
+// the configured social images for the given topic slug and is omitted if none is configured.
+func buildSocialMetadata(lang, canonicalURL, title, description, topicSlug string) sharedModel.SocialMetadata {
+	socialMetadata := sharedModel.SocialMetadata{
+		Title:       title,
+		Description: strings.Join(strings.Fields(description), " "),
+		URL:         canonicalURL,
+		SiteName: core.Localisation{
+			LocaleKey: socialSiteNameKey,
+			Plural:    1,
+		},
+		Type:        socialType,
+		Locale:      helpers.GetOpenGraphLocale(lang),
+		TwitterCard: twitterCardSummary,
+	}
+
+	for _, supportedLang := range cfg.SupportedLanguages {
+		alternateLocale := helpers.GetOpenGraphLocale(supportedLang)
+		if alternateLocale != socialMetadata.Locale {
+			socialMetadata.AlternateLocales = append(socialMetadata.AlternateLocales, alternateLocale)
+		}
+	}
+
+	if imageURL := cfg.SocialImageURLs[topicSlug]; topicSlug != "" && imageURL != "" {
+		socialMetadata.ImageURL = imageURL
+		socialMetadata.TwitterCard = twitterCardLargeImage
+	}
+
+	return socialMetadata
+}
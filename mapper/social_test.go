@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildSocialMetadata(t *testing.T) {
+	Convey("Given an English page with no configured social images", t, func() {
+		socialMetadata := buildSocialMetadata("en", "https://ons.gov.uk/economy/datasets/cpih", "CPIH", "Consumer prices\nindex", "economy")
+
+		Convey("Then the Open Graph and Twitter Card details are populated", func() {
+			So(socialMetadata.Title, ShouldEqual, "CPIH")
+			So(socialMetadata.Description, ShouldEqual, "Consumer prices index")
+			So(socialMetadata.URL, ShouldEqual, "https://ons.gov.uk/economy/datasets/cpih")
+			So(socialMetadata.SiteName.LocaleKey, ShouldEqual, "OfficeForNationalStatistics")
+			So(socialMetadata.Type, ShouldEqual, "website")
+			So(socialMetadata.Locale, ShouldEqual, "en_GB")
+			So(socialMetadata.AlternateLocales, ShouldResemble, []string{"cy_GB"})
+			So(socialMetadata.ImageURL, ShouldBeEmpty)
+			So(socialMetadata.TwitterCard, ShouldEqual, "summary")
+		})
+	})
+
+	Convey("Given a Welsh page", t, func() {
+		socialMetadata := buildSocialMetadata("cy", "https://cy.ons.gov.uk/datasets/cpih", "CPIH", "", "")
+
+		Convey("Then the locales reflect the Welsh site", func() {
+			So(socialMetadata.URL, ShouldEqual, "https://cy.ons.gov.uk/datasets/cpih")
+			So(socialMetadata.Locale, ShouldEqual, "cy_GB")
+			So(socialMetadata.AlternateLocales, ShouldResemble, []string{"en_GB"})
+		})
+	})
+
+	Convey("Given a social image is configured for a topic", t, func() {
+		originalImageURLs := cfg.SocialImageURLs
+		cfg.SocialImageURLs = map[string]string{"economy": "https://cdn.ons.gov.uk/economy.png"}
+		defer func() { cfg.SocialImageURLs = originalImageURLs }()
+
+		Convey("When the page belongs to that topic", func() {
+			socialMetadata := buildSocialMetadata("en", "https://ons.gov.uk/economy/datasets/cpih", "CPIH", "", "economy")
+
+			Convey("Then the image is included and a large image card is used", func() {
+				So(socialMetadata.ImageURL, ShouldEqual, "https://cdn.ons.gov.uk/economy.png")
+				So(socialMetadata.TwitterCard, ShouldEqual, "summary_large_image")
+			})
+		})
+
+		Convey("When the page belongs to a different topic", func() {
+			socialMetadata := buildSocialMetadata("en", "https://ons.gov.uk/census/datasets/ts001", "TS001", "", "census")
+
+			Convey("Then no image is included", func() {
+				So(socialMetadata.ImageURL, ShouldBeEmpty)
+				So(socialMetadata.TwitterCard, ShouldEqual, "summary")
+			})
+		})
+	})
+}
@@ -18,7 +18,7 @@ import (
 
 // CreateCensusBasePage builds a base datasetLandingPageCensus.Page with shared functionality between Dataset Landing Pages and Filter Output pages
 func CreateStaticBasePage(basePage core.Page, d dpDatasetApiModels.Dataset, version dpDatasetApiModels.Version,
-	allVersions []dpDatasetApiModels.Version, isEnableMultivariate bool, topicObjectList []*dpTopicApiModels.Topic,
+	allVersions []dpDatasetApiModels.Version, isEnableMultivariate bool, topicObjectList []*dpTopicApiModels.Topic, entryTopicSlug string,
 ) static.Page {
 	var editionStr string
 
@@ -59,8 +59,10 @@ func CreateStaticBasePage(basePage core.Page, d dpDatasetApiModels.Dataset, vers
 		hasOtherVersions = true
 	}
 
-	// topicSlug is used for constructing URLs for static datasets
-	topicSlug := topicObjectList[0].Slug
+	// topicSlug is used for constructing URLs for static datasets so that users stay within the topic they came in through,
+	// whereas the canonical URL always uses the primary topic
+	primaryTopicSlug := topicObjectList[0].Slug
+	topicSlug := getEntryTopicSlug(topicObjectList, entryTopicSlug)
 	latestVersionURL := helpers.DatasetVersionURLWithTopic(topicSlug, d.ID, version.Edition, strconv.Itoa(latestVersionNumber))
 
 	if d.NationalStatistic != nil {
@@ -93,7 +95,7 @@ func CreateStaticBasePage(basePage core.Page, d dpDatasetApiModels.Dataset, vers
 	p.ShowCensusBranding = false
 
 	// BREADCRUMBS
-	breadcrumbsObject := CreateBreadcrumbsFromTopicList(topicObjectList)
+	breadcrumbsObject := CreateBreadcrumbsForEntryTopic(topicObjectList, topicSlug)
 	breadcrumbsObject = append(breadcrumbsObject, core.TaxonomyNode{
 		Title: d.Title,
 		URI:   fmt.Sprintf("/%s/datasets/%s/editions", topicSlug, d.ID),
@@ -156,9 +158,11 @@ func CreateStaticBasePage(basePage core.Page, d dpDatasetApiModels.Dataset, vers
 	}
 
 	// SHARING LINKS
-	currentURL := helpers.GetCurrentURL(basePage.Language, p.SiteDomain, basePage.URI)
+	currentURL := helpers.GetCurrentURL(basePage.Language, defaultLanguage(), p.SiteDomain, basePage.URI)
 	p.DatasetLandingPage.DatasetURL = currentURL
 	p.DatasetLandingPage.ShareDetails = buildStaticSharingDetails(d, basePage.Language, currentURL)
+	p.Canonical = buildCanonical(basePage.Language, p.SiteDomain, getCanonicalTopicPath(basePage.URI, topicSlug, primaryTopicSlug))
+	p.SocialMetadata = buildSocialMetadata(basePage.Language, p.Canonical.URL, d.Title, d.Description, primaryTopicSlug)
 
 	// RELATED CONTENT
 	p.DatasetLandingPage.RelatedContentItems = []sharedModel.RelatedContentItem{}
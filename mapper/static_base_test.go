@@ -29,11 +29,17 @@ func TestCreateStaticBasePage(t *testing.T) {
 			Edition:      editionSlug,
 		}
 		Convey("When CreateStaticBasePage is called", func() {
-			staticPage := CreateStaticBasePage(basePage, dataset, version, allVersions, isEnableMultivariate, topicObjectList)
+			staticPage := CreateStaticBasePage(basePage, dataset, version, allVersions, isEnableMultivariate, topicObjectList, "topic1-slug")
 
 			Convey("Then the resulting static.Page should have expected values", func() {
 				So(staticPage.Version.Edition, ShouldEqual, editionTitleStr)
 			})
+
+			Convey("Then the social metadata should be populated for the primary topic", func() {
+				So(staticPage.SocialMetadata.URL, ShouldEqual, "https://ons.gov.uk")
+				So(staticPage.SocialMetadata.Locale, ShouldEqual, "en_GB")
+				So(staticPage.SocialMetadata.AlternateLocales, ShouldResemble, []string{"cy_GB"})
+			})
 		})
 	})
 	Convey("If `version.EditionTitle` field value is not valid", t, func() {
@@ -44,7 +50,7 @@ func TestCreateStaticBasePage(t *testing.T) {
 			Edition:      editionSlug,
 		}
 		Convey("When CreateStaticBasePage is called", func() {
-			staticPage := CreateStaticBasePage(basePage, dataset, version, allVersions, isEnableMultivariate, topicObjectList)
+			staticPage := CreateStaticBasePage(basePage, dataset, version, allVersions, isEnableMultivariate, topicObjectList, "topic1-slug")
 
 			Convey("Then the resulting static.Page should have expected values", func() {
 				So(staticPage.Version.Edition, ShouldEqual, editionSlug)
@@ -58,11 +64,39 @@ func TestCreateStaticBasePage(t *testing.T) {
 		version := dpDatasetApiModels.Version{}
 
 		Convey("When CreateStaticBasePage is called", func() {
-			staticPage := CreateStaticBasePage(basePage, datasetWithQMI, version, allVersions, isEnableMultivariate, topicObjectList)
+			staticPage := CreateStaticBasePage(basePage, datasetWithQMI, version, allVersions, isEnableMultivariate, topicObjectList, "topic1-slug")
 
 			Convey("Then the resulting static.Page should include QMI URL", func() {
 				So(staticPage.DatasetLandingPage.QMIURL, ShouldEqual, "https://example.com/qmi")
 			})
 		})
 	})
+
+	Convey("If the page is reached through a secondary topic", t, func() {
+		multiTopicDataset := dpDatasetApiModels.Dataset{ID: "cpih", Title: "CPIH", Topics: []string{"topic1", "topic2"}}
+		multiTopicList := []*dpTopicApiModels.Topic{
+			{ID: "topic1", Slug: "topic1-slug", Title: "Topic one"},
+			{ID: "topic2", Slug: "topic2-slug", Title: "Topic two"},
+		}
+		secondaryTopicPage := basePage
+		secondaryTopicPage.URI = "/topic2-slug/datasets/cpih/editions/2024/versions/1"
+		version := dpDatasetApiModels.Version{Edition: "2024", Version: 1}
+
+		Convey("When CreateStaticBasePage is called", func() {
+			staticPage := CreateStaticBasePage(secondaryTopicPage, multiTopicDataset, version, allVersions, isEnableMultivariate, multiTopicList, "topic2-slug")
+
+			Convey("Then the breadcrumbs follow the topic list to the secondary topic", func() {
+				So(staticPage.Breadcrumb, ShouldHaveLength, 4)
+				So(staticPage.Breadcrumb[1].URI, ShouldEqual, "/topic1-slug")
+				So(staticPage.Breadcrumb[2].Title, ShouldEqual, "Topic two")
+				So(staticPage.Breadcrumb[2].URI, ShouldEqual, "/topic1-slug/topic2-slug")
+				So(staticPage.Breadcrumb[3].URI, ShouldEqual, "/topic2-slug/datasets/cpih/editions")
+			})
+
+			Convey("Then the canonical URL points at the primary topic", func() {
+				So(staticPage.Canonical.URL, ShouldEqual, "https://ons.gov.uk/topic1-slug/datasets/cpih/editions/2024/versions/1")
+				So(staticPage.SocialMetadata.URL, ShouldEqual, staticPage.Canonical.URL)
+			})
+		})
+	})
 }
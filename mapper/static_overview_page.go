@@ -10,20 +10,22 @@ import (
 	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
 	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
 	dpTopicApiModels "github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // CreateStaticLandingPage creates a static-overview page based on api model responses
 func CreateStaticOverviewPage(ctx context.Context, basePage core.Page, datasetDetails dpDatasetApiModels.Dataset,
-	version dpDatasetApiModels.Version, allVersions []dpDatasetApiModels.Version, isEnableMultivariate bool, topicObjectList []*dpTopicApiModels.Topic, isPublishing bool, enableApprovalButton bool,
+	version dpDatasetApiModels.Version, allVersions []dpDatasetApiModels.Version, isEnableMultivariate bool, topicObjectList []*dpTopicApiModels.Topic, entryTopicSlug string, isPublishing bool, collectionID string, transitions []workflow.Transition,
 ) static.Page {
-	p := CreateStaticBasePage(basePage, datasetDetails, version, allVersions, isEnableMultivariate, topicObjectList)
+	p := CreateStaticBasePage(basePage, datasetDetails, version, allVersions, isEnableMultivariate, topicObjectList, entryTopicSlug)
 
-	// SET STATE FOR APPROVAL BUTTON USE
+	// PUBLISHING WORKFLOW
 	p.DatasetLandingPage.State = version.State
-	if isPublishing && enableApprovalButton {
-		p.ShowApprove = true
+	if isPublishing {
+		p.ShowWorkflow = true
+		p.Workflow = MapWorkflow(basePage.URI, collectionID, version.State, transitions)
 	}
 
 	// DOWNLOADS
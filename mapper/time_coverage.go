@@ -0,0 +1,106 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/timelabel"
+)
+
+// timeLabelSummariser summarises the labels of time dimensions on the filterable and census pages
+var timeLabelSummariser = timelabel.NewSummariser()
+
+// timeCoverageKeys are the locale keys of the coverage summary for each unit of time
+var timeCoverageKeys = map[timelabel.Unit]string{
+	timelabel.Day:           "TimeCoverageDays",
+	timelabel.Week:          "TimeCoverageWeeks",
+	timelabel.Quarter:       "TimeCoverageQuarters",
+	timelabel.Year:          "TimeCoverageYears",
+	timelabel.FinancialYear: "TimeCoverageFinancialYears",
+}
+
+// isTimeDimension reports whether the dimension with the given name is a dimension of time
+func isTimeDimension(name string) bool {
+	return strings.EqualFold(name, DimensionTime)
+}
+
+// summariseTimeDimension replaces the values of a time dimension with a summary of the time covered by its
+// labels, returning false and leaving the dimension unchanged if it is not a time dimension or none of the
+// labels are in a recognised time format
+func summariseTimeDimension(lang, name string, dim *sharedModel.Dimension, labels []string) bool {
+	if !isTimeDimension(name) {
+		return false
+	}
+
+	coverage, gaps, ok := summariseTimeLabels(lang, labels)
+	if !ok {
+		return false
+	}
+	dim.Values = coverage
+	dim.Gaps = gaps
+	dim.IsTimeSummary = true
+	return true
+}
+
+// summariseTimeLabels returns the localised coverage of each run of consecutive periods described by the
+// labels, along with the gaps between the runs. Labels which could not be summarised with the others follow
+// the coverage as they are. It returns false if none of the labels are in a recognised time format.
+func summariseTimeLabels(lang string, labels []string) (coverage, gaps []string, ok bool) {
+	summary, ok := timeLabelSummariser.Summarise(labels)
+	if !ok {
+		return nil, nil, false
+	}
+
+	for _, run := range summary.Runs {
+		coverage = append(coverage, localiseTimeCoverage(lang, summary.Unit, run))
+	}
+	coverage = append(coverage, summary.Unsummarised...)
+	for _, gap := range summary.Gaps {
+		gaps = append(gaps, helper.Localise("TimeCoverageGap", lang, gap.Len(),
+			localisePeriod(lang, summary.Unit, gap.Start), localisePeriod(lang, summary.Unit, gap.End)))
+	}
+
+	return coverage, gaps, true
+}
+
+// localiseTimeCoverage returns the coverage summary of a run of periods, pluralised by the number of
+// periods covered
+func localiseTimeCoverage(lang string, unit timelabel.Unit, run timelabel.Range) string {
+	if unit == timelabel.Month {
+		start, end := unit.Time(run.Start), unit.Time(run.End)
+		return helper.Localise("TimeCoverageMonths", lang, run.Len(),
+			localiseMonth(lang, start.Month()), strconv.Itoa(start.Year()),
+			localiseMonth(lang, end.Month()), strconv.Itoa(end.Year()))
+	}
+	return helper.Localise(timeCoverageKeys[unit], lang, run.Len(),
+		localisePeriod(lang, unit, run.Start), localisePeriod(lang, unit, run.End))
+}
+
+// localisePeriod returns the display label of the period of the unit with the given ordinal
+func localisePeriod(lang string, unit timelabel.Unit, ordinal int) string {
+	t := unit.Time(ordinal)
+	switch unit {
+	case timelabel.Day:
+		return fmt.Sprintf("%d %s %d", t.Day(), localiseMonth(lang, t.Month()), t.Year())
+	case timelabel.Week:
+		year, week := t.ISOWeek()
+		return helper.Localise("TimeLabelWeek", lang, 1, strconv.Itoa(week), strconv.Itoa(year))
+	case timelabel.Month:
+		return fmt.Sprintf("%s %d", localiseMonth(lang, t.Month()), t.Year())
+	case timelabel.Quarter:
+		return helper.Localise("TimeLabelQuarter", lang, 1, strconv.Itoa(int(t.Month()-1)/3+1), strconv.Itoa(t.Year()))
+	case timelabel.FinancialYear:
+		return fmt.Sprintf("%d-%02d", t.Year(), (t.Year()+1)%100)
+	default:
+		return strconv.Itoa(t.Year())
+	}
+}
+
+// localiseMonth returns the name of the month in the given language
+func localiseMonth(lang string, month time.Month) string {
+	return helper.Localise("TimestampMonth"+month.String(), lang, 1)
+}
@@ -0,0 +1,111 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSummariseTimeLabels(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given time labels in each of the recognised formats", t, func() {
+		tests := []struct {
+			name     string
+			labels   []string
+			coverage []string
+			gaps     []string
+		}{
+			{
+				name:     "months",
+				labels:   []string{"Jan-20", "Feb-20", "Jun-20"},
+				coverage: []string{"All months between January 2020 and February 2020", "This year 2020 contains data for the month June"},
+				gaps:     []string{"No data is available between March 2020 and May 2020"},
+			},
+			{
+				name:     "years",
+				labels:   []string{"2016", "2017", "2019"},
+				coverage: []string{"All years between 2016 and 2017", "This year contains data for 2019"},
+				gaps:     []string{"No data is available for 2018"},
+			},
+			{
+				name:     "quarters",
+				labels:   []string{"2020 Q3", "2020 Q4", "2021 Q1"},
+				coverage: []string{"All quarters between Q3 2020 and Q1 2021"},
+			},
+			{
+				name:     "financial years",
+				labels:   []string{"2017-18", "2019-20"},
+				coverage: []string{"This dataset contains data for the financial year 2017-18", "This dataset contains data for the financial year 2019-20"},
+				gaps:     []string{"No data is available for 2018-19"},
+			},
+			{
+				name:     "weeks",
+				labels:   []string{"2020 W53", "2021 W01", "2021 W02"},
+				coverage: []string{"All weeks between week 53 2020 and week 2 2021"},
+			},
+			{
+				name:     "spans of months",
+				labels:   []string{"Jan-Mar 2020", "Feb-Apr 2020"},
+				coverage: []string{"All months between January 2020 and April 2020"},
+			},
+			{
+				name:     "dates",
+				labels:   []string{"2020-02-28", "2020-02-29", "2020-03-01"},
+				coverage: []string{"All days between 28 February 2020 and 1 March 2020"},
+			},
+		}
+
+		for _, tc := range tests {
+			Convey("Then "+tc.name+" are summarised with their gaps", func() {
+				coverage, gaps, ok := summariseTimeLabels("en", tc.labels)
+				So(ok, ShouldBeTrue)
+				So(coverage, ShouldResemble, tc.coverage)
+				So(gaps, ShouldResemble, tc.gaps)
+			})
+		}
+	})
+
+	Convey("Given time labels and the Welsh language", t, func() {
+		coverage, gaps, ok := summariseTimeLabels("cy", []string{"2020 Q1", "2020 Q2", "2020 Q4"})
+
+		Convey("Then the summary is localised", func() {
+			So(ok, ShouldBeTrue)
+			So(coverage, ShouldResemble, []string{"Pob chwarter rhwng Ch1 2020 a Ch2 2020", "Mae'r set ddata hon yn cynnwys data ar gyfer Ch4 2020"})
+			So(gaps, ShouldResemble, []string{"Nid oes data ar gael ar gyfer Ch3 2020"})
+		})
+	})
+
+	Convey("Given time labels in mixed formats", t, func() {
+		coverage, _, ok := summariseTimeLabels("en", []string{"2016", "2017", "2017 Q1", "Total"})
+
+		Convey("Then the labels which could not be summarised follow the coverage", func() {
+			So(ok, ShouldBeTrue)
+			So(coverage, ShouldResemble, []string{"All years between 2016 and 2017", "2017 Q1", "Total"})
+		})
+	})
+
+	Convey("Given a time dimension with labels that are not times", t, func() {
+		dim := sharedModel.Dimension{Values: []string{"unchanged"}}
+		ok := summariseTimeDimension("en", DimensionTime, &dim, []string{"Label 1", "Label 2"})
+
+		Convey("Then the dimension is not summarised", func() {
+			So(ok, ShouldBeFalse)
+			So(dim.Values, ShouldResemble, []string{"unchanged"})
+			So(dim.IsTimeSummary, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a dimension which is not of time with labels that are years", t, func() {
+		dim := sharedModel.Dimension{Values: []string{"unchanged"}}
+		ok := summariseTimeDimension("en", "age", &dim, []string{"2016", "2017"})
+
+		Convey("Then the dimension is not summarised", func() {
+			So(ok, ShouldBeFalse)
+			So(dim.Values, ShouldResemble, []string{"unchanged"})
+		})
+	})
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/diff"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+)
+
+// MapVersionDiffPanel maps the differences between a static or filterable dataset version under review and the
+// published version of its edition to the panel shown to publishers. A published version of 0 means no version has
+// been published yet.
+func MapVersionDiffPanel(publishedVersion int, changes []diff.Change, lang string) static.Panel {
+	return static.Panel{
+		Type:       static.Info,
+		Body:       mapVersionDiffBody(publishedVersion, changes, lang),
+		CSSClasses: []string{"ons-u-mt-m", "ons-u-mb-l"},
+		Language:   lang,
+	}
+}
+
+// mapVersionDiffBody summarises the changed fields, followed by the values removed and added in each, which are
+// collapsed until expanded
+func mapVersionDiffBody(publishedVersion int, changes []diff.Change, lang string) []string {
+	if publishedVersion == 0 {
+		return []string{"<p>" + helper.Localise("VersionDiffNoPublished", lang, 1) + "</p>"}
+	}
+
+	version := strconv.Itoa(publishedVersion)
+	if len(changes) == 0 {
+		return []string{"<p>" + helper.Localise("VersionDiffNone", lang, 1, version) + "</p>"}
+	}
+
+	var summary, full strings.Builder
+	for _, c := range changes {
+		name := helper.Localise(c.LocaleKey, lang, 1)
+		if c.IsList {
+			summary.WriteString("<li class=\"ons-list__item\">" +
+				helper.Localise("VersionDiffCounts", lang, 1, name, strconv.Itoa(len(c.Added)), strconv.Itoa(len(c.Removed))) + "</li>")
+		} else {
+			summary.WriteString("<li class=\"ons-list__item\">" + helper.Localise("VersionDiffChanged", lang, 1, name) + "</li>")
+		}
+
+		full.WriteString("<h3 class=\"ons-u-fs-r--b ons-u-mt-s ons-u-mb-xs\">" + name + "</h3>")
+		full.WriteString("<ul class=\"ons-list ons-list--bare\">")
+		for _, value := range c.Removed {
+			full.WriteString("<li class=\"ons-list__item\"><del>" + helper.Localise("VersionDiffRemoved", lang, 1) + ": " + html.EscapeString(value) + "</del></li>")
+		}
+		for _, value := range c.Added {
+			full.WriteString("<li class=\"ons-list__item\"><ins>" + helper.Localise("VersionDiffAdded", lang, 1) + ": " + html.EscapeString(value) + "</ins></li>")
+		}
+		full.WriteString("</ul>")
+	}
+
+	return []string{
+		"<p>" + helper.Localise("VersionDiffIntro", lang, 1, version) + "</p>",
+		"<ul class=\"ons-list\">" + summary.String() + "</ul>",
+		"<details><summary>" + helper.Localise("VersionDiffShowFull", lang, 1) + "</summary>" + full.String() + "</details>",
+	}
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/diff"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMapVersionDiffPanel(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given a version which changes the title and adds a download", t, func() {
+		changes := []diff.Change{
+			{Field: diff.FieldTitle, LocaleKey: "VersionDiffTitle", Removed: []string{"CPI"}, Added: []string{"CPI <UK>"}},
+			{Field: diff.FieldDistributions, LocaleKey: "VersionDiffDistributions", IsList: true, Added: []string{"XLSX (XLSX, 2048 bytes)"}},
+		}
+
+		Convey("When it is mapped to a panel", func() {
+			panel := MapVersionDiffPanel(2, changes, "en")
+
+			Convey("Then the changed fields are summarised", func() {
+				So(panel.Type, ShouldEqual, static.Info)
+				So(panel.Body, ShouldHaveLength, 3)
+				So(panel.Body[0], ShouldEqual, "<p>Compared with published version 2, this version changes:</p>")
+				So(panel.Body[1], ShouldEqual, "<ul class=\"ons-list\">"+
+					"<li class=\"ons-list__item\">Title changed</li>"+
+					"<li class=\"ons-list__item\">Downloads: 1 added, 0 removed</li></ul>")
+			})
+
+			Convey("Then the full differences are collapsed and escaped", func() {
+				So(panel.Body[2], ShouldStartWith, "<details><summary>Show all differences</summary>")
+				So(panel.Body[2], ShouldContainSubstring, "<del>Removed: CPI</del>")
+				So(panel.Body[2], ShouldContainSubstring, "<ins>Added: CPI &lt;UK&gt;</ins>")
+				So(panel.Body[2], ShouldContainSubstring, "<ins>Added: XLSX (XLSX, 2048 bytes)</ins>")
+			})
+		})
+
+		Convey("When it is mapped to a panel in Welsh", func() {
+			panel := MapVersionDiffPanel(2, changes, "cy")
+
+			Convey("Then the panel is localised", func() {
+				So(panel.Type, ShouldEqual, static.Info)
+				So(panel.Body[0], ShouldEqual, "<p>O'i chymharu â fersiwn gyhoeddedig 2, mae'r fersiwn hon yn newid:</p>")
+			})
+		})
+	})
+
+	Convey("Given a version with no changes", t, func() {
+		panel := MapVersionDiffPanel(3, nil, "en")
+
+		Convey("Then the panel says so", func() {
+			So(panel.Body, ShouldResemble, []string{
+				"<p>This version does not change the title, description, downloads, alerts, usage notes or release date of published version 3.</p>",
+			})
+		})
+	})
+
+	Convey("Given an edition with no published version", t, func() {
+		panel := MapVersionDiffPanel(0, nil, "en")
+
+		Convey("Then the panel says there is nothing to compare with", func() {
+			So(panel.Body, ShouldResemble, []string{
+				"<p>No version of this edition has been published yet, so there is nothing to compare this version with.</p>",
+			})
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package mapper
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	dpRendererModel "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/versionstate"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
+)
+
+type flashPanel struct {
+	panelType static.PanelType
+	localeKey string
+}
+
+// flashPanels describe how each flash message is shown on static dataset pages
+var flashPanels = map[flash.Message]flashPanel{
+	flash.ApprovalSucceeded:      {panelType: static.Success, localeKey: "ApprovalSucceeded"},
+	flash.ApprovalFailed:         {panelType: static.Error, localeKey: "ApprovalFailed"},
+	flash.RejectionSucceeded:     {panelType: static.Success, localeKey: "RejectionSucceeded"},
+	flash.RejectionFailed:        {panelType: static.Error, localeKey: "RejectionFailed"},
+	flash.ReturnToDraftSucceeded: {panelType: static.Success, localeKey: "ReturnToDraftSucceeded"},
+	flash.ReturnToDraftFailed:    {panelType: static.Error, localeKey: "ReturnToDraftFailed"},
+}
+
+// transitionLocaleKeys are the prefixes of the locale keys describing each transition
+var transitionLocaleKeys = map[workflow.Transition]string{
+	workflow.Approve:       "Approve",
+	workflow.Reject:        "Reject",
+	workflow.ReturnToDraft: "ReturnToDraft",
+}
+
+type versionStatus struct {
+	localeKey  string
+	statusType string
+}
+
+// versionStatuses describe how each version state is shown to publishers
+var versionStatuses = map[string]versionStatus{
+	dpDatasetApiModels.EditionConfirmedState: {localeKey: "VersionStateDraft", statusType: "info"},
+	dpDatasetApiModels.AssociatedState:       {localeKey: "VersionStateAwaitingApproval", statusType: "pending"},
+	dpDatasetApiModels.ApprovedState:         {localeKey: "VersionStateApproved", statusType: "success"},
+	dpDatasetApiModels.PublishedState:        {localeKey: "VersionStatePublished", statusType: "success"},
+}
+
+// CreateVersionTransitionPage maps the page asking a publisher to confirm a change to the state of a static dataset
+// version. When errorKey is set the page shows the localised error against the reason field.
+func CreateVersionTransitionPage(basePage dpRendererModel.Page, req *http.Request, lang string, d dpDatasetApiModels.Dataset,
+	version dpDatasetApiModels.Version, transition workflow.Transition, errorKey, csrfToken, serviceMessage string, emergencyBannerContent zebedee.EmergencyBanner) versionstate.Page {
+	p := versionstate.Page{
+		Page: basePage,
+	}
+	MapCookiePreferences(req, &p.CookiesPreferencesSet, &p.CookiesPolicy)
+
+	editionID := version.Edition
+	versionID := strconv.Itoa(version.Version)
+	keyPrefix := transitionLocaleKeys[transition]
+
+	p.Language = lang
+	p.Data.Title = helper.Localise(keyPrefix+"ConfirmTitle", lang, 1)
+	p.Data.Body = helper.Localise(keyPrefix+"ConfirmBody", lang, 1, d.Title, editionID, versionID)
+	p.Data.Warning = helper.Localise(keyPrefix+"ConfirmWarning", lang, 1)
+	p.Data.Button = helper.Localise(keyPrefix+"ConfirmButton", lang, 1)
+	p.Data.DatasetTitle = d.Title
+	p.Data.Edition = editionID
+	p.Data.Version = versionID
+	p.Data.ActionURL = req.URL.Path
+	p.Data.VersionURL = strings.TrimSuffix(req.URL.Path, "/"+string(transition))
+	p.Data.RequiresReason = transition.RequiresReason()
+	p.Data.ReasonField = workflow.ReasonField
+	p.Data.CSRFToken = csrfToken
+
+	p.Metadata.Title = p.Data.Title + " - " + d.Title
+	p.DatasetId = d.ID
+	p.DatasetTitle = d.Title
+	p.URI = req.URL.Path
+	p.BetaBannerEnabled = true
+	p.ServiceMessage = serviceMessage
+	p.EmergencyBanner = mapEmergencyBanner(emergencyBannerContent)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	if errorKey != "" {
+		p.Error = dpRendererModel.Error{
+			Title: helper.Localise(errorKey, lang, 1),
+			ErrorItems: []dpRendererModel.ErrorItem{
+				{
+					Description: dpRendererModel.Localisation{
+						LocaleKey: errorKey,
+						Plural:    1,
+					},
+					URL: "#" + workflow.ReasonField,
+				},
+			},
+			Language: lang,
+		}
+	}
+
+	return p
+}
+
+// MapPreview maps the preview banner shown to publishers at the top of a page which can show unpublished content. The
+// state is that of the version shown on the page, or empty for pages which are not about a single version. Nothing is
+// shown outside publishing.
+func MapPreview(isPublishing bool, collectionID, state string) sharedModel.Preview {
+	if !isPublishing {
+		return sharedModel.Preview{}
+	}
+	return sharedModel.Preview{
+		ShowWorkflow: true,
+		Workflow:     MapWorkflow("", collectionID, state, nil),
+	}
+}
+
+// MapWorkflow maps the state of a dataset version, the collection being previewed and the transitions the user can
+// make from it to the preview banner shown to publishers at the top of the version page. Without a state only the
+// collection is shown.
+func MapWorkflow(versionURL, collectionID, state string, transitions []workflow.Transition) sharedModel.Workflow {
+	wf := sharedModel.Workflow{
+		CollectionID: collectionID,
+	}
+	if state == "" {
+		return wf
+	}
+
+	status, ok := versionStatuses[state]
+	if !ok {
+		// states publishers do not usually see are shown as they are named by the dataset API
+		status = versionStatus{statusType: "info"}
+	}
+
+	wf.State = state
+	wf.StateLabel = dpRendererModel.Localisation{Text: state}
+	wf.StatusType = status.statusType
+	wf.IsUnpublished = state != dpDatasetApiModels.PublishedState
+	if status.localeKey != "" {
+		wf.StateLabel = dpRendererModel.Localisation{LocaleKey: status.localeKey, Plural: 1}
+	}
+
+	for _, t := range transitions {
+		wf.Transitions = append(wf.Transitions, sharedModel.WorkflowTransition{
+			Label: dpRendererModel.Localisation{LocaleKey: "VersionState" + transitionLocaleKeys[t], Plural: 1},
+			URL:   versionURL + "/" + string(t),
+		})
+	}
+
+	return wf
+}
+
+// MapFlashPanel maps a flash message to the panel shown at the top of a static dataset page. Unknown messages are
+// ignored, returning false.
+func MapFlashPanel(m flash.Message, lang string) (static.Panel, bool) {
+	fp, ok := flashPanels[m]
+	if !ok {
+		return static.Panel{}, false
+	}
+
+	return static.Panel{
+		Type:       fp.panelType,
+		Body:       []string{helper.Localise(fp.localeKey, lang, 1)},
+		CSSClasses: []string{"ons-u-mt-m", "ons-u-mb-l"},
+		Language:   lang,
+	}, true
+}
@@ -0,0 +1,201 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-design-system-go/helper"
+	core "github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	dpDatasetApiModels "github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/flash"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/mapper/mocks"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/static"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/workflow"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateVersionTransitionPage(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	dataset := dpDatasetApiModels.Dataset{ID: "cpih01", Title: "Consumer prices"}
+	version := dpDatasetApiModels.Version{Edition: "time-series", Version: 3, State: "associated"}
+
+	Convey("Given a static dataset version awaiting approval", t, func() {
+		req := httptest.NewRequest("GET", "/economy/datasets/cpih01/editions/time-series/versions/3/approve", nil)
+
+		Convey("When the approval confirmation page is mapped", func() {
+			page := CreateVersionTransitionPage(core.Page{}, req, "en", dataset, version, workflow.Approve, "", "token", "", zebedee.EmergencyBanner{})
+
+			Convey("Then the version being approved is described", func() {
+				So(page.Data.DatasetTitle, ShouldEqual, "Consumer prices")
+				So(page.Data.Edition, ShouldEqual, "time-series")
+				So(page.Data.Version, ShouldEqual, "3")
+				So(page.Data.Title, ShouldEqual, "Approve this version")
+				So(page.Data.Body, ShouldEqual, "You are about to approve Consumer prices, edition time-series, version 3.")
+				So(page.Metadata.Title, ShouldEqual, "Approve this version - Consumer prices")
+			})
+
+			Convey("Then the form posts back to the transition URL with the csrf token", func() {
+				So(page.Data.ActionURL, ShouldEqual, "/economy/datasets/cpih01/editions/time-series/versions/3/approve")
+				So(page.Data.VersionURL, ShouldEqual, "/economy/datasets/cpih01/editions/time-series/versions/3")
+				So(page.Data.CSRFToken, ShouldEqual, "token")
+			})
+
+			Convey("Then no reason is asked for", func() {
+				So(page.Data.RequiresReason, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the rejection confirmation page is mapped", func() {
+			req := httptest.NewRequest("GET", "/economy/datasets/cpih01/editions/time-series/versions/3/reject", nil)
+			page := CreateVersionTransitionPage(core.Page{}, req, "en", dataset, version, workflow.Reject, "", "token", "", zebedee.EmergencyBanner{})
+
+			Convey("Then the publisher is asked for a reason", func() {
+				So(page.Data.Title, ShouldEqual, "Reject this version")
+				So(page.Data.RequiresReason, ShouldBeTrue)
+				So(page.Data.ReasonField, ShouldEqual, workflow.ReasonField)
+				So(page.Data.VersionURL, ShouldEqual, "/economy/datasets/cpih01/editions/time-series/versions/3")
+				So(page.Error.ErrorItems, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the rejection confirmation page is mapped after a submission without a reason", func() {
+			req := httptest.NewRequest("POST", "/economy/datasets/cpih01/editions/time-series/versions/3/reject", nil)
+			page := CreateVersionTransitionPage(core.Page{}, req, "en", dataset, version, workflow.Reject, "VersionStateReasonRequired", "token", "", zebedee.EmergencyBanner{})
+
+			Convey("Then the localised error links to the reason field", func() {
+				So(page.Error.Title, ShouldEqual, "Enter a reason")
+				So(page.Error.ErrorItems, ShouldHaveLength, 1)
+				So(page.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "VersionStateReasonRequired")
+				So(page.Error.ErrorItems[0].URL, ShouldEqual, "#"+workflow.ReasonField)
+				So(page.Data.ActionURL, ShouldEqual, "/economy/datasets/cpih01/editions/time-series/versions/3/reject")
+			})
+		})
+	})
+}
+
+func TestMapWorkflow(t *testing.T) {
+	versionURL := "/economy/datasets/cpih01/editions/time-series/versions/3"
+
+	Convey("Given a version awaiting approval and the transitions available to an admin", t, func() {
+		wf := MapWorkflow(versionURL, "collection-1", "associated", []workflow.Transition{workflow.Approve, workflow.Reject, workflow.ReturnToDraft})
+
+		Convey("Then the collection being previewed is shown", func() {
+			So(wf.CollectionID, ShouldEqual, "collection-1")
+		})
+
+		Convey("Then the version is shown as unpublished", func() {
+			So(wf.IsUnpublished, ShouldBeTrue)
+		})
+
+		Convey("Then the state is shown as pending", func() {
+			So(wf.State, ShouldEqual, "associated")
+			So(wf.StateLabel, ShouldResemble, core.Localisation{LocaleKey: "VersionStateAwaitingApproval", Plural: 1})
+			So(wf.StatusType, ShouldEqual, "pending")
+		})
+
+		Convey("Then a link is given to confirm each transition", func() {
+			So(wf.Transitions, ShouldResemble, []sharedModel.WorkflowTransition{
+				{Label: core.Localisation{LocaleKey: "VersionStateApprove", Plural: 1}, URL: versionURL + "/approve"},
+				{Label: core.Localisation{LocaleKey: "VersionStateReject", Plural: 1}, URL: versionURL + "/reject"},
+				{Label: core.Localisation{LocaleKey: "VersionStateReturnToDraft", Plural: 1}, URL: versionURL + "/return-to-draft"},
+			})
+		})
+	})
+
+	Convey("Given a published version previewed outside a collection", t, func() {
+		wf := MapWorkflow(versionURL, "", "published", nil)
+
+		Convey("Then no collection or unpublished warning is shown", func() {
+			So(wf.CollectionID, ShouldBeEmpty)
+			So(wf.IsUnpublished, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a version in a state publishers do not usually see", t, func() {
+		wf := MapWorkflow(versionURL, "", "detached", nil)
+
+		Convey("Then the state is shown as named by the dataset API with no transitions", func() {
+			So(wf.StateLabel, ShouldResemble, core.Localisation{Text: "detached"})
+			So(wf.StatusType, ShouldEqual, "info")
+			So(wf.Transitions, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestMapPreview(t *testing.T) {
+	Convey("Given a page previewed in a collection in publishing", t, func() {
+		Convey("When the page shows a single version", func() {
+			preview := MapPreview(true, "collection-1", "edition-confirmed")
+
+			Convey("Then the collection and state of the version are shown without transitions", func() {
+				So(preview.ShowWorkflow, ShouldBeTrue)
+				So(preview.Workflow.CollectionID, ShouldEqual, "collection-1")
+				So(preview.Workflow.StateLabel, ShouldResemble, core.Localisation{LocaleKey: "VersionStateDraft", Plural: 1})
+				So(preview.Workflow.IsUnpublished, ShouldBeTrue)
+				So(preview.Workflow.Transitions, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the page is not about a single version", func() {
+			preview := MapPreview(true, "collection-1", "")
+
+			Convey("Then only the collection is shown", func() {
+				So(preview.ShowWorkflow, ShouldBeTrue)
+				So(preview.Workflow, ShouldResemble, sharedModel.Workflow{CollectionID: "collection-1"})
+			})
+		})
+	})
+
+	Convey("Given a page outside publishing", t, func() {
+		preview := MapPreview(false, "", "published")
+
+		Convey("Then no preview banner is shown", func() {
+			So(preview, ShouldResemble, sharedModel.Preview{})
+		})
+	})
+}
+
+func TestMapFlashPanel(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+
+	Convey("Given the flash message of a successful approval", t, func() {
+		panel, ok := MapFlashPanel(flash.ApprovalSucceeded, "en")
+
+		Convey("Then it is mapped to a success panel", func() {
+			So(ok, ShouldBeTrue)
+			So(panel.Type, ShouldEqual, static.Success)
+			So(panel.Body, ShouldResemble, []string{"This version has been approved."})
+		})
+	})
+
+	Convey("Given the flash message of a failed approval", t, func() {
+		panel, ok := MapFlashPanel(flash.ApprovalFailed, "cy")
+
+		Convey("Then it is mapped to a localised error panel", func() {
+			So(ok, ShouldBeTrue)
+			So(panel.Type, ShouldEqual, static.Error)
+			So(panel.Body, ShouldResemble, []string{"Nid oedd modd cymeradwyo'r fersiwn hon. Rhowch gynnig arall arni yn nes ymlaen."})
+		})
+	})
+
+	Convey("Given the flash message of a successful rejection", t, func() {
+		panel, ok := MapFlashPanel(flash.RejectionSucceeded, "en")
+
+		Convey("Then it is mapped to a success panel", func() {
+			So(ok, ShouldBeTrue)
+			So(panel.Type, ShouldEqual, static.Success)
+			So(panel.Body, ShouldResemble, []string{"This version has been rejected and returned to draft."})
+		})
+	})
+
+	Convey("Given an unknown flash message", t, func() {
+		_, ok := MapFlashPanel(flash.Message("<script>"), "en")
+
+		Convey("Then no panel is mapped", func() {
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
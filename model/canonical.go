@@ -0,0 +1,13 @@
+package model
+
+// Canonical contains the canonical URL of a page along with the URLs of its alternate language versions
+type Canonical struct {
+	URL        string      `json:"url"`
+	Alternates []Alternate `json:"alternates"`
+}
+
+// Alternate is the URL of a page in a given language, rendered as an hreflang alternate
+type Alternate struct {
+	HrefLang string `json:"hreflang"`
+	URL      string `json:"url"`
+}
@@ -11,14 +11,18 @@ import (
 // Page contains data for the census landing page
 type Page struct {
 	model.Page
-	DatasetLandingPage  DatasetLandingPage    `json:"data"`
-	Version             sharedModel.Version   `json:"version"`
-	Versions            []sharedModel.Version `json:"versions"`
-	ID                  string                `json:"id"`
-	ContactDetails      contact.Details       `json:"contact_details"`
-	HasContactDetails   bool                  `json:"has_contact_details"`
-	IsNationalStatistic bool                  `json:"is_national_statistic"`
-	ShowCensusBranding  bool                  `json:"show_census_branding"`
+	sharedModel.Preview
+	DatasetLandingPage  DatasetLandingPage         `json:"data"`
+	Version             sharedModel.Version        `json:"version"`
+	Versions            []sharedModel.Version      `json:"versions"`
+	ID                  string                     `json:"id"`
+	ContactDetails      contact.Details            `json:"contact_details"`
+	HasContactDetails   bool                       `json:"has_contact_details"`
+	IsNationalStatistic bool                       `json:"is_national_statistic"`
+	ShowCensusBranding  bool                       `json:"show_census_branding"`
+	Canonical           sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata      sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce            string                     `json:"-"`
 }
 
 // DatasetLandingPage contains properties related to the census dataset landing page
@@ -42,4 +46,7 @@ type DatasetLandingPage struct {
 	OSRLogo             osrlogo.OSRLogo                  `json:"osr_logo"`
 	FeedbackAPIURL      string                           `json:"feedback_api_url"`
 	ImproveResults      model.Collapsible                `json:"improve_results"`
+	DownloadsEventsURL  string                           `json:"downloads_events_url"`
+	FilterSpecURL       string                           `json:"filter_spec_url"`
+	SDCDetailURL        string                           `json:"sdc_detail_url"`
 }
@@ -2,6 +2,7 @@ package custom
 
 import (
 	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 )
 
 // Page contains data for the census landing page
@@ -11,6 +12,9 @@ type Page struct {
 	IsNationalStatistic     bool                    `json:"is_national_statistic"`
 	ShowCensusBranding      bool                    `json:"show_census_branding"`
 	FeedbackAPIURL          string                  `json:"feedback_api_url"`
+	Canonical               sharedModel.Canonical   `json:"canonical"`
+	CSRFToken               string                  `json:"-"`
+	CSPNonce                string                  `json:"-"`
 }
 
 // CreateDatasetPage contains properties related to the create dataset  page
@@ -24,3 +28,26 @@ type PopulationType struct {
 	Label       string
 	Description string
 }
+
+// ConfirmPage contains data for the page confirming the selections of a custom dataset link before it is created
+type ConfirmPage struct {
+	model.Page
+	ConfirmCustomDatasetPage ConfirmCustomDatasetPage `json:"data"`
+	ShowCensusBranding       bool                     `json:"show_census_branding"`
+	CSRFToken                string                   `json:"-"`
+	CSPNonce                 string                   `json:"-"`
+}
+
+// ConfirmCustomDatasetPage contains the selections of a custom dataset link, which are submitted to create it
+type ConfirmCustomDatasetPage struct {
+	PopulationType PopulationType
+	AreaType       Selection
+	Dimensions     []Selection
+	DimensionIDs   string
+}
+
+// Selection is an area type or dimension chosen for a custom dataset
+type Selection struct {
+	ID    string
+	Label string
+}
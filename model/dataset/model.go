@@ -2,13 +2,18 @@ package dataset
 
 import (
 	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/contact"
 )
 
 // Page contains data re-used for each page type a Data struct for data specific to the page type
 type Page struct {
 	model.Page
-	DatasetPage DatasetPage `json:"data"`
+	sharedModel.Preview
+	DatasetPage    DatasetPage                `json:"data"`
+	Canonical      sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce       string                     `json:"-"`
 	contact.Details
 }
 
@@ -4,6 +4,7 @@ import (
 	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 
 	"github.com/ONSdigital/dis-design-system-go/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/contact"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/osrlogo"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/staticlegacy"
@@ -21,8 +22,12 @@ const (
 // Page contains data re-used for each page type a Data struct for data specific to the page type
 type Page struct {
 	model.Page
-	DatasetLandingPage DatasetLandingPage `json:"data"`
-	ContactDetails     contact.Details    `json:"contact_details"`
+	sharedModel.Preview
+	DatasetLandingPage DatasetLandingPage         `json:"data"`
+	ContactDetails     contact.Details            `json:"contact_details"`
+	Canonical          sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata     sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce           string                     `json:"-"`
 }
 
 // DatasetLandingPage represents the data on the dataset landing page
@@ -49,6 +54,7 @@ type DatasetLandingPage struct {
 	UsageNotes               []UsageNote             `json:"UsageNotes"`
 	Alerts                   []Alert                 `json:"alerts"`
 	OSRLogo                  osrlogo.OSRLogo         `json:"osr_logo"`
+	Panels                   []census.Panel          `json:"panels"`
 }
 
 // UsageNote represents data for a single usage note
@@ -7,6 +7,7 @@ type Dimension struct {
 	Title             string   `json:"title"`
 	Name              string   `json:"name"`
 	Values            []string `json:"values"`
+	Gaps              []string `json:"gaps"`
 	OptionsURL        string   `json:"options_url"`
 	TotalItems        int      `json:"total_items"`
 	Description       string   `json:"description"`
@@ -16,6 +17,7 @@ type Dimension struct {
 	IsPopulationType  bool     `json:"is_population_type"`
 	ShowChange        bool     `json:"show_change"`
 	IsTruncated       bool     `json:"is_truncated"`
+	IsTimeSummary     bool     `json:"is_time_summary"`
 	TruncateLink      string   `json:"truncate_link"`
 	ID                string   `json:"id"`
 }
@@ -0,0 +1,36 @@
+package dimensionoptions
+
+import (
+	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+)
+
+// Page contains the data re-used on each page as well as the data for the current page
+type Page struct {
+	model.Page
+	sharedModel.Preview
+	Data      DimensionOptions      `json:"data"`
+	Canonical sharedModel.Canonical `json:"canonical"`
+	CSPNonce  string                `json:"-"`
+}
+
+// DimensionOptions represents the data on the dimension options page
+type DimensionOptions struct {
+	DatasetTitle    string   `json:"dataset_title"`
+	DimensionName   string   `json:"dimension_name"`
+	DimensionTitle  string   `json:"dimension_title"`
+	Description     string   `json:"description"`
+	Options         []Option `json:"options"`
+	Query           string   `json:"query"`
+	SortBy          string   `json:"sort_by"`
+	TotalOptions    int      `json:"total_options"`
+	MatchingOptions int      `json:"matching_options"`
+	VersionURL      string   `json:"version_url"`
+	CSVURL          string   `json:"csv_url"`
+}
+
+// Option represents a single option of a dimension
+type Option struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
@@ -2,6 +2,7 @@ package editions
 
 import (
 	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/contact"
 	filterable "github.com/ONSdigital/dp-frontend-dataset-controller/model/datasetLandingPageFilterable"
 )
@@ -9,10 +10,13 @@ import (
 // Page contains data re-used for each page type a Data struct for data specific to the page type
 type Page struct {
 	model.Page
+	sharedModel.Preview
 	filterable.DatasetLandingPage
-	ContactDetails contact.Details `json:"contact_details"`
-	Editions       []List          `json:"editions"`
-	ShowApprove    bool            `json:"show_approve"`
+	ContactDetails contact.Details            `json:"contact_details"`
+	Editions       []List                     `json:"editions"`
+	Canonical      sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce       string                     `json:"-"`
 }
 
 // List contains data for a single edition
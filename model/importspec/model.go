@@ -0,0 +1,19 @@
+package importspec
+
+import (
+	"github.com/ONSdigital/dis-design-system-go/model"
+)
+
+// Page contains the data re-used on each page as well as the data for the page importing a filter specification
+type Page struct {
+	model.Page
+	Data     ImportFilterSpec `json:"data"`
+	CSPNonce string           `json:"-"`
+}
+
+// ImportFilterSpec represents the data on the page importing the selections of a previously downloaded filter output
+type ImportFilterSpec struct {
+	Spec      string `json:"spec"`
+	SpecField string `json:"spec_field"`
+	CSRFToken string `json:"-"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import core "github.com/ONSdigital/dis-design-system-go/model"
+
+// Preview contains the banner shown to publishers previewing a page in publishing
+type Preview struct {
+	ShowWorkflow bool     `json:"show_workflow"`
+	Workflow     Workflow `json:"workflow"`
+}
+
+// Workflow contains the collection a page is being previewed in and, for a page showing a single version, the
+// publishing state of the version and the transitions the user can make from it
+type Workflow struct {
+	CollectionID  string               `json:"collection_id"`
+	State         string               `json:"state"`
+	StateLabel    core.Localisation    `json:"state_label"`
+	StatusType    string               `json:"status_type"`
+	IsUnpublished bool                 `json:"is_unpublished"`
+	Transitions   []WorkflowTransition `json:"transitions"`
+}
+
+// WorkflowTransition represents a link to the page confirming a transition
+type WorkflowTransition struct {
+	Label core.Localisation `json:"label"`
+	URL   string            `json:"url"`
+}
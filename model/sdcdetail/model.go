@@ -0,0 +1,44 @@
+package sdcdetail
+
+import (
+	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
+)
+
+// Page contains the data re-used on each page as well as the data for the current page
+type Page struct {
+	model.Page
+	Data      SDCDetail             `json:"data"`
+	Canonical sharedModel.Canonical `json:"canonical"`
+	CSRFToken string                `json:"-"`
+	CSPNonce  string                `json:"-"`
+}
+
+// SDCDetail represents the data on the statistical disclosure control detail page of a filter output
+type SDCDetail struct {
+	DatasetTitle    string            `json:"dataset_title"`
+	FilterOutputURL string            `json:"filter_output_url"`
+	Panels          []census.Panel    `json:"panels"`
+	AreaTypes       []AreaTypeCount   `json:"area_types"`
+	Suggestions     []Suggestion      `json:"suggestions"`
+	ImproveResults  model.Collapsible `json:"improve_results"`
+}
+
+// AreaTypeCount is the number of areas of an area type which are blocked or can be published
+type AreaTypeCount struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	Passed     int    `json:"passed"`
+	Blocked    int    `json:"blocked"`
+	Total      int    `json:"total"`
+	IsSelected bool   `json:"is_selected"`
+}
+
+// Suggestion describes how a change to a dimension of the filter output would affect the number of blocked areas
+type Suggestion struct {
+	DimensionName       string `json:"dimension_name"`
+	DimensionTitle      string `json:"dimension_title"`
+	BlockedWithout      int    `json:"blocked_without"`
+	CategorisationCount int    `json:"categorisation_count"`
+}
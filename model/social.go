@@ -0,0 +1,16 @@
+package model
+
+import core "github.com/ONSdigital/dis-design-system-go/model"
+
+// SocialMetadata contains the Open Graph and Twitter Card details rendered in the head of a page
+type SocialMetadata struct {
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	URL              string            `json:"url"`
+	SiteName         core.Localisation `json:"site_name"`
+	Type             string            `json:"type"`
+	Locale           string            `json:"locale"`
+	AlternateLocales []string          `json:"alternate_locales"`
+	ImageURL         string            `json:"image_url,omitempty"`
+	TwitterCard      string            `json:"twitter_card"`
+}
@@ -12,17 +12,20 @@ import (
 // Page contains data for the census landing page
 type Page struct {
 	model.Page
-	DatasetLandingPage  DatasetLandingPage    `json:"data"`
-	Version             sharedModel.Version   `json:"version"`
-	Versions            []sharedModel.Version `json:"versions"`
-	ID                  string                `json:"id"`
-	ContactDetails      contact.Details       `json:"contact_details"`
-	HasContactDetails   bool                  `json:"has_contact_details"`
-	IsNationalStatistic bool                  `json:"is_national_statistic"`
-	ShowCensusBranding  bool                  `json:"show_census_branding"`
-	Publisher           publisher.Publisher   `json:"publisher,omitempty"`
-	UsageNotes          []UsageNote           `json:"usage_notes"`
-	ShowApprove         bool                  `json:"show_approve"`
+	sharedModel.Preview
+	DatasetLandingPage  DatasetLandingPage         `json:"data"`
+	Version             sharedModel.Version        `json:"version"`
+	Versions            []sharedModel.Version      `json:"versions"`
+	ID                  string                     `json:"id"`
+	ContactDetails      contact.Details            `json:"contact_details"`
+	HasContactDetails   bool                       `json:"has_contact_details"`
+	IsNationalStatistic bool                       `json:"is_national_statistic"`
+	ShowCensusBranding  bool                       `json:"show_census_branding"`
+	Publisher           publisher.Publisher        `json:"publisher,omitempty"`
+	UsageNotes          []UsageNote                `json:"usage_notes"`
+	Canonical           sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata      sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce            string                     `json:"-"`
 }
 
 // StaticOverviewPage contains properties related to the static dataset
@@ -1,34 +1,17 @@
 package static
 
-type PanelType int
+import "github.com/ONSdigital/dp-frontend-dataset-controller/model/census"
+
+// PanelType is the type of panel shown, which is shared with census landing pages
+type PanelType = census.PanelType
 
 const (
-	Info PanelType = iota
-	Pending
-	Success
-	Error
+	Info    = census.Info
+	Pending = census.Pending
+	Success = census.Success
+	Error   = census.Error
 )
 
-// Panel contains the data required to populate a panel UI component
-type Panel struct {
-	Type        PanelType `json:"type"`
-	DisplayIcon bool      `json:"display_icon"`
-	CSSClasses  []string  `json:"css_classes"`
-	Body        []string  `json:"body"`
-	Language    string    `json:"language"`
-}
-
-// FuncGetPanelType returns the panel type as a string
-func (p Panel) FuncGetPanelType() (panelType string) {
-	switch p.Type {
-	case Info:
-		return "info"
-	case Pending:
-		return "pending"
-	case Success:
-		return "success"
-	case Error:
-		return "error"
-	}
-	return panelType
-}
+// Panel contains the data required to populate a panel UI component. It is shared with census landing pages so that
+// the same panels can be mapped for both.
+type Panel = census.Panel
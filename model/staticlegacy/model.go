@@ -2,6 +2,7 @@ package staticlegacy
 
 import (
 	"github.com/ONSdigital/dis-design-system-go/model"
+	sharedModel "github.com/ONSdigital/dp-frontend-dataset-controller/model"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/contact"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/osrlogo"
 	"github.com/ONSdigital/dp-frontend-dataset-controller/model/related"
@@ -10,8 +11,12 @@ import (
 // Page contains data re-used for each page type a Data struct for data specific to the page type
 type Page struct {
 	model.Page
-	DatasetLandingPage DatasetLandingPage `json:"data"`
-	FilterID           string             `json:"filter_id"`
+	sharedModel.Preview
+	DatasetLandingPage DatasetLandingPage         `json:"data"`
+	FilterID           string                     `json:"filter_id"`
+	Canonical          sharedModel.Canonical      `json:"canonical"`
+	SocialMetadata     sharedModel.SocialMetadata `json:"social_metadata"`
+	CSPNonce           string                     `json:"-"`
 	contact.Details
 }
 
@@ -8,7 +8,10 @@ import (
 // Page contains the data re-used on each page as well as the data for the current page
 type Page struct {
 	model.Page
-	Data VersionsList `json:"data"`
+	sharedModel.Preview
+	Data      VersionsList          `json:"data"`
+	Canonical sharedModel.Canonical `json:"canonical"`
+	CSPNonce  string                `json:"-"`
 }
 
 // VersionsList represents the data on the versions list page
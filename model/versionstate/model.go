@@ -0,0 +1,28 @@
+package versionstate
+
+import (
+	"github.com/ONSdigital/dis-design-system-go/model"
+)
+
+// Page contains the data re-used on each page as well as the data for the current page
+type Page struct {
+	model.Page
+	Data     Transition `json:"data"`
+	CSPNonce string     `json:"-"`
+}
+
+// Transition represents the data on the page confirming a change to the state of a static dataset version
+type Transition struct {
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	Warning        string `json:"warning"`
+	Button         string `json:"button"`
+	DatasetTitle   string `json:"dataset_title"`
+	Edition        string `json:"edition"`
+	Version        string `json:"version"`
+	VersionURL     string `json:"version_url"`
+	ActionURL      string `json:"action_url"`
+	RequiresReason bool   `json:"requires_reason"`
+	ReasonField    string `json:"reason_field"`
+	CSRFToken      string `json:"-"`
+}
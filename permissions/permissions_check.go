@@ -3,25 +3,139 @@ package permissions
 import (
 	"context"
 	"fmt"
-	"slices"
-
 	"strings"
+	"sync"
 
+	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	"github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+)
 
-	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
+// Permissions checked by the controller
+const (
+	DatasetsApprove = "datasets:approve"
+	DatasetsEdit    = "datasets:edit"
 )
 
-func CheckIsAdmin(ctx context.Context, token string, authorisation auth.Middleware) (bool, error) {
-	userToken := strings.ReplaceAll(token, request.BearerPrefix, "")
-	entityData, err := authorisation.Parse(userToken)
+// datasetIDAttribute is the attribute permission policies are scoped to a dataset by
+const datasetIDAttribute = "dataset_id"
+
+// TokenParser verifies a user's JWT token, returning the entity data it contains. It is satisfied by the
+// dp-authorisation middleware.
+type TokenParser interface {
+	Parse(token string) (*permsdk.EntityData, error)
+}
+
+// Checker checks the permissions of users through the dp-authorisation permissions checker
+type Checker struct {
+	parser  TokenParser
+	checker auth.PermissionsChecker
+}
+
+// NewChecker returns a Checker verifying tokens with the parser and checking permissions with the checker. The checker
+// may be nil when authorisation is disabled, as tokens then carry no identity to check permissions for.
+func NewChecker(parser TokenParser, checker auth.PermissionsChecker) *Checker {
+	return &Checker{
+		parser:  parser,
+		checker: checker,
+	}
+}
+
+// ForRequest returns the permissions of the user making a request. Results are memoised, so the returned value should
+// only be used for the lifetime of the request.
+func (c *Checker) ForRequest(token string) *Request {
+	return &Request{
+		checker: c,
+		token:   strings.ReplaceAll(token, request.BearerPrefix, ""),
+		results: map[check]bool{},
+	}
+}
+
+type check struct {
+	permission string
+	datasetID  string
+}
+
+// Request holds the memoised permissions of the user making a request
+type Request struct {
+	checker *Checker
+	token   string
+
+	mu         sync.Mutex
+	parsed     bool
+	entityData *permsdk.EntityData
+	parseErr   error
+	results    map[check]bool
+}
+
+// HasDatasetPermission reports whether the user has the permission on the dataset
+func (r *Request) HasDatasetPermission(ctx context.Context, permission, datasetID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := check{permission: permission, datasetID: datasetID}
+	if result, ok := r.results[key]; ok {
+		return result, nil
+	}
+
+	if err := r.parse(); err != nil {
+		return false, fmt.Errorf("check permission: %w", err)
+	}
+
+	// without an identity, for example when authorisation is disabled, the user has no permissions
+	if r.entityData == nil {
+		r.results[key] = false
+		return false, nil
+	}
+
+	result, err := r.checker.checker.HasPermission(ctx, *r.entityData, permission, map[string]string{datasetIDAttribute: datasetID})
 	if err != nil {
-		return false, fmt.Errorf("check admin: failed to parse user JWT token: %w", err)
+		return false, fmt.Errorf("check permission %s: %w", permission, err)
+	}
+
+	r.results[key] = result
+	return result, nil
+}
+
+// UserID returns the ID of the user making the request, as given in their token. It is empty when the token carries no
+// identity, for example when authorisation is disabled.
+func (r *Request) UserID() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.parse(); err != nil {
+		return "", err
+	}
+	if r.entityData == nil {
+		return "", nil
 	}
+	return r.entityData.UserID, nil
+}
 
-	if slices.Contains(entityData.Groups, "role-admin") {
-		return true, nil
+// parse verifies the user's token the first time it is needed. The caller must hold the lock.
+func (r *Request) parse() error {
+	if !r.parsed {
+		r.entityData, r.parseErr = r.checker.parser.Parse(r.token)
+		r.parsed = true
+	}
+	if r.parseErr != nil {
+		return fmt.Errorf("failed to parse user JWT token: %w", r.parseErr)
 	}
+	return nil
+}
+
+// ForDataset returns the user's permissions on a single dataset
+func (r *Request) ForDataset(datasetID string) Dataset {
+	return Dataset{request: r, datasetID: datasetID}
+}
+
+// Dataset holds the permissions of the user making a request on a single dataset
+type Dataset struct {
+	request   *Request
+	datasetID string
+}
 
-	return false, nil
+// Has reports whether the user has the permission on the dataset
+func (d Dataset) Has(ctx context.Context, permission string) (bool, error) {
+	return d.request.HasDatasetPermission(ctx, permission, d.datasetID)
 }
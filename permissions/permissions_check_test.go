@@ -1,60 +1,146 @@
 package permissions
 
 import (
-	"errors"
+	"context"
 	"testing"
 
-	authorisation "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
-	"github.com/ONSdigital/dp-authorisation/v2/authorisationtest"
-	"github.com/ONSdigital/dp-permissions-api/sdk"
+	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions/permissionstest"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+// countingChecker counts the permission checks made through it
+type countingChecker struct {
+	auth.PermissionsChecker
+	calls int
+}
+
+func (c *countingChecker) HasPermission(ctx context.Context, entityData permsdk.EntityData, permission string, attributes map[string]string) (bool, error) {
+	c.calls++
+	return c.PermissionsChecker.HasPermission(ctx, entityData, permission, attributes)
+}
+
+type nilParser struct{}
+
+func (nilParser) Parse(string) (*permsdk.EntityData, error) {
+	return nil, nil
+}
+
 func TestPermissionsCheck(t *testing.T) {
-	Convey("When a valid JWT token is submitted", t, func() {
-		Convey("test user is a publishing manager (admin)", func() {
-			adminJWTToken := authorisationtest.AdminJWTToken
+	issuer, err := permissionstest.NewIssuer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := permsdk.Bundle{
+		DatasetsApprove: {
+			"groups/role-admin": {{}},
+			"users/cpih-editor": {permissionstest.DatasetPolicy("cpih01")},
+		},
+		DatasetsEdit: {
+			"groups/role-publisher": {{}},
+		},
+	}
 
-			middlewareMock := &authorisation.MiddlewareMock{
-				ParseFunc: func(token string) (*sdk.EntityData, error) {
-					return &sdk.EntityData{
-						Groups: []string{"role-admin"},
-					}, nil
-				},
-			}
-			adminUser, err := CheckIsAdmin(t.Context(), adminJWTToken, middlewareMock)
+	Convey("Given a user in the admin group", t, func() {
+		token, err := issuer.Token("admin", "role-admin")
+		So(err, ShouldBeNil)
+		perms := NewChecker(issuer, permissionstest.NewPermissionsChecker(bundle)).ForRequest(token)
+
+		Convey("Then they can approve any dataset", func() {
+			canApprove, err := perms.HasDatasetPermission(t.Context(), DatasetsApprove, "any-dataset")
 			So(err, ShouldBeNil)
-			So(adminUser, ShouldBeTrue)
+			So(canApprove, ShouldBeTrue)
 		})
 
-		Convey("test user is a publisher", func() {
-			publisherJWTToken := authorisationtest.PublisherJWTToken
+		Convey("Then their identity is taken from the token", func() {
+			userID, err := perms.UserID()
+			So(err, ShouldBeNil)
+			So(userID, ShouldEqual, "admin")
+		})
 
-			middlewareMock := &authorisation.MiddlewareMock{
-				ParseFunc: func(token string) (*sdk.EntityData, error) {
-					return &sdk.EntityData{
-						Groups: []string{"role-publisher"},
-					}, nil
-				},
-			}
-			adminUser, err := CheckIsAdmin(t.Context(), publisherJWTToken, middlewareMock)
+		Convey("Then they do not have permissions they have not been granted", func() {
+			canEdit, err := perms.HasDatasetPermission(t.Context(), DatasetsEdit, "any-dataset")
 			So(err, ShouldBeNil)
-			So(adminUser, ShouldBeFalse)
+			So(canEdit, ShouldBeFalse)
 		})
 	})
 
-	Convey("When an invalid JWT token is submitted", t, func() {
-		Convey("an error will be returned", func() {
-			invalidToken := "test-123-token"
+	Convey("Given a user who can only approve a single dataset", t, func() {
+		token, err := issuer.Token("cpih-editor", "role-publisher")
+		So(err, ShouldBeNil)
+		perms := NewChecker(issuer, permissionstest.NewPermissionsChecker(bundle)).ForRequest(token)
+
+		Convey("Then they can approve that dataset", func() {
+			canApprove, err := perms.ForDataset("cpih01").Has(t.Context(), DatasetsApprove)
+			So(err, ShouldBeNil)
+			So(canApprove, ShouldBeTrue)
+		})
+
+		Convey("Then they cannot approve other datasets", func() {
+			canApprove, err := perms.ForDataset("weekly-deaths").Has(t.Context(), DatasetsApprove)
+			So(err, ShouldBeNil)
+			So(canApprove, ShouldBeFalse)
+		})
 
-			middlewareMock := &authorisation.MiddlewareMock{
-				ParseFunc: func(token string) (*sdk.EntityData, error) {
-					return nil, errors.New("jwt token is not valid")
-				},
-			}
-			_, err := CheckIsAdmin(t.Context(), invalidToken, middlewareMock)
+		Convey("Then they keep the permissions of their groups", func() {
+			canEdit, err := perms.ForDataset("weekly-deaths").Has(t.Context(), DatasetsEdit)
+			So(err, ShouldBeNil)
+			So(canEdit, ShouldBeTrue)
+		})
+	})
+
+	Convey("Given the same permission is checked several times in a request", t, func() {
+		token, err := issuer.Token("admin", "role-admin")
+		So(err, ShouldBeNil)
+		checker := &countingChecker{PermissionsChecker: permissionstest.NewPermissionsChecker(bundle)}
+		perms := NewChecker(issuer, checker).ForRequest(token)
+
+		for range 3 {
+			_, err = perms.HasDatasetPermission(t.Context(), DatasetsApprove, "cpih01")
+			So(err, ShouldBeNil)
+		}
+		_, err = perms.HasDatasetPermission(t.Context(), DatasetsApprove, "weekly-deaths")
+		So(err, ShouldBeNil)
+
+		Convey("Then the permissions checker is only asked once per permission and dataset", func() {
+			So(checker.calls, ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given a token signed by an unknown key", t, func() {
+		other, err := permissionstest.NewIssuer()
+		So(err, ShouldBeNil)
+		token, err := other.Token("admin", "role-admin")
+		So(err, ShouldBeNil)
+		perms := NewChecker(issuer, permissionstest.NewPermissionsChecker(bundle)).ForRequest(token)
+
+		Convey("Then an error is returned", func() {
+			_, err := perms.HasDatasetPermission(t.Context(), DatasetsApprove, "cpih01")
 			So(err, ShouldNotBeNil)
-			So(err.Error(), ShouldContainSubstring, "jwt token is not valid")
+			So(err.Error(), ShouldContainSubstring, "failed to parse user JWT token")
+		})
+
+		Convey("Then the user cannot be identified", func() {
+			_, err := perms.UserID()
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given authorisation is disabled so tokens carry no identity", t, func() {
+		perms := NewChecker(nilParser{}, nil).ForRequest("token")
+
+		Convey("Then the user has no permissions", func() {
+			canApprove, err := perms.HasDatasetPermission(t.Context(), DatasetsApprove, "cpih01")
+			So(err, ShouldBeNil)
+			So(canApprove, ShouldBeFalse)
+		})
+
+		Convey("Then the user has no identity", func() {
+			userID, err := perms.UserID()
+			So(err, ShouldBeNil)
+			So(userID, ShouldBeEmpty)
 		})
 	})
 }
@@ -0,0 +1,98 @@
+// Package permissionstest signs JWT tokens with a locally generated key and checks permissions against a fixed
+// bundle, so permission checks can be tested without a live identity service or permissions API.
+package permissionstest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"time"
+
+	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	authJWT "github.com/ONSdigital/dp-authorisation/v2/jwt"
+	authPermissions "github.com/ONSdigital/dp-authorisation/v2/permissions"
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const keyID = "permissionstest"
+
+// Issuer signs JWT tokens in the format issued by the identity service, and verifies them in the same way the
+// dp-authorisation middleware does
+type Issuer struct {
+	*authJWT.CognitoRSAParser
+	key *rsa.PrivateKey
+}
+
+// NewIssuer returns an Issuer with a newly generated signing key
+func NewIssuer() (*Issuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, err := authJWT.NewCognitoRSAParser(map[string]string{keyID: base64.StdEncoding.EncodeToString(publicKey)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Issuer{CognitoRSAParser: parser, key: key}, nil
+}
+
+// Token returns a bearer token for the user, belonging to the given groups
+func (i *Issuer) Token(userID string, groups ...string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"username":       userID,
+		"cognito:groups": groups,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header[authJWT.Kid] = keyID
+
+	signed, err := token.SignedString(i.key)
+	if err != nil {
+		return "", err
+	}
+	return request.BearerPrefix + signed, nil
+}
+
+// NewPermissionsChecker returns the dp-authorisation permissions checker, reading permissions from the bundle
+// rather than the permissions API
+func NewPermissionsChecker(bundle permsdk.Bundle) auth.PermissionsChecker {
+	return authPermissions.NewCheckerForStore(bundleCache{bundle: bundle})
+}
+
+// DatasetPolicy returns a policy applying to the given datasets
+func DatasetPolicy(datasetIDs ...string) permsdk.Policy {
+	return permsdk.Policy{
+		Condition: permsdk.Condition{
+			Attribute: "dataset_id",
+			Operator:  permsdk.OperatorStringEquals,
+			Values:    datasetIDs,
+		},
+	}
+}
+
+type bundleCache struct {
+	bundle permsdk.Bundle
+}
+
+func (c bundleCache) GetPermissionsBundle(_ context.Context, _ permsdk.Headers) (permsdk.Bundle, error) {
+	return c.bundle, nil
+}
+
+func (c bundleCache) Close(_ context.Context) error {
+	return nil
+}
+
+func (c bundleCache) HealthCheck(_ context.Context, state *health.CheckState) error {
+	return state.Update(health.StatusOK, "permissions bundle is fixed", 0)
+}
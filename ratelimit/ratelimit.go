@@ -0,0 +1,205 @@
+// Package ratelimit limits how often each client can make requests to the routes which create filters in the filter
+// API, so that a crawler or script cannot create them in bulk. Each client has a token bucket per route, which holds a
+// burst of requests and is refilled evenly over time.
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// Names of the routes which are limited, which limits are configured by
+const (
+	RouteCreateFilter           = "create-filter"
+	RouteCreateFlexFilter       = "create-flex-filter"
+	RouteCreateFilterFromOutput = "create-filter-from-output"
+	RouteCreateCustomDataset    = "create-custom-dataset"
+	RouteImportFilterSpec       = "import-filter-spec"
+)
+
+// off is the limit configured to stop limiting a route
+const off = "off"
+
+// defaultLimit allows a burst of ten filters, and one more every six seconds, which people creating filters through
+// the pages do not reach
+const defaultLimit = "10/1m"
+
+// defaultLimits are the limits of each route unless configured otherwise
+var defaultLimits = map[string]string{
+	RouteCreateFilter:           defaultLimit,
+	RouteCreateFlexFilter:       defaultLimit,
+	RouteCreateFilterFromOutput: defaultLimit,
+	RouteCreateCustomDataset:    defaultLimit,
+	RouteImportFilterSpec:       defaultLimit,
+}
+
+// counters are the number of requests allowed and limited on each route, published with the service's other
+// expvar variables at /debug/vars
+var counters = expvar.NewMap("rate_limit")
+
+// Limit is a burst of Requests a client can make, refilled evenly over Per
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// perSecond is the rate the bucket of the limit is refilled at
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// ParseLimit parses a limit written as requests/duration, e.g. 10/1m
+func ParseLimit(s string) (Limit, error) {
+	requests, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q is not written as requests/duration", s)
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil || n < 1 {
+		return Limit{}, fmt.Errorf("rate limit %q does not allow a positive number of requests", s)
+	}
+	d, err := time.ParseDuration(per)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("rate limit %q does not have a positive duration", s)
+	}
+	return Limit{Requests: n, Per: d}, nil
+}
+
+// Identify verifies a user's session token, returning the ID of the user it was issued to. The ID is empty when the
+// token carries no identity.
+type Identify func(token string) (string, error)
+
+// Limiter limits the requests of each client to the routes with a limit
+type Limiter struct {
+	store          Store
+	limits         map[string]Limit
+	trustedProxies int
+	identify       Identify
+}
+
+// New returns a Limiter applying the default limits, overridden by route name, where "off" removes the limit of a
+// route. An unknown route or invalid limit is an error, so that mistakes in configuration are found at startup.
+// Clients are told apart by the address the first of the trusted proxies in front of the service received the request
+// from, as recorded in X-Forwarded-For. When identify is not nil, users whose session token it verifies are told apart
+// by who they are instead, so that publishers sharing an address do not share a limit.
+func New(store Store, limits map[string]string, trustedProxies int, identify Identify) (*Limiter, error) {
+	for route := range limits {
+		if _, ok := defaultLimits[route]; !ok {
+			return nil, fmt.Errorf("unknown rate limited route %q", route)
+		}
+	}
+
+	l := &Limiter{store: store, limits: make(map[string]Limit, len(defaultLimits)), trustedProxies: trustedProxies, identify: identify}
+	for route, limit := range defaultLimits {
+		if configured, ok := limits[route]; ok {
+			limit = configured
+		}
+		if limit == off {
+			continue
+		}
+
+		parsed, err := ParseLimit(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit for route %q: %w", route, err)
+		}
+		l.limits[route] = parsed
+	}
+	return l, nil
+}
+
+// Middleware limits the requests matching the named routes of the router, serving limited for the requests a client
+// makes beyond its limit. It must run ahead of the renderror middleware, which would otherwise replace the page served
+// by limited. Requests are allowed if the store fails, as limiting is not worth failing requests for.
+func (l *Limiter) Middleware(router *mux.Router, limited http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var match mux.RouteMatch
+			if !router.Match(req, &match) || match.Route == nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			route := match.Route.GetName()
+			limit, ok := l.limits[route]
+			if !ok {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			ctx := req.Context()
+			allowed, retryAfter, err := l.store.Take(ctx, route+":"+l.clientKey(req), limit)
+			if err != nil {
+				log.Error(ctx, "failed to take rate limit token", err, log.Data{"route": route})
+				allowed = true
+			}
+
+			if allowed {
+				counters.Add(route+".allowed", 1)
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			counters.Add(route+".limited", 1)
+			log.Warn(ctx, "request rate limited", log.Data{"route": route, "path": req.URL.Path, "retry_after": retryAfter.String()})
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			limited.ServeHTTP(w, req)
+		})
+	}
+}
+
+// clientKey identifies the client making a request: by the user its session token was issued to when the token can be
+// verified, otherwise by its address. A token which cannot be verified is ignored, as a client could otherwise escape
+// its limit by sending a different made up token with each request. The user ID is hashed so that it is not held by
+// the store.
+func (l *Limiter) clientKey(req *http.Request) string {
+	if l.identify != nil {
+		token := req.Header.Get(request.FlorenceHeaderKey)
+		if cookie, err := req.Cookie(request.FlorenceCookieKey); token == "" && err == nil {
+			token = cookie.Value
+		}
+		if token != "" {
+			if userID, err := l.identify(token); err == nil && userID != "" {
+				sum := sha256.Sum256([]byte(userID))
+				return "user:" + hex.EncodeToString(sum[:16])
+			}
+		}
+	}
+	return "ip:" + l.clientIP(req)
+}
+
+// clientIP returns the address of the client, skipping the addresses in X-Forwarded-For appended by the trusted
+// proxies, as any before them can be set by the client
+func (l *Limiter) clientIP(req *http.Request) string {
+	if l.trustedProxies > 0 {
+		var forwarded []string
+		for _, header := range req.Header.Values("X-Forwarded-For") {
+			for _, addr := range strings.Split(header, ",") {
+				if addr = strings.TrimSpace(addr); addr != "" {
+					forwarded = append(forwarded, addr)
+				}
+			}
+		}
+		if len(forwarded) > 0 {
+			return forwarded[max(len(forwarded)-l.trustedProxies, 0)]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,219 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func TestParseLimit(t *testing.T) {
+	Convey("Given a limit written as requests/duration", t, func() {
+		limit, err := ParseLimit("5/30s")
+
+		Convey("Then it is parsed", func() {
+			So(err, ShouldBeNil)
+			So(limit, ShouldResemble, Limit{Requests: 5, Per: 30 * time.Second})
+		})
+	})
+
+	Convey("Given invalid limits", t, func() {
+		Convey("Then they are rejected", func() {
+			for _, s := range []string{"5", "0/1m", "x/1m", "5/0s", "5/soon"} {
+				_, err := ParseLimit(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestNew(t *testing.T) {
+	Convey("Given no configured limits", t, func() {
+		l, err := New(NewMemoryStore(), nil, 0, nil)
+
+		Convey("Then every route has the default limit", func() {
+			So(err, ShouldBeNil)
+			So(l.limits, ShouldHaveLength, len(defaultLimits))
+			So(l.limits[RouteCreateFilter], ShouldResemble, Limit{Requests: 10, Per: time.Minute})
+		})
+	})
+
+	Convey("Given configured limits", t, func() {
+		l, err := New(NewMemoryStore(), map[string]string{RouteCreateFilter: "3/1h", RouteImportFilterSpec: "off"}, 0, nil)
+
+		Convey("Then they override the defaults", func() {
+			So(err, ShouldBeNil)
+			So(l.limits[RouteCreateFilter], ShouldResemble, Limit{Requests: 3, Per: time.Hour})
+			So(l.limits, ShouldNotContainKey, RouteImportFilterSpec)
+			So(l.limits[RouteCreateFlexFilter], ShouldResemble, Limit{Requests: 10, Per: time.Minute})
+		})
+	})
+
+	Convey("Given a limit for an unknown route", t, func() {
+		_, err := New(NewMemoryStore(), map[string]string{"create-everything": "1/1m"}, 0, nil)
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an invalid limit", t, func() {
+		_, err := New(NewMemoryStore(), map[string]string{RouteCreateFilter: "lots"}, 0, nil)
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMovedPermanently)
+	})
+	limited := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	newRouter := func(l *Limiter) http.Handler {
+		router := mux.NewRouter()
+		router.Path("/datasets/{datasetID}/editions/{editionID}/versions/{versionID}/filter").Methods("POST").Handler(ok).Name(RouteCreateFilter)
+		router.Path("/datasets/{datasetID}").Methods("GET").Handler(ok)
+		return l.Middleware(router, limited)(router)
+	}
+
+	send := func(h http.Handler, method, path string, setup func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, http.NoBody)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if setup != nil {
+			setup(req)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	filterPath := "/datasets/cpih01/editions/time-series/versions/1/filter"
+
+	Convey("Given a route limited to two requests a minute", t, func() {
+		l, err := New(NewMemoryStore(), map[string]string{RouteCreateFilter: "2/1m"}, 1, nil)
+		So(err, ShouldBeNil)
+		handler := newRouter(l)
+		limitedBefore := counterValue(RouteCreateFilter + ".limited")
+
+		from := func(addr string) func(req *http.Request) {
+			return func(req *http.Request) {
+				req.Header.Set("X-Forwarded-For", addr)
+			}
+		}
+
+		Convey("When a client exceeds the limit", func() {
+			So(send(handler, http.MethodPost, filterPath, from("192.0.2.1")).Code, ShouldEqual, http.StatusMovedPermanently)
+			So(send(handler, http.MethodPost, filterPath, from("192.0.2.1")).Code, ShouldEqual, http.StatusMovedPermanently)
+			w := send(handler, http.MethodPost, filterPath, from("192.0.2.1"))
+
+			Convey("Then the limited handler is served with when to try again", func() {
+				So(w.Code, ShouldEqual, http.StatusTooManyRequests)
+				So(w.Header().Get("Retry-After"), ShouldEqual, "30")
+			})
+
+			Convey("Then the limited request is counted", func() {
+				So(counterValue(RouteCreateFilter+".limited"), ShouldEqual, limitedBefore+1)
+			})
+
+			Convey("Then other clients are not limited", func() {
+				So(send(handler, http.MethodPost, filterPath, from("192.0.2.2")).Code, ShouldEqual, http.StatusMovedPermanently)
+			})
+
+			Convey("Then addresses set by the client before the trusted proxy's are ignored", func() {
+				So(send(handler, http.MethodPost, filterPath, from("198.51.100.7, 192.0.2.1")).Code, ShouldEqual, http.StatusTooManyRequests)
+			})
+
+			Convey("Then routes without a limit are not limited", func() {
+				So(send(handler, http.MethodGet, "/datasets/cpih01", from("192.0.2.1")).Code, ShouldEqual, http.StatusMovedPermanently)
+			})
+		})
+
+		Convey("When a client rotates made up session tokens", func() {
+			withToken := func(token string) func(req *http.Request) {
+				return func(req *http.Request) {
+					req.Header.Set("X-Forwarded-For", "192.0.2.3")
+					req.AddCookie(&http.Cookie{Name: request.FlorenceCookieKey, Value: token})
+				}
+			}
+			send(handler, http.MethodPost, filterPath, withToken("token-1"))
+			send(handler, http.MethodPost, filterPath, withToken("token-2"))
+
+			Convey("Then they are still limited by their address", func() {
+				So(send(handler, http.MethodPost, filterPath, withToken("token-3")).Code, ShouldEqual, http.StatusTooManyRequests)
+			})
+		})
+	})
+
+	Convey("Given a route limited to two requests a minute for verified publishers", t, func() {
+		identify := func(token string) (string, error) {
+			if userID, ok := map[string]string{"session-1": "publisher-1", "session-2": "publisher-1", "session-3": "publisher-2"}[token]; ok {
+				return userID, nil
+			}
+			return "", errors.New("invalid token")
+		}
+		l, err := New(NewMemoryStore(), map[string]string{RouteCreateFilter: "2/1m"}, 1, identify)
+		So(err, ShouldBeNil)
+		handler := newRouter(l)
+
+		withSession := func(token string) func(req *http.Request) {
+			return func(req *http.Request) {
+				req.Header.Set("X-Forwarded-For", "192.0.2.4")
+				req.AddCookie(&http.Cookie{Name: request.FlorenceCookieKey, Value: token})
+			}
+		}
+
+		Convey("When a publisher exceeds the limit with different sessions", func() {
+			send(handler, http.MethodPost, filterPath, withSession("session-1"))
+			send(handler, http.MethodPost, filterPath, withSession("session-2"))
+
+			Convey("Then they are limited by who they are rather than their address", func() {
+				So(send(handler, http.MethodPost, filterPath, withSession("session-1")).Code, ShouldEqual, http.StatusTooManyRequests)
+				So(send(handler, http.MethodPost, filterPath, withSession("session-3")).Code, ShouldEqual, http.StatusMovedPermanently)
+			})
+		})
+
+		Convey("When a client rotates tokens which cannot be verified", func() {
+			send(handler, http.MethodPost, filterPath, withSession("forged-1"))
+			send(handler, http.MethodPost, filterPath, withSession("forged-2"))
+
+			Convey("Then they are limited by their address", func() {
+				So(send(handler, http.MethodPost, filterPath, withSession("forged-3")).Code, ShouldEqual, http.StatusTooManyRequests)
+			})
+		})
+	})
+
+	Convey("Given the store fails", t, func() {
+		l, err := New(failingStore{}, nil, 0, nil)
+		So(err, ShouldBeNil)
+		handler := newRouter(l)
+
+		Convey("Then requests are allowed", func() {
+			So(send(handler, http.MethodPost, filterPath, nil).Code, ShouldEqual, http.StatusMovedPermanently)
+		})
+	})
+}
+
+// counterValue returns the current value of a rate limit counter
+func counterValue(name string) int64 {
+	if v, ok := counters.Get(name).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
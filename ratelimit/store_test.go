@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 2, Per: time.Minute}
+
+	Convey("Given an empty memory store", t, func() {
+		now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		store := NewMemoryStore()
+		store.now = func() time.Time { return now }
+
+		Convey("When a client takes its burst of tokens", func() {
+			first, _, err := store.Take(ctx, "create-filter:ip:192.0.2.1", limit)
+			So(err, ShouldBeNil)
+			second, _, err := store.Take(ctx, "create-filter:ip:192.0.2.1", limit)
+			So(err, ShouldBeNil)
+
+			Convey("Then both are allowed", func() {
+				So(first, ShouldBeTrue)
+				So(second, ShouldBeTrue)
+			})
+
+			Convey("Then the next is refused until a token is refilled", func() {
+				ok, retryAfter, err := store.Take(ctx, "create-filter:ip:192.0.2.1", limit)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+				So(retryAfter, ShouldEqual, 30*time.Second)
+
+				now = now.Add(30 * time.Second)
+				ok, _, err = store.Take(ctx, "create-filter:ip:192.0.2.1", limit)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then another client has its own bucket", func() {
+				ok, _, err := store.Take(ctx, "create-filter:ip:192.0.2.2", limit)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then the bucket is removed once it has refilled", func() {
+				now = now.Add(2 * time.Minute)
+				_, _, err := store.Take(ctx, "create-filter:ip:192.0.2.2", limit)
+				So(err, ShouldBeNil)
+				So(store.buckets, ShouldHaveLength, 1)
+				So(store.buckets, ShouldContainKey, "create-filter:ip:192.0.2.2")
+			})
+		})
+	})
+}
@@ -0,0 +1,113 @@
+// Package security sets the security headers of every response, including a Content-Security-Policy which only allows
+// scripts carrying the nonce generated for the request, and collects the reports of violations of that policy.
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ReportPath is the path browsers send Content-Security-Policy violation reports to
+const ReportPath = "/csp-report"
+
+const nonceBytes = 16
+
+// maxReportBytes limits the reports read, which are only ever small
+const maxReportBytes = 16 * 1024
+
+// permissionsPolicy disables the browser features no page of the service uses
+const permissionsPolicy = "camera=(), geolocation=(), microphone=(), payment=(), usb=()"
+
+type contextKey struct{}
+
+// Nonce returns the nonce generated for the request, which page models pass to the service's own inline scripts
+func Nonce(ctx context.Context) string {
+	nonce, _ := ctx.Value(contextKey{}).(string)
+	return nonce
+}
+
+// Policy returns the Content-Security-Policy allowing scripts with the nonce, and the scripts they load. The
+// 'unsafe-inline' and https: sources are ignored by browsers supporting nonces and only apply to older browsers.
+func Policy(nonce string) string {
+	return "script-src 'nonce-" + nonce + "' 'strict-dynamic' https: 'unsafe-inline'; " +
+		"object-src 'none'; base-uri 'self'; report-uri " + ReportPath
+}
+
+// Middleware generates a nonce for each request, adds it to the request's context and sets the security headers of its
+// response. In report-only mode the Content-Security-Policy is not enforced and violations are only reported. Reports sent to ReportPath are
+// collected here, ahead of the rest of the chain, as browsers send them without a CSRF token.
+func Middleware(reportOnly bool, hstsMaxAge time.Duration) func(http.Handler) http.Handler {
+	cspHeader := "Content-Security-Policy"
+	if reportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+	hsts := "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds())) + "; includeSubDomains"
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == ReportPath && req.Method == http.MethodPost {
+				collectReport(w, req)
+				return
+			}
+
+			b := make([]byte, nonceBytes)
+			if _, err := rand.Read(b); err != nil {
+				log.Error(req.Context(), "failed to generate csp nonce", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			nonce := base64.StdEncoding.EncodeToString(b)
+
+			header := w.Header()
+			header.Set(cspHeader, Policy(nonce))
+			header.Set("Strict-Transport-Security", hsts)
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+			header.Set("Permissions-Policy", permissionsPolicy)
+
+			h.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKey{}, nonce)))
+		})
+	}
+}
+
+// report is the violation report browsers send to the report-uri of a policy
+type report struct {
+	CSPReport struct {
+		DocumentURI        string `json:"document-uri"`
+		EffectiveDirective string `json:"effective-directive"`
+		ViolatedDirective  string `json:"violated-directive"`
+		BlockedURI         string `json:"blocked-uri"`
+		SourceFile         string `json:"source-file"`
+		LineNumber         int    `json:"line-number"`
+		Disposition        string `json:"disposition"`
+	} `json:"csp-report"`
+}
+
+// collectReport logs a violation report so that scripts the policy blocks, or would block in report-only mode, can be
+// found before the policy is enforced
+func collectReport(w http.ResponseWriter, req *http.Request) {
+	var r report
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxReportBytes)).Decode(&r); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.Warn(req.Context(), "content security policy violation reported", log.Data{
+		"document_uri":        r.CSPReport.DocumentURI,
+		"effective_directive": r.CSPReport.EffectiveDirective,
+		"violated_directive":  r.CSPReport.ViolatedDirective,
+		"blocked_uri":         r.CSPReport.BlockedURI,
+		"source_file":         r.CSPReport.SourceFile,
+		"line_number":         r.CSPReport.LineNumber,
+		"disposition":         r.CSPReport.Disposition,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	var nonces []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		nonces = append(nonces, Nonce(req.Context()))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	Convey("Given the middleware enforcing the policy", t, func() {
+		nonces = nil
+		handler := Middleware(false, 24*time.Hour)(next)
+
+		Convey("When two pages are requested", func() {
+			first := httptest.NewRecorder()
+			handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/datasets/cpih01", http.NoBody))
+			second := httptest.NewRecorder()
+			handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/datasets/cpih01", http.NoBody))
+
+			Convey("Then each request is given a different nonce", func() {
+				So(nonces, ShouldHaveLength, 2)
+				So(nonces[0], ShouldNotBeEmpty)
+				So(nonces[0], ShouldNotEqual, nonces[1])
+			})
+
+			Convey("Then the policy only allows scripts with the request's nonce", func() {
+				So(first.Header().Get("Content-Security-Policy"), ShouldEqual, Policy(nonces[0]))
+				So(first.Header().Get("Content-Security-Policy"), ShouldContainSubstring, "'nonce-"+nonces[0]+"'")
+				So(first.Header().Get("Content-Security-Policy"), ShouldEndWith, "report-uri "+ReportPath)
+				So(first.Header().Get("Content-Security-Policy-Report-Only"), ShouldBeEmpty)
+			})
+
+			Convey("Then the other security headers are set", func() {
+				So(first.Header().Get("Strict-Transport-Security"), ShouldEqual, "max-age=86400; includeSubDomains")
+				So(first.Header().Get("X-Content-Type-Options"), ShouldEqual, "nosniff")
+				So(first.Header().Get("Referrer-Policy"), ShouldEqual, "strict-origin-when-cross-origin")
+				So(first.Header().Get("Permissions-Policy"), ShouldEqual, permissionsPolicy)
+			})
+		})
+	})
+
+	Convey("Given the middleware in report-only mode", t, func() {
+		nonces = nil
+		handler := Middleware(true, 24*time.Hour)(next)
+
+		Convey("When a page is requested", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/datasets/cpih01", http.NoBody))
+
+			Convey("Then the policy is only reported", func() {
+				So(w.Header().Get("Content-Security-Policy"), ShouldBeEmpty)
+				So(w.Header().Get("Content-Security-Policy-Report-Only"), ShouldEqual, Policy(nonces[0]))
+			})
+		})
+	})
+
+	Convey("Given the middleware", t, func() {
+		nonces = nil
+		handler := Middleware(false, 24*time.Hour)(next)
+
+		Convey("When a violation is reported", func() {
+			body := `{"csp-report":{"document-uri":"https://www.ons.gov.uk/datasets/cpih01","violated-directive":"script-src","blocked-uri":"inline"}}`
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, ReportPath, strings.NewReader(body)))
+
+			Convey("Then the report is collected without reaching the rest of the chain", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(nonces, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an invalid report is sent", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, ReportPath, strings.NewReader("not a report")))
+
+			Convey("Then it is rejected", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
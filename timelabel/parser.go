@@ -0,0 +1,159 @@
+package timelabel
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser parses time labels of a particular format
+type Parser interface {
+	// Parse returns the period described by label, and false if the label is not in the parser's format
+	Parse(label string) (Period, bool)
+}
+
+// ParserFunc allows an ordinary function to be used as a Parser
+type ParserFunc func(label string) (Period, bool)
+
+// Parse calls f(label)
+func (f ParserFunc) Parse(label string) (Period, bool) {
+	return f(label)
+}
+
+// DefaultParsers returns the built in parsers in the order they are tried. Financial years are tried before
+// ISO months as labels such as "2011-12" are more likely to be financial years in ONS datasets.
+func DefaultParsers() []Parser {
+	return []Parser{
+		ParserFunc(ParseYear),
+		ParserFunc(ParseFinancialYear),
+		ParserFunc(ParseQuarter),
+		ParserFunc(ParseMonth),
+		ParserFunc(ParseMonthRange),
+		ParserFunc(ParseWeek),
+		ParserFunc(ParseDate),
+	}
+}
+
+var (
+	yearPattern          = regexp.MustCompile(`^(\d{4})$`)
+	financialYearPattern = regexp.MustCompile(`^(\d{4})[-/](\d{2}|\d{4})$`)
+	quarterPattern       = regexp.MustCompile(`^(?:(\d{4})[ -]?Q([1-4])|Q([1-4])[ -]?(\d{4}))$`)
+	monthRangePattern    = regexp.MustCompile(`^([A-Za-z]{3})-([A-Za-z]{3}) (\d{4})$`)
+	weekPattern          = regexp.MustCompile(`^(\d{4})[ -]?W(\d{2})$`)
+)
+
+// monthLayouts are the layouts of the month labels recognised by ParseMonth
+var monthLayouts = []string{"Jan-06", "Jan 2006", "January 2006", "2006-01"}
+
+// ParseYear parses calendar years, e.g. "2020"
+func ParseYear(label string) (Period, bool) {
+	match := yearPattern.FindStringSubmatch(strings.TrimSpace(label))
+	if match == nil {
+		return Period{}, false
+	}
+	year, _ := strconv.Atoi(match[1])
+	return Period{Label: label, Unit: Year, Start: year, End: year}, true
+}
+
+// ParseFinancialYear parses financial years, e.g. "2019-20", "2019/20" or "2019-2020"
+func ParseFinancialYear(label string) (Period, bool) {
+	match := financialYearPattern.FindStringSubmatch(strings.TrimSpace(label))
+	if match == nil {
+		return Period{}, false
+	}
+	start, _ := strconv.Atoi(match[1])
+	end, _ := strconv.Atoi(match[2])
+
+	if len(match[2]) == 2 && end != (start+1)%100 || len(match[2]) == 4 && end != start+1 {
+		return Period{}, false
+	}
+	return Period{Label: label, Unit: FinancialYear, Start: start, End: start}, true
+}
+
+// ParseQuarter parses calendar quarters, e.g. "2020 Q1", "2020-Q1" or "Q1 2020"
+func ParseQuarter(label string) (Period, bool) {
+	match := quarterPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(label)))
+	if match == nil {
+		return Period{}, false
+	}
+	year, quarter := match[1], match[2]
+	if year == "" {
+		year, quarter = match[4], match[3]
+	}
+	y, _ := strconv.Atoi(year)
+	q, _ := strconv.Atoi(quarter)
+
+	ordinal := quarterOrdinal(y, q)
+	return Period{Label: label, Unit: Quarter, Start: ordinal, End: ordinal}, true
+}
+
+// ParseMonth parses months, e.g. "Jan-20", "Jan 2020", "January 2020" or "2020-01"
+func ParseMonth(label string) (Period, bool) {
+	for _, layout := range monthLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(label)); err == nil {
+			ordinal := monthOrdinal(t.Year(), t.Month())
+			return Period{Label: label, Unit: Month, Start: ordinal, End: ordinal}, true
+		}
+	}
+	return Period{}, false
+}
+
+// ParseMonthRange parses spans of months, e.g. "Jan-Mar 2020". The year is that of the last month, so
+// "Dec-Feb 2020" starts in December 2019.
+func ParseMonthRange(label string) (Period, bool) {
+	match := monthRangePattern.FindStringSubmatch(strings.TrimSpace(label))
+	if match == nil {
+		return Period{}, false
+	}
+	startMonth, err := time.Parse("Jan", match[1])
+	if err != nil {
+		return Period{}, false
+	}
+	endMonth, err := time.Parse("Jan", match[2])
+	if err != nil {
+		return Period{}, false
+	}
+	year, _ := strconv.Atoi(match[3])
+
+	startYear := year
+	if startMonth.Month() > endMonth.Month() {
+		startYear--
+	}
+	return Period{
+		Label: label,
+		Unit:  Month,
+		Start: monthOrdinal(startYear, startMonth.Month()),
+		End:   monthOrdinal(year, endMonth.Month()),
+	}, true
+}
+
+// ParseWeek parses ISO 8601 weeks, e.g. "2021 W05", "2021-W05" or "2021W05"
+func ParseWeek(label string) (Period, bool) {
+	match := weekPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(label)))
+	if match == nil {
+		return Period{}, false
+	}
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+
+	// the first ISO week of a year is the one containing the 4th of January
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+7*(week-1))
+	if isoYear, isoWeek := monday.ISOWeek(); isoYear != year || isoWeek != week {
+		return Period{}, false
+	}
+
+	ordinal := weekOrdinal(monday)
+	return Period{Label: label, Unit: Week, Start: ordinal, End: ordinal}, true
+}
+
+// ParseDate parses ISO 8601 dates, e.g. "2020-01-31"
+func ParseDate(label string) (Period, bool) {
+	t, err := time.Parse(time.DateOnly, strings.TrimSpace(label))
+	if err != nil {
+		return Period{}, false
+	}
+	ordinal := dayOrdinal(t)
+	return Period{Label: label, Unit: Day, Start: ordinal, End: ordinal}, true
+}
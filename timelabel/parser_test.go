@@ -0,0 +1,120 @@
+package timelabel
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParsers(t *testing.T) {
+	Convey("Given the default parsers", t, func() {
+		Convey("Then calendar years are parsed", func() {
+			p, ok := ParseYear("2020")
+			So(ok, ShouldBeTrue)
+			So(p, ShouldResemble, Period{Label: "2020", Unit: Year, Start: 2020, End: 2020})
+
+			_, ok = ParseYear("20")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then financial years are parsed", func() {
+			for _, label := range []string{"2019-20", "2019/20", "2019-2020", "1999-00"} {
+				p, ok := ParseFinancialYear(label)
+				So(ok, ShouldBeTrue)
+				So(p.Unit, ShouldEqual, FinancialYear)
+				So(FinancialYear.Time(p.Start).Month(), ShouldEqual, time.April)
+			}
+
+			p, _ := ParseFinancialYear("2019-20")
+			So(p.Start, ShouldEqual, 2019)
+
+			_, ok := ParseFinancialYear("2019-21")
+			So(ok, ShouldBeFalse)
+			_, ok = ParseFinancialYear("2020-01")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then quarters are parsed", func() {
+			for _, label := range []string{"2020 Q2", "2020-Q2", "2020Q2", "Q2 2020", "2020 q2"} {
+				p, ok := ParseQuarter(label)
+				So(ok, ShouldBeTrue)
+				So(p.Unit, ShouldEqual, Quarter)
+				So(Quarter.Time(p.Start), ShouldEqual, time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC))
+			}
+
+			_, ok := ParseQuarter("2020 Q5")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then months are parsed", func() {
+			for _, label := range []string{"Mar-20", "Mar 2020", "March 2020", "2020-03"} {
+				p, ok := ParseMonth(label)
+				So(ok, ShouldBeTrue)
+				So(p.Unit, ShouldEqual, Month)
+				So(Month.Time(p.Start), ShouldEqual, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+			}
+
+			_, ok := ParseMonth("2020-13")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then spans of months are parsed", func() {
+			p, ok := ParseMonthRange("Jan-Mar 2020")
+			So(ok, ShouldBeTrue)
+			So(p.Unit, ShouldEqual, Month)
+			So(Month.Time(p.Start), ShouldEqual, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+			So(Month.Time(p.End), ShouldEqual, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+			Convey("And spans crossing a year start in the previous year", func() {
+				p, ok := ParseMonthRange("Dec-Feb 2020")
+				So(ok, ShouldBeTrue)
+				So(Month.Time(p.Start), ShouldEqual, time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC))
+				So(Month.Time(p.End), ShouldEqual, time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC))
+			})
+
+			_, ok = ParseMonthRange("Jan-Foo 2020")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then ISO weeks are parsed", func() {
+			for _, label := range []string{"2021 W05", "2021-W05", "2021W05"} {
+				p, ok := ParseWeek(label)
+				So(ok, ShouldBeTrue)
+				So(p.Unit, ShouldEqual, Week)
+				So(Week.Time(p.Start), ShouldEqual, time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC))
+			}
+
+			Convey("And the first week of a year can start in the previous year", func() {
+				p, ok := ParseWeek("2021 W01")
+				So(ok, ShouldBeTrue)
+				So(Week.Time(p.Start), ShouldEqual, time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC))
+
+				p, ok = ParseWeek("2020 W01")
+				So(ok, ShouldBeTrue)
+				So(Week.Time(p.Start), ShouldEqual, time.Date(2019, time.December, 30, 0, 0, 0, 0, time.UTC))
+			})
+
+			Convey("And weeks before 1970 are parsed", func() {
+				p, ok := ParseWeek("1969 W52")
+				So(ok, ShouldBeTrue)
+				So(Week.Time(p.Start), ShouldEqual, time.Date(1969, time.December, 22, 0, 0, 0, 0, time.UTC))
+			})
+
+			_, ok := ParseWeek("2021 W53")
+			So(ok, ShouldBeFalse)
+			_, ok = ParseWeek("2020 W53")
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then ISO dates are parsed", func() {
+			p, ok := ParseDate("2020-02-29")
+			So(ok, ShouldBeTrue)
+			So(p.Unit, ShouldEqual, Day)
+			So(Day.Time(p.Start), ShouldEqual, time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC))
+
+			_, ok = ParseDate("2021-02-29")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package timelabel
+
+import "time"
+
+// Unit is the granularity of the periods described by a set of time labels
+type Unit int
+
+// Units of time recognised by the default parsers
+const (
+	Day Unit = iota + 1
+	Week
+	Month
+	Quarter
+	Year
+	FinancialYear
+)
+
+var (
+	epoch       = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	epochMonday = time.Date(1970, time.January, 5, 0, 0, 0, 0, time.UTC)
+)
+
+// financialYearStart is the month UK financial years start in
+const financialYearStart = time.April
+
+// String returns the name of the unit
+func (u Unit) String() string {
+	switch u {
+	case Day:
+		return "day"
+	case Week:
+		return "week"
+	case Month:
+		return "month"
+	case Quarter:
+		return "quarter"
+	case Year:
+		return "year"
+	case FinancialYear:
+		return "financial year"
+	default:
+		return "unknown"
+	}
+}
+
+// Time returns the start of the period with the given ordinal
+func (u Unit) Time(ordinal int) time.Time {
+	switch u {
+	case Day:
+		return epoch.AddDate(0, 0, ordinal)
+	case Week:
+		return epochMonday.AddDate(0, 0, 7*ordinal)
+	case Month:
+		return time.Date(ordinal/12, time.Month(ordinal%12+1), 1, 0, 0, 0, 0, time.UTC)
+	case Quarter:
+		return time.Date(ordinal/4, time.Month((ordinal%4)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	case Year:
+		return time.Date(ordinal, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case FinancialYear:
+		return time.Date(ordinal, financialYearStart, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}
+	}
+}
+
+// Period is the span of time described by a single label. Start and End are ordinals of the unit, so
+// consecutive periods have consecutive ordinals, e.g. a month label covers a single month whereas
+// "Jan-Mar 2020" covers three.
+type Period struct {
+	Label string
+	Unit  Unit
+	Start int
+	End   int
+}
+
+// Range is a span of consecutive ordinals of a unit, from Start to End inclusive
+type Range struct {
+	Start int
+	End   int
+}
+
+// Len returns the number of units in the range
+func (r Range) Len() int {
+	return r.End - r.Start + 1
+}
+
+func dayOrdinal(t time.Time) int {
+	return int(t.Sub(epoch).Hours() / 24)
+}
+
+func weekOrdinal(monday time.Time) int {
+	return int(monday.Sub(epochMonday).Hours()/24) / 7
+}
+
+func monthOrdinal(year int, month time.Month) int {
+	return year*12 + int(month) - 1
+}
+
+func quarterOrdinal(year, quarter int) int {
+	return year*4 + quarter - 1
+}
@@ -0,0 +1,126 @@
+package timelabel
+
+import "sort"
+
+// Summary describes the time covered by a set of labels as runs of consecutive periods, along with the
+// gaps between them. Labels which could not be summarised with the others are kept in Unsummarised.
+type Summary struct {
+	Unit         Unit
+	Runs         []Range
+	Gaps         []Range
+	Unsummarised []string
+}
+
+// Summariser summarises time labels using the first of its parsers that recognises all of them
+type Summariser struct {
+	parsers []Parser
+}
+
+// NewSummariser creates a Summariser that tries each of the parsers in turn, using DefaultParsers if
+// none are given
+func NewSummariser(parsers ...Parser) *Summariser {
+	if len(parsers) == 0 {
+		parsers = DefaultParsers()
+	}
+	return &Summariser{parsers: parsers}
+}
+
+// Summarise groups the periods described by labels into runs of consecutive or overlapping periods and
+// reports the gaps between the runs. When none of the parsers recognise every label, each label is parsed
+// on its own and the periods of the most common unit are summarised, leaving the other labels unsummarised.
+// It returns false if none of the labels are recognised.
+func (s *Summariser) Summarise(labels []string) (Summary, bool) {
+	if len(labels) == 0 {
+		return Summary{}, false
+	}
+
+	for _, parser := range s.parsers {
+		if periods, ok := parseAll(parser, labels); ok {
+			return summarise(periods), true
+		}
+	}
+	return s.summariseEach(labels)
+}
+
+// summariseEach parses each label with the first parser that recognises it, summarising the periods of the
+// unit recognised most often, or first on a tie
+func (s *Summariser) summariseEach(labels []string) (Summary, bool) {
+	periods := make([]Period, len(labels))
+	parsed := make([]bool, len(labels))
+	counts := make(map[Unit]int)
+	var unit Unit
+	for i, label := range labels {
+		if periods[i], parsed[i] = s.parse(label); !parsed[i] {
+			continue
+		}
+		counts[periods[i].Unit]++
+		if unit == 0 || counts[periods[i].Unit] > counts[unit] {
+			unit = periods[i].Unit
+		}
+	}
+	if unit == 0 {
+		return Summary{}, false
+	}
+
+	var summarised []Period
+	var unsummarised []string
+	for i, label := range labels {
+		if parsed[i] && periods[i].Unit == unit {
+			summarised = append(summarised, periods[i])
+		} else {
+			unsummarised = append(unsummarised, label)
+		}
+	}
+
+	summary := summarise(summarised)
+	summary.Unsummarised = unsummarised
+	return summary, true
+}
+
+// parse returns the period described by label using the first parser that recognises it
+func (s *Summariser) parse(label string) (Period, bool) {
+	for _, parser := range s.parsers {
+		if period, ok := parser.Parse(label); ok {
+			return period, true
+		}
+	}
+	return Period{}, false
+}
+
+// parseAll returns the periods for all of the labels, or false if any of them could not be parsed or the
+// parser returned periods of more than one unit
+func parseAll(parser Parser, labels []string) ([]Period, bool) {
+	periods := make([]Period, 0, len(labels))
+	for _, label := range labels {
+		period, ok := parser.Parse(label)
+		if !ok || len(periods) > 0 && period.Unit != periods[0].Unit {
+			return nil, false
+		}
+		periods = append(periods, period)
+	}
+	return periods, true
+}
+
+func summarise(periods []Period) Summary {
+	sort.Slice(periods, func(i, j int) bool {
+		if periods[i].Start == periods[j].Start {
+			return periods[i].End < periods[j].End
+		}
+		return periods[i].Start < periods[j].Start
+	})
+
+	summary := Summary{Unit: periods[0].Unit}
+	run := Range{Start: periods[0].Start, End: periods[0].End}
+	for _, period := range periods[1:] {
+		if period.Start <= run.End+1 {
+			run.End = max(run.End, period.End)
+			continue
+		}
+		summary.Runs = append(summary.Runs, run)
+		summary.Gaps = append(summary.Gaps, Range{Start: run.End + 1, End: period.Start - 1})
+		run = Range{Start: period.Start, End: period.End}
+	}
+	summary.Runs = append(summary.Runs, run)
+
+	return summary
+}
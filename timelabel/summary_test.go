@@ -0,0 +1,137 @@
+package timelabel
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSummarise(t *testing.T) {
+	summariser := NewSummariser()
+
+	Convey("Given labels for consecutive years", t, func() {
+		summary, ok := summariser.Summarise([]string{"2018", "2016", "2017"})
+
+		Convey("Then a single run is returned without gaps", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Year)
+			So(summary.Runs, ShouldResemble, []Range{{Start: 2016, End: 2018}})
+			So(summary.Gaps, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given labels for quarters with a gap", t, func() {
+		summary, ok := summariser.Summarise([]string{"2020 Q1", "2020 Q2", "2020 Q4", "2021 Q1", "2021 Q1"})
+
+		Convey("Then the runs are grouped and the gap is reported", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Quarter)
+			So(summary.Runs, ShouldResemble, []Range{
+				{Start: quarterOrdinal(2020, 1), End: quarterOrdinal(2020, 2)},
+				{Start: quarterOrdinal(2020, 4), End: quarterOrdinal(2021, 1)},
+			})
+			So(summary.Gaps, ShouldResemble, []Range{{Start: quarterOrdinal(2020, 3), End: quarterOrdinal(2020, 3)}})
+		})
+	})
+
+	Convey("Given labels for months in different years", t, func() {
+		summary, ok := summariser.Summarise([]string{"Jan-05", "Feb-07"})
+
+		Convey("Then the months are not treated as consecutive", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Runs, ShouldHaveLength, 2)
+			So(summary.Gaps, ShouldResemble, []Range{{Start: monthOrdinal(2005, 2), End: monthOrdinal(2007, 1)}})
+			So(summary.Gaps[0].Len(), ShouldEqual, 24)
+		})
+	})
+
+	Convey("Given labels for rolling spans of months", t, func() {
+		summary, ok := summariser.Summarise([]string{"Jan-Mar 2020", "Feb-Apr 2020", "Mar-May 2020", "Sep-Nov 2020"})
+
+		Convey("Then overlapping spans are merged into the months they cover", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Month)
+			So(summary.Runs, ShouldResemble, []Range{
+				{Start: monthOrdinal(2020, 1), End: monthOrdinal(2020, 5)},
+				{Start: monthOrdinal(2020, 9), End: monthOrdinal(2020, 11)},
+			})
+			So(summary.Gaps, ShouldResemble, []Range{{Start: monthOrdinal(2020, 6), End: monthOrdinal(2020, 8)}})
+		})
+	})
+
+	Convey("Given labels for financial years", t, func() {
+		summary, ok := summariser.Summarise([]string{"2017-18", "2018-19", "2019-20"})
+
+		Convey("Then they are recognised as financial years rather than months", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, FinancialYear)
+			So(summary.Runs, ShouldResemble, []Range{{Start: 2017, End: 2019}})
+		})
+	})
+
+	Convey("Given labels for consecutive ISO weeks across a year", t, func() {
+		summary, ok := summariser.Summarise([]string{"2020 W52", "2020 W53", "2021 W01"})
+
+		Convey("Then a single run is returned", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Week)
+			So(summary.Runs, ShouldHaveLength, 1)
+			So(summary.Runs[0].Len(), ShouldEqual, 3)
+		})
+	})
+
+	Convey("Given labels in mixed formats", t, func() {
+		summary, ok := summariser.Summarise([]string{"2019", "2020 Q1", "2020", "Total", "2021"})
+
+		Convey("Then the labels of the most common unit are summarised and the others are kept", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Year)
+			So(summary.Runs, ShouldResemble, []Range{{Start: 2019, End: 2021}})
+			So(summary.Unsummarised, ShouldResemble, []string{"2020 Q1", "Total"})
+		})
+	})
+
+	Convey("Given labels which all parse as more than one format", t, func() {
+		summary, ok := summariser.Summarise([]string{"2011-12", "2012-01"})
+
+		Convey("Then a format recognising every label is preferred", func() {
+			So(ok, ShouldBeTrue)
+			So(summary.Unit, ShouldEqual, Month)
+			So(summary.Unsummarised, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given labels that are not times", t, func() {
+		_, ok := summariser.Summarise([]string{"3", "6", "19"})
+
+		Convey("Then they are not summarised", func() {
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given no labels", t, func() {
+		_, ok := summariser.Summarise(nil)
+
+		Convey("Then they are not summarised", func() {
+			So(ok, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a summariser with a custom parser", t, func() {
+		custom := NewSummariser(ParserFunc(func(label string) (Period, bool) {
+			if label == "Census 2021" {
+				return Period{Label: label, Unit: Year, Start: 2021, End: 2021}, true
+			}
+			return Period{}, false
+		}))
+
+		Convey("Then only the custom parser is used", func() {
+			summary, ok := custom.Summarise([]string{"Census 2021"})
+			So(ok, ShouldBeTrue)
+			So(summary.Runs, ShouldResemble, []Range{{Start: 2021, End: 2021}})
+
+			_, ok = custom.Summarise([]string{"2021"})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,130 @@
+// Package workflow defines the transitions publishers can make between the states of a static dataset version, and
+// enforces which of them are allowed from each state and by whom.
+package workflow
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+)
+
+// Transition identifies a change a publisher can make to the state of a version
+type Transition string
+
+// Transitions offered to publishers
+const (
+	Approve       Transition = "approve"
+	Reject        Transition = "reject"
+	ReturnToDraft Transition = "return-to-draft"
+)
+
+// ReasonField is the name of the form field a publisher gives the reason for a transition in
+const ReasonField = "reason"
+
+// Errors returned when a transition cannot be made
+var (
+	ErrUnknownTransition    = errors.New("unknown version state transition")
+	ErrTransitionNotAllowed = errors.New("version state transition not allowed from the current state")
+	ErrPermissionDenied     = errors.New("user does not have permission to make the version state transition")
+	ErrReasonRequired       = errors.New("a reason is required for the version state transition")
+)
+
+// Permissions reports whether the user has a permission on the dataset a version belongs to
+type Permissions interface {
+	Has(ctx context.Context, permission string) (bool, error)
+}
+
+type rule struct {
+	from           []string
+	to             string
+	permission     string
+	requiresReason bool
+}
+
+// rules are the allowed transitions. A rejected or returned version goes back to draft, which for static datasets is
+// the edition-confirmed state.
+var rules = map[Transition]rule{
+	Approve: {
+		from:       []string{models.AssociatedState},
+		to:         models.ApprovedState,
+		permission: permissions.DatasetsApprove,
+	},
+	Reject: {
+		from:           []string{models.AssociatedState},
+		to:             models.EditionConfirmedState,
+		permission:     permissions.DatasetsApprove,
+		requiresReason: true,
+	},
+	ReturnToDraft: {
+		from:       []string{models.AssociatedState, models.ApprovedState},
+		to:         models.EditionConfirmedState,
+		permission: permissions.DatasetsEdit,
+	},
+}
+
+// order is the order transitions are offered in
+var order = []Transition{Approve, Reject, ReturnToDraft}
+
+// Parse returns the transition with the given name
+func Parse(name string) (Transition, error) {
+	t := Transition(name)
+	if _, ok := rules[t]; !ok {
+		return "", ErrUnknownTransition
+	}
+	return t, nil
+}
+
+// RequiresReason reports whether the publisher has to give a reason for the transition
+func (t Transition) RequiresReason() bool {
+	return rules[t].requiresReason
+}
+
+// Available returns the transitions the user can make from the given state, in the order they are offered
+func Available(ctx context.Context, state string, perms Permissions) ([]Transition, error) {
+	var available []Transition
+	for _, t := range order {
+		err := Check(ctx, state, t, perms)
+		switch {
+		case err == nil:
+			available = append(available, t)
+		case errors.Is(err, ErrPermissionDenied), errors.Is(err, ErrTransitionNotAllowed):
+		default:
+			return nil, err
+		}
+	}
+	return available, nil
+}
+
+// Check returns an error if the user cannot make the transition from the given state
+func Check(ctx context.Context, state string, t Transition, perms Permissions) error {
+	r, ok := rules[t]
+	if !ok {
+		return ErrUnknownTransition
+	}
+	if !slices.Contains(r.from, state) {
+		return ErrTransitionNotAllowed
+	}
+	permitted, err := perms.Has(ctx, r.permission)
+	if err != nil {
+		return err
+	}
+	if !permitted {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// Next returns the state a version moves to when the transition is made, once the transition has been checked and
+// any reason it requires has been given
+func Next(ctx context.Context, state string, t Transition, perms Permissions, reason string) (string, error) {
+	if err := Check(ctx, state, t, perms); err != nil {
+		return "", err
+	}
+	if t.RequiresReason() && reason == "" {
+		return "", ErrReasonRequired
+	}
+	return rules[t].to, nil
+}
@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-frontend-dataset-controller/permissions"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// grantedPermissions are the permissions a user has on the dataset
+type grantedPermissions map[string]bool
+
+func (g grantedPermissions) Has(_ context.Context, permission string) (bool, error) {
+	return g[permission], nil
+}
+
+type failingPermissions struct{}
+
+func (failingPermissions) Has(_ context.Context, _ string) (bool, error) {
+	return false, errors.New("permissions API unavailable")
+}
+
+var (
+	approver = grantedPermissions{permissions.DatasetsApprove: true, permissions.DatasetsEdit: true}
+	editor   = grantedPermissions{permissions.DatasetsEdit: true}
+)
+
+func TestParse(t *testing.T) {
+	Convey("Known transitions are parsed", t, func() {
+		tr, err := Parse("return-to-draft")
+		So(err, ShouldBeNil)
+		So(tr, ShouldEqual, ReturnToDraft)
+	})
+
+	Convey("Unknown transitions are rejected", t, func() {
+		_, err := Parse("publish")
+		So(err, ShouldEqual, ErrUnknownTransition)
+	})
+}
+
+func TestAvailable(t *testing.T) {
+	Convey("Given a version awaiting approval", t, func() {
+		Convey("Then a user who can approve the dataset can approve, reject or return it to draft", func() {
+			available, err := Available(t.Context(), models.AssociatedState, approver)
+			So(err, ShouldBeNil)
+			So(available, ShouldResemble, []Transition{Approve, Reject, ReturnToDraft})
+		})
+
+		Convey("Then a user who can only edit the dataset can only return it to draft", func() {
+			available, err := Available(t.Context(), models.AssociatedState, editor)
+			So(err, ShouldBeNil)
+			So(available, ShouldResemble, []Transition{ReturnToDraft})
+		})
+
+		Convey("Then a user without permissions on the dataset cannot change it", func() {
+			available, err := Available(t.Context(), models.AssociatedState, grantedPermissions{})
+			So(err, ShouldBeNil)
+			So(available, ShouldBeEmpty)
+		})
+
+		Convey("Then an error checking permissions is returned", func() {
+			_, err := Available(t.Context(), models.AssociatedState, failingPermissions{})
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an approved version", t, func() {
+		Convey("Then it can only be returned to draft", func() {
+			available, err := Available(t.Context(), models.ApprovedState, approver)
+			So(err, ShouldBeNil)
+			So(available, ShouldResemble, []Transition{ReturnToDraft})
+		})
+	})
+
+	Convey("Given a draft or published version", t, func() {
+		Convey("Then no transitions are available", func() {
+			for _, state := range []string{models.EditionConfirmedState, models.PublishedState} {
+				available, err := Available(t.Context(), state, approver)
+				So(err, ShouldBeNil)
+				So(available, ShouldBeEmpty)
+			}
+		})
+	})
+}
+
+func TestNext(t *testing.T) {
+	Convey("Approving a version awaiting approval moves it to approved", t, func() {
+		state, err := Next(t.Context(), models.AssociatedState, Approve, approver, "")
+		So(err, ShouldBeNil)
+		So(state, ShouldEqual, models.ApprovedState)
+	})
+
+	Convey("Rejecting a version moves it back to draft", t, func() {
+		state, err := Next(t.Context(), models.AssociatedState, Reject, approver, "Figures do not match the release")
+		So(err, ShouldBeNil)
+		So(state, ShouldEqual, models.EditionConfirmedState)
+	})
+
+	Convey("Rejecting a version without a reason fails", t, func() {
+		_, err := Next(t.Context(), models.AssociatedState, Reject, approver, "")
+		So(err, ShouldEqual, ErrReasonRequired)
+	})
+
+	Convey("Approving without permission to approve the dataset fails", t, func() {
+		_, err := Next(t.Context(), models.AssociatedState, Approve, editor, "")
+		So(err, ShouldEqual, ErrPermissionDenied)
+	})
+
+	Convey("Approving a published version fails", t, func() {
+		_, err := Next(t.Context(), models.PublishedState, Approve, approver, "")
+		So(err, ShouldEqual, ErrTransitionNotAllowed)
+	})
+}